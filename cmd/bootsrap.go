@@ -1,19 +1,58 @@
 package cmd
 
 import (
-	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
-	_ "github.com/lib/pq"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/dialect"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/logger"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/notifier"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/replica"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
+// InitLogger builds the logger of the Log* settings into cfg and makes it the default slog logger,
+// which the log package writes to as well.
+func InitLogger(cfg *config.MainConfig) *logger.Logger {
+	levels, err := logger.ParseLevels(cfg.LogLevel)
+	if err != nil {
+		fatal("invalid LOG_LEVEL", "error", err)
+	}
+
+	if _, ok := levels[logger.GormComponent]; cfg.LogMode && !ok {
+		levels[logger.GormComponent] = slog.LevelDebug
+	}
+
+	l, err := logger.New(os.Stderr, cfg.LogFormat, levels)
+	if err != nil {
+		fatal("invalid LOG_FORMAT", "error", err)
+	}
+
+	cfg.Logger = l
+	slog.SetDefault(l.For("app"))
+
+	return l
+}
+
+// fatal logs msg at error level and exits.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
 func InitElastic(cfg *config.MainConfig) *elasticsearch.Client {
 
 	es, err := elasticsearch.NewClient(elasticsearch.Config{
@@ -31,60 +70,112 @@ func InitElastic(cfg *config.MainConfig) *elasticsearch.Client {
 
 	res, err := es.Info()
 	if err != nil {
-		log.Fatalf("Error getting response: %s", err)
+		fatal("failed to connect to elastic", "error", err)
 	}
 
 	defer res.Body.Close()
-	log.Println(res)
 
-	log.Println("succesfully connected to elastic")
+	slog.Info("connected to elastic", "host", cfg.ElasticHost, "status", res.Status())
 
 	return es
 
 }
 
-func NewPostgres(cfg *config.MainConfig) *sql.DB {
-	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s "+
-		"password=%s dbname=%s sslmode=disable",
-		cfg.PostgresHost,
-		cfg.PostgresPort,
-		cfg.PostgresUsername,
-		cfg.PostgresPassword,
-		cfg.DBName,
-	)
-	db, err := sql.Open("postgres", psqlInfo)
-	if err != nil {
-		panic(err)
+// InitDatabase connects gorm to the database DB_TYPE picks, and to its read replicas when DB_REPLICAS lists any.
+func InitDatabase(cfg *config.MainConfig) *gorm.DB {
+	var dialector gorm.Dialector
+	switch cfg.DBType {
+	case dialect.Postgres:
+		dialector = newDialector(cfg, cfg.PostgresHost, cfg.PostgresPort)
+	case dialect.MySQL:
+		dialector = newDialector(cfg, cfg.MySQLHost, cfg.MySQLPort)
+	case dialect.SQLite:
+		dialector = sqlite.Open(cfg.SQLitePath + "?_foreign_keys=on")
+	default:
+		fatal("unknown database type", "type", cfg.DBType)
 	}
 
-	err = db.Ping()
-	if err != nil {
-		panic(err)
+	db := openDatabase(cfg, dialector)
+
+	slog.Info("connected to the database", "type", cfg.DBType)
+
+	if err := db.Use(metrics.Gorm{}); err != nil {
+		fatal("failed to time the database statements", "error", err)
+	}
+
+	rdb, _ := db.DB()
+	if err := metrics.RegisterDB("primary", rdb); err != nil {
+		fatal("failed to publish the connection pool stats", "error", err)
+	}
+
+	// sqlite takes one writer at a time, and every connection to :memory: opens a database of its own
+	if cfg.DBType == dialect.SQLite {
+		if len(cfg.DBReplicas) > 0 {
+			fatal("sqlite has no read replicas")
+		}
+
+		rdb.SetMaxOpenConns(1)
+		rdb.SetConnMaxLifetime(0)
+	}
+
+	if len(cfg.DBReplicas) == 0 {
+		return db
 	}
 
+	replicas := make([]*replica.Replica, 0, len(cfg.DBReplicas))
+	for _, addr := range cfg.DBReplicas {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			fatal("invalid replica address", "replica", addr, "error", err)
+		}
+
+		rdb, err := openDatabase(cfg, newDialector(cfg, host, port)).DB()
+		if err != nil {
+			fatal("failed to connect to the replica", "replica", addr, "error", err)
+		}
+
+		if err := metrics.RegisterDB(addr, rdb); err != nil {
+			fatal("failed to publish the connection pool stats", "replica", addr, "error", err)
+		}
+
+		replicas = append(replicas, &replica.Replica{Name: addr, DB: rdb})
+	}
+
+	if err := db.Use(replica.New(time.Duration(cfg.DBReplicaHealthInterval)*time.Second, replicas...)); err != nil {
+		fatal("failed to route reads to the replicas", "error", err)
+	}
+
+	slog.Info("reading from the database replicas", "replicas", len(replicas))
+
 	return db
 }
 
-// init postgress with gorm
-func InitPostgreSQL(cfg *config.MainConfig) *gorm.DB {
-	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=Asia/Jakarta", cfg.PostgresHost, cfg.PostgresUsername, cfg.PostgresPassword, cfg.DBName, cfg.PostgresPort)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+// newDialector returns the postgres or mysql dialector of the server at host and port.
+func newDialector(cfg *config.MainConfig, host, port string) gorm.Dialector {
+	if cfg.DBType == dialect.MySQL {
+		dsn := fmt.Sprintf("%v:%v@tcp(%v:%v)/%v?parseTime=true&loc=%v", cfg.MySQLUsername, cfg.MySQLPassword, host, port, cfg.MySQLDBName, url.QueryEscape(cfg.DBTimeZone))
+		return mysql.Open(dsn)
+	}
+
+	dsn := fmt.Sprintf("host=%v user=%v password=%v dbname=%v port=%v sslmode=disable TimeZone=%v", host, cfg.PostgresUsername, cfg.PostgresPassword, cfg.DBName, port, cfg.DBTimeZone)
+	return postgres.Open(dsn)
+}
+
+func openDatabase(cfg *config.MainConfig, dialector gorm.Dialector) *gorm.DB {
+	db, err := gorm.Open(dialector, &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			SingularTable: true,
 		},
 		SkipDefaultTransaction: true,
+		Logger:                 cfg.Logger.Gorm(time.Duration(cfg.LogSlowQuery) * time.Millisecond),
 	})
 	if err != nil {
-		log.Fatalf(err.Error())
-		panic(err)
+		fatal("failed to connect to the database", "error", err)
 	}
 
-	log.Printf("Successfully connected to database server")
-
 	rdb, err := db.DB()
 	if err != nil {
-		log.Fatalf(err.Error())
-		panic(err)
+		fatal("failed to connect to the database", "error", err)
 	}
 
 	rdb.SetMaxIdleConns(cfg.MaxIdleConns)
@@ -93,3 +184,68 @@ func InitPostgreSQL(cfg *config.MainConfig) *gorm.DB {
 
 	return db
 }
+
+// InitCache keeps entries in process only, a remote cache shared by the instances plugs into cache.New.
+// The hits and misses are published with the metrics.
+func InitCache(cfg *config.MainConfig) *cache.Cache {
+	c := cache.New(cfg.ServiceName, cache.NewLRU(cfg.CacheSize), nil, time.Duration(cfg.CacheTTL)*time.Second)
+
+	err := metrics.RegisterCache(func() map[string]metrics.CacheLookups {
+		lookups := make(map[string]metrics.CacheLookups)
+		for kind, stats := range c.Stats() {
+			lookups[kind] = metrics.CacheLookups{Hits: stats.Hits, Misses: stats.Misses}
+		}
+
+		return lookups
+	})
+	if err != nil {
+		fatal("failed to publish the cache lookups", "error", err)
+	}
+
+	return c
+}
+
+func InitExchangeRate(cfg *config.MainConfig) exchangerate.Provider {
+	switch cfg.ExchangeRateProvider {
+	case "file":
+		provider, err := exchangerate.NewFileProvider(cfg.ExchangeRateFile)
+		if err != nil {
+			fatal("failed to load the exchange rates", "file", cfg.ExchangeRateFile, "error", err)
+		}
+
+		return provider
+	default:
+		fatal("unknown exchange rate provider", "provider", cfg.ExchangeRateProvider)
+	}
+
+	return nil
+}
+
+func InitNotifier(cfg *config.MainConfig) notifier.Notifier {
+	var notifiers notifier.Multi
+	for _, name := range cfg.AlertNotifiers {
+		switch name {
+		case "log":
+			notifiers = append(notifiers, notifier.NewLogNotifier(cfg.Logger.For("notifier")))
+		case "webhook":
+			if cfg.AlertWebhookURL == "" {
+				fatal("ALERT_WEBHOOK_URL is required for the webhook notifier")
+			}
+
+			notifiers = append(notifiers, notifier.NewWebhookNotifier(cfg.AlertWebhookURL, time.Duration(cfg.AlertWebhookTimeout)*time.Second))
+		case "email":
+			notifiers = append(notifiers, notifier.NewEmailNotifier(notifier.SMTPConfig{
+				Host:     cfg.SMTPHost,
+				Port:     cfg.SMTPPort,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.AlertEmailFrom,
+				To:       cfg.AlertEmailTo,
+			}))
+		default:
+			fatal("unknown alert notifier", "notifier", name)
+		}
+	}
+
+	return notifiers
+}
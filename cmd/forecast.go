@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forecastBookID      int
+	forecastMethod      string
+	forecastHistory     int
+	forecastHorizon     int
+	forecastReorderOnly bool
+	forecastLimit       int
+)
+
+var forecastCommand = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast book demand and print the suggested reorders",
+	Run: func(_ *cobra.Command, _ []string) {
+		cfg := config.Get()
+		InitLogger(cfg)
+
+		forecastUseCase := usecase.NewForecastUseCase(cfg, repository.NewRepository(InitDatabase(cfg)))
+
+		resp, err := forecastUseCase.ForecastDemand(context.Background(), &domain.ForecastRequest{
+			BookID:      forecastBookID,
+			Method:      forecastMethod,
+			History:     forecastHistory,
+			Horizon:     forecastHorizon,
+			ReorderOnly: forecastReorderOnly,
+			Limit:       forecastLimit,
+		})
+		if err != nil {
+			fatal("failed to forecast demand", "error", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "BOOK\tTITLE\tPER DAY\tFORECAST\tAVAILABLE\tON ORDER\tLEAD DAYS\tREORDER POINT\tSUGGESTED\t")
+		for _, book := range resp.Books {
+			fmt.Fprintf(w, "%d\t%s\t%.2f\t%.2f\t%d\t%d\t%d\t%d\t%d\t\n", book.BookID, book.Title, book.Level, book.ForecastTotal,
+				book.Available, book.OnOrder, book.LeadTimeDays, book.SuggestedReorderPoint, book.SuggestedQuantity)
+		}
+		w.Flush()
+
+		fmt.Printf("%d of %d books shown\n", len(resp.Books), resp.Total)
+	},
+}
+
+func init() {
+	forecastCommand.Flags().IntVar(&forecastBookID, "book-id", 0, "book id, every book sold in the history when empty")
+	forecastCommand.Flags().StringVar(&forecastMethod, "method", "", "moving_average or exponential_smoothing")
+	forecastCommand.Flags().IntVar(&forecastHistory, "history", 0, "days of history")
+	forecastCommand.Flags().IntVar(&forecastHorizon, "horizon", 0, "days to forecast")
+	forecastCommand.Flags().BoolVar(&forecastReorderOnly, "reorder-only", false, "only the books to reorder")
+	forecastCommand.Flags().IntVar(&forecastLimit, "limit", 0, "number of books")
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelBookIDs  []int
+	labelCopies   int
+	labelOutput   string
+	barcodeBookID int
+	barcodeSymbol string
+	barcodeFormat string
+	barcodeOutput string
+	barcodeWidth  int
+	barcodeHeight int
+)
+
+var labelsCommand = &cobra.Command{
+	Use:   "labels",
+	Short: "Render an A4 pdf sheet of shelf labels",
+	Run: func(_ *cobra.Command, _ []string) {
+		labelUseCase := newLabelUseCase()
+
+		pdf, err := labelUseCase.RenderLabelSheet(context.Background(), &domain.LabelSheetRequest{
+			BookIDs: labelBookIDs,
+			Copies:  labelCopies,
+		})
+		if err != nil {
+			fatal("failed to render labels", "error", err)
+		}
+
+		if err := os.WriteFile(labelOutput, pdf, 0o644); err != nil {
+			fatal("failed to write the labels", "file", labelOutput, "error", err)
+		}
+
+		fmt.Printf("%d book labels written to %s\n", len(labelBookIDs), labelOutput)
+	},
+}
+
+var barcodeCommand = &cobra.Command{
+	Use:   "barcode",
+	Short: "Render a book barcode as png or svg",
+	Run: func(_ *cobra.Command, _ []string) {
+		labelUseCase := newLabelUseCase()
+
+		image, err := labelUseCase.RenderBarcode(context.Background(), &domain.BarcodeRequest{
+			BookID:    barcodeBookID,
+			Symbology: barcodeSymbol,
+			Format:    barcodeFormat,
+			Width:     barcodeWidth,
+			Height:    barcodeHeight,
+		})
+		if err != nil {
+			fatal("failed to render barcode", "error", err)
+		}
+
+		if err := os.WriteFile(barcodeOutput, image.Data, 0o644); err != nil {
+			fatal("failed to write the barcode", "file", barcodeOutput, "error", err)
+		}
+
+		fmt.Printf("barcode written to %s\n", barcodeOutput)
+	},
+}
+
+func init() {
+	labelsCommand.Flags().IntSliceVar(&labelBookIDs, "book-ids", nil, "comma separated book ids")
+	labelsCommand.Flags().IntVar(&labelCopies, "copies", 1, "labels per book")
+	labelsCommand.Flags().StringVarP(&labelOutput, "output", "o", "labels.pdf", "output file")
+	labelsCommand.MarkFlagRequired("book-ids")
+
+	barcodeCommand.Flags().IntVar(&barcodeBookID, "book-id", 0, "book id")
+	barcodeCommand.Flags().StringVar(&barcodeSymbol, "symbology", "code128", "code128, ean13 or qr")
+	barcodeCommand.Flags().StringVar(&barcodeFormat, "format", "png", "png or svg")
+	barcodeCommand.Flags().IntVar(&barcodeWidth, "width", 0, "width in pixels")
+	barcodeCommand.Flags().IntVar(&barcodeHeight, "height", 0, "height in pixels")
+	barcodeCommand.Flags().StringVarP(&barcodeOutput, "output", "o", "barcode.png", "output file")
+	barcodeCommand.MarkFlagRequired("book-id")
+}
+
+func newLabelUseCase() usecase.LabelUseCaseImpl {
+	cfg := config.Get()
+	InitLogger(cfg)
+
+	repo := repository.NewRepository(InitDatabase(cfg))
+
+	return usecase.NewLabelUseCase(cfg, repo)
+}
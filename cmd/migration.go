@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"log"
+	"fmt"
 
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	migration "github.com/imanudd/inventorySvc-clean-architecture/database"
@@ -11,7 +11,7 @@ import (
 var migrateCmd = &cobra.Command{
 	Use: "migrate",
 	Run: func(_ *cobra.Command, _ []string) {
-		log.Println("use -h to show available commands")
+		fmt.Println("use -h to show available commands")
 	},
 }
 
@@ -38,7 +38,12 @@ var migrateFreshCmd = &cobra.Command{
 
 func startMigrate(migrationType string) {
 	cfg := config.Get()
-	db := NewPostgres(cfg)
+	InitLogger(cfg)
+
+	db, err := InitDatabase(cfg).DB()
+	if err != nil {
+		fatal("failed to get the database connection", "error", err)
+	}
 
 	m := migration.New(cfg, db)
 	m.Start(migrationType)
@@ -1,11 +1,12 @@
 package cmd
 
 import (
-	"log"
+	"context"
 
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	rest "github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/cached"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/usecase"
 	"github.com/spf13/cobra"
 )
@@ -14,12 +15,9 @@ var restCommand = &cobra.Command{
 	Use: "rest",
 	Run: func(cmd *cobra.Command, args []string) {
 		cfg := config.Get()
+		InitLogger(cfg)
 
-		pgDB := InitPostgreSQL(cfg)
-
-		if cfg.LogMode {
-			pgDB = pgDB.Debug()
-		}
+		db := InitDatabase(cfg)
 
 		// client := InitElastic(cfg)
 
@@ -27,8 +25,12 @@ var restCommand = &cobra.Command{
 		// es := elasticsearch.New(client)
 
 		app := rest.NewRest(cfg)
-		repo := repository.NewRepository(pgDB)
-		useCase := usecase.NewUsecase(cfg, repo)
+		repo := repository.NewRepository(db)
+		if cfg.CacheSize > 0 {
+			repo = cached.NewRepository(repo, InitCache(cfg))
+		}
+
+		useCase := usecase.NewUsecase(cfg, repo, InitExchangeRate(cfg), InitNotifier(cfg))
 
 		route := &rest.Route{
 			Config:     cfg,
@@ -39,8 +41,16 @@ var restCommand = &cobra.Command{
 
 		route.RegisterRoutes()
 
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go runPriceScheduler(ctx, cfg, useCase.GetPriceUseCase())
+		go runStockAlertEvaluator(ctx, cfg, useCase.GetStockAlertUseCase())
+		go runAnalyticsRefresher(ctx, cfg, useCase.GetAnalyticsUseCase())
+		go runIdempotencyKeyPurger(ctx, cfg, repo.GetIdempotencyRepo())
+
 		if err := rest.Serve(app, cfg); err != nil {
-			log.Fatalf("Failed to start server: %v\n", err)
+			fatal("failed to start the server", "error", err)
 		}
 
 	},
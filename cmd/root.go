@@ -23,6 +23,9 @@ func Run() {
 
 	rootCommand.AddCommand(migrateCmd)
 	rootCommand.AddCommand(restCommand)
+	rootCommand.AddCommand(labelsCommand)
+	rootCommand.AddCommand(barcodeCommand)
+	rootCommand.AddCommand(forecastCommand)
 
 	if err := rootCommand.Execute(); err != nil {
 		log.Fatal(err)
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/usecase"
+)
+
+// runPriceScheduler applies due scheduled prices every PRICE_SCHEDULER_INTERVAL seconds until ctx is done.
+func runPriceScheduler(ctx context.Context, cfg *config.MainConfig, priceUseCase usecase.PriceUseCaseImpl) {
+	if cfg.PriceSchedulerInterval <= 0 {
+		return
+	}
+
+	logger := cfg.Logger.For("scheduler").With("job", "prices")
+
+	ticker := time.NewTicker(time.Duration(cfg.PriceSchedulerInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			applied, err := priceUseCase.ApplyScheduledPrices(ctx, now)
+			if err != nil {
+				logger.Error("failed to apply scheduled prices", "error", err)
+			}
+
+			if applied > 0 {
+				logger.Info("scheduled prices applied", "applied", applied)
+			}
+		}
+	}
+}
+
+// runStockAlertEvaluator raises and resolves low stock alerts every STOCK_ALERT_INTERVAL seconds until ctx is done.
+func runStockAlertEvaluator(ctx context.Context, cfg *config.MainConfig, stockAlertUseCase usecase.StockAlertUseCaseImpl) {
+	if cfg.StockAlertInterval <= 0 {
+		return
+	}
+
+	logger := cfg.Logger.For("scheduler").With("job", "stock_alerts")
+
+	ticker := time.NewTicker(time.Duration(cfg.StockAlertInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			raised, err := stockAlertUseCase.EvaluateStockAlerts(ctx, now)
+			if err != nil {
+				logger.Error("failed to evaluate stock alerts", "error", err)
+			}
+
+			if raised > 0 {
+				logger.Info("low stock alerts raised", "raised", raised)
+			}
+		}
+	}
+}
+
+// runAnalyticsRefresher refreshes the analytics rollups on start and then every ANALYTICS_REFRESH_INTERVAL
+// seconds until ctx is done.
+func runAnalyticsRefresher(ctx context.Context, cfg *config.MainConfig, analyticsUseCase usecase.AnalyticsUseCaseImpl) {
+	if cfg.AnalyticsRefreshInterval <= 0 {
+		return
+	}
+
+	logger := cfg.Logger.For("scheduler").With("job", "analytics")
+
+	refresh := func(now time.Time) {
+		if err := analyticsUseCase.RefreshAnalytics(ctx, now); err != nil {
+			logger.Error("failed to refresh analytics", "error", err)
+		}
+	}
+
+	refresh(time.Now())
+
+	ticker := time.NewTicker(time.Duration(cfg.AnalyticsRefreshInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			refresh(now)
+		}
+	}
+}
+
+// runIdempotencyKeyPurger deletes expired idempotency keys every IDEMPOTENCY_PURGE_INTERVAL seconds until ctx is done.
+func runIdempotencyKeyPurger(ctx context.Context, cfg *config.MainConfig, idempotencyRepo repository.IdempotencyRepositoryImpl) {
+	if cfg.IdempotencyPurgeInterval <= 0 {
+		return
+	}
+
+	logger := cfg.Logger.For("scheduler").With("job", "idempotency_keys")
+
+	ticker := time.NewTicker(time.Duration(cfg.IdempotencyPurgeInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := idempotencyRepo.DeleteExpired(ctx, now); err != nil {
+				logger.Error("failed to purge expired idempotency keys", "error", err)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/logger"
 	"github.com/kelseyhightower/envconfig"
 )
 
@@ -9,16 +10,52 @@ type MainConfig struct {
 	ServicePort int    `envconfig:"HTTP_PORT" default:"8000"`
 	Environment string `envconfig:"ENVIRONMENT" default:"development"`
 
+	// DBType picks the database, postgres, mysql or sqlite
+	DBType string `envconfig:"DB_TYPE" default:"postgres"`
+	// DBTimeZone is the time zone timestamps are written in by postgres and mysql
+	DBTimeZone string `envconfig:"DB_TIMEZONE" default:"Asia/Jakarta"`
+
 	PostgresHost     string `envconfig:"PGSQL_HOST" default:"localhost"`
 	PostgresPort     string `envconfig:"PGSQL_PORT" default:"5432"`
-	DBType           string `envconfig:"DB_TYPE" default:"postgres"`
 	PostgresUsername string `envconfig:"PGSQL_USERNAME" default:"root"`
 	PostgresPassword string `envconfig:"PGSQL_PASSWORD" default:"root"`
 	DBName           string `envconfig:"PGSQL_DBNAME" default:"inventorybook"`
-	LogMode          bool   `envconfig:"LOG_MODE" default:"true"`
-	MaxIdleConns     int    `envconfig:"MAX_IDLE_CONNS" default:"10"`
-	MaxOpenConns     int    `envconfig:"MAX_OPEN_CONNS" default:"10"`
-	ConnMaxLifetime  int    `envconfig:"CONN_MAX_LIFETIME" default:"60"`
+
+	MySQLHost     string `envconfig:"MYSQL_HOST" default:"localhost"`
+	MySQLPort     string `envconfig:"MYSQL_PORT" default:"3306"`
+	MySQLUsername string `envconfig:"MYSQL_USERNAME" default:"root"`
+	MySQLPassword string `envconfig:"MYSQL_PASSWORD" default:"root"`
+	MySQLDBName   string `envconfig:"MYSQL_DBNAME" default:"inventorybook"`
+
+	// DBReplicas lists the host:port of the read replicas of postgres or mysql, they share the credentials
+	// and database name of the primary. Reads outside a transaction go to a healthy replica, to the primary
+	// when none is. DBReplicaHealthInterval is how many seconds pass between health checks of the replicas
+	DBReplicas              []string `envconfig:"DB_REPLICAS"`
+	DBReplicaHealthInterval int      `envconfig:"DB_REPLICA_HEALTH_INTERVAL" default:"10"`
+
+	// SQLitePath is the database file, :memory: keeps the database in memory until the service stops
+	SQLitePath string `envconfig:"SQLITE_PATH" default:"inventorybook.db"`
+
+	// LogMode logs every SQL statement, as gorm=debug in LOG_LEVEL does, unless LOG_LEVEL sets a level for gorm
+	LogMode         bool `envconfig:"LOG_MODE" default:"true"`
+	MaxIdleConns    int  `envconfig:"MAX_IDLE_CONNS" default:"10"`
+	MaxOpenConns    int  `envconfig:"MAX_OPEN_CONNS" default:"10"`
+	ConnMaxLifetime int  `envconfig:"CONN_MAX_LIFETIME" default:"60"`
+
+	// LogFormat is json or text. LogLevel is the default level followed by the levels of components, as in
+	// info,gorm=debug,http=warn, they can be changed while the rest server runs through /inventorysvc/admin/log-levels.
+	// LogSlowQuery is how many milliseconds make a SQL statement slow, slow statements are logged at warn level
+	LogFormat    string `envconfig:"LOG_FORMAT" default:"json"`
+	LogLevel     string `envconfig:"LOG_LEVEL" default:"info"`
+	LogSlowQuery int    `envconfig:"LOG_SLOW_QUERY" default:"200"`
+
+	// Logger is built from the Log* settings by InitLogger, components take their logger from it
+	Logger *logger.Logger `ignored:"true"`
+
+	// CacheSize is how many books, authors and users the rest server keeps in memory, 0 turns the cache off.
+	// CacheTTL is how many seconds an entry lives, it bounds how long another instance serves a changed row
+	CacheSize int `envconfig:"CACHE_SIZE" default:"10000"`
+	CacheTTL  int `envconfig:"CACHE_TTL" default:"60"`
 
 	ElasticHost          string `envconfig:"ELASTIC_HOST" default:"https://localhost:9200"`
 	ElasticUsername      string `envconfig:"ELASTIC_USERNAME" default:"-"`
@@ -26,6 +63,58 @@ type MainConfig struct {
 	ElasticCAFingerprint string `envconfig:"ELASTIC_CACERT" default:"-"`
 
 	SignatureKey string `envconfig:"JWT_SECRET_KEY" default:"secret"`
+	// AdminToken guards the /inventorysvc/admin routes, sent in the X-Admin-Token header. The routes are not served when it is empty
+	AdminToken string `envconfig:"ADMIN_TOKEN"`
+
+	// IdempotencyKeyTTL is how many seconds a stored response is replayed for a retried Idempotency-Key
+	IdempotencyKeyTTL int `envconfig:"IDEMPOTENCY_KEY_TTL" default:"86400"`
+	// IdempotencyPurgeInterval is how many seconds the rest server waits between deleting expired idempotency keys, 0 disables it
+	IdempotencyPurgeInterval int `envconfig:"IDEMPOTENCY_PURGE_INTERVAL" default:"3600"`
+
+	// SKUPrefix starts the SKU generated for new books, the books migrated by 000018 got the default BK
+	SKUPrefix string `envconfig:"SKU_PREFIX" default:"BK"`
+
+	// DefaultCurrency prices requests that send a bare number instead of an amount with currency
+	DefaultCurrency      string `envconfig:"DEFAULT_CURRENCY" default:"IDR"`
+	ExchangeRateProvider string `envconfig:"EXCHANGE_RATE_PROVIDER" default:"file"`
+	ExchangeRateFile     string `envconfig:"EXCHANGE_RATE_FILE" default:"config/exchange_rates.json"`
+
+	// PriceSchedulerInterval is how many seconds the rest server waits between applying due scheduled prices, 0 disables it
+	PriceSchedulerInterval int `envconfig:"PRICE_SCHEDULER_INTERVAL" default:"60"`
+
+	// ValuationMethod is the costing method of the valuation reports when a request names none: fifo or weighted_average
+	ValuationMethod string `envconfig:"VALUATION_METHOD" default:"fifo"`
+
+	// AnalyticsRefreshInterval is how many seconds the rest server waits between analytics rollup refreshes, 0 disables it
+	AnalyticsRefreshInterval int `envconfig:"ANALYTICS_REFRESH_INTERVAL" default:"900"`
+
+	// Forecast* are the defaults of demand forecasts, history, horizon, lead and review times are in days.
+	// ForecastServiceLevelZ is the number of standard deviations of demand the safety stock covers, 1.65 for 95%
+	ForecastMethod        string  `envconfig:"FORECAST_METHOD" default:"exponential_smoothing"`
+	ForecastWindow        int     `envconfig:"FORECAST_WINDOW" default:"28"`
+	ForecastAlpha         float64 `envconfig:"FORECAST_ALPHA" default:"0.3"`
+	ForecastSeason        int     `envconfig:"FORECAST_SEASON" default:"7"`
+	ForecastHistoryDays   int     `envconfig:"FORECAST_HISTORY_DAYS" default:"182"`
+	ForecastHorizonDays   int     `envconfig:"FORECAST_HORIZON_DAYS" default:"28"`
+	ForecastLeadTimeDays  int     `envconfig:"FORECAST_LEAD_TIME_DAYS" default:"14"`
+	ForecastReviewDays    int     `envconfig:"FORECAST_REVIEW_DAYS" default:"7"`
+	ForecastServiceLevelZ float64 `envconfig:"FORECAST_SERVICE_LEVEL_Z" default:"1.65"`
+
+	// StockAlertInterval is how many seconds the rest server waits between low stock evaluations, 0 disables it
+	StockAlertInterval int `envconfig:"STOCK_ALERT_INTERVAL" default:"300"`
+	// StockAlertAutoDraft drafts purchase orders for low stock books whose reorder point names a supplier
+	StockAlertAutoDraft bool `envconfig:"STOCK_ALERT_AUTO_DRAFT" default:"false"`
+
+	// AlertNotifiers lists where alerts go: log, webhook and email
+	AlertNotifiers      []string `envconfig:"ALERT_NOTIFIERS" default:"log"`
+	AlertWebhookURL     string   `envconfig:"ALERT_WEBHOOK_URL"`
+	AlertWebhookTimeout int      `envconfig:"ALERT_WEBHOOK_TIMEOUT" default:"5"`
+	SMTPHost            string   `envconfig:"SMTP_HOST" default:"localhost"`
+	SMTPPort            int      `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername        string   `envconfig:"SMTP_USERNAME"`
+	SMTPPassword        string   `envconfig:"SMTP_PASSWORD"`
+	AlertEmailFrom      string   `envconfig:"ALERT_EMAIL_FROM" default:"inventorybook@localhost"`
+	AlertEmailTo        []string `envconfig:"ALERT_EMAIL_TO"`
 }
 
 func Get() *MainConfig {
@@ -1,9 +1,13 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"embed"
 	"fmt"
+
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/dialect"
 	migrate "github.com/rubenv/sql-migrate"
 )
 
@@ -13,6 +17,20 @@ const (
 	MIGRATION_TYPE_FRESH = "fresh"
 )
 
+// migrations keeps a directory of migrations per DB_TYPE. Postgres has the whole history, the other
+// dialects start from the schema as it was when they were added. Number a new migration after the
+// latest of all dialects and add it to every one of them.
+//
+//go:embed migration
+var migrations embed.FS
+
+// migrateDialects are the sql-migrate names of the dialects
+var migrateDialects = map[string]string{
+	dialect.Postgres: "postgres",
+	dialect.MySQL:    "mysql",
+	dialect.SQLite:   "sqlite3",
+}
+
 type Migration struct {
 	cfg *config.MainConfig
 	db  *sql.DB
@@ -26,7 +44,22 @@ func New(cfg *config.MainConfig, db *sql.DB) *Migration {
 }
 
 func (m *Migration) Start(migrationType string) {
-	migrations := &migrate.FileMigrationSource{Dir: "./database/migration"}
+	count, err := m.Migrate(migrationType)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("applied %d migrations to database\n", count)
+}
+
+// Migrate applies the migrations of the configured dialect and returns how many it applied.
+func (m *Migration) Migrate(migrationType string) (int, error) {
+	name, ok := migrateDialects[m.cfg.DBType]
+	if !ok {
+		return 0, fmt.Errorf("unknown database type %q", m.cfg.DBType)
+	}
+
+	source := &migrate.EmbedFileSystemMigrationSource{FileSystem: migrations, Root: "migration/" + m.cfg.DBType}
 
 	var direction migrate.MigrationDirection
 
@@ -37,22 +70,74 @@ func (m *Migration) Start(migrationType string) {
 		direction = migrate.Down
 	case MIGRATION_TYPE_FRESH:
 		if m.cfg.Environment == "production" {
-			fmt.Print("cannot migrate fresh in production")
-			return
+			return 0, fmt.Errorf("cannot migrate fresh in production")
 		}
 
 		fmt.Println("drop schema !!!")
-		_, err := m.db.Exec("drop schema public cascade; create schema public;")
-		if err != nil {
-			panic(err)
+		if err := m.dropAll(); err != nil {
+			return 0, err
 		}
 
 		direction = migrate.Up
+	default:
+		return 0, fmt.Errorf("unknown migration type %q", migrationType)
+	}
+
+	return migrate.Exec(m.db, name, source, direction)
+}
+
+// dropAll drops every table, the migration records included.
+func (m *Migration) dropAll() error {
+	if m.cfg.DBType == dialect.Postgres {
+		_, err := m.db.Exec("drop schema public cascade; create schema public;")
+		return err
 	}
 
-	count, err := migrate.Exec(m.db, "postgres", migrations, direction)
+	ctx := context.Background()
+
+	// foreign key checks are off for the connection only, keep to one
+	conn, err := m.db.Conn(ctx)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	fmt.Printf("applied %d migrations to database\n", count)
+	defer conn.Close()
+
+	list, checks := "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE()", "SET FOREIGN_KEY_CHECKS = %d"
+	if m.cfg.DBType == dialect.SQLite {
+		list, checks = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'", "PRAGMA foreign_keys = %d"
+	}
+
+	rows, err := conn.QueryContext(ctx, list)
+	if err != nil {
+		return err
+	}
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			rows.Close()
+			return err
+		}
+
+		tables = append(tables, table)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf(checks, 0)); err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := conn.ExecContext(ctx, "DROP TABLE IF EXISTS "+table); err != nil {
+			return err
+		}
+	}
+
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(checks, 1))
+	return err
 }
@@ -0,0 +1,32 @@
+-- +migrate Down
+DROP TABLE IF EXISTS analytics_refreshes;
+DROP TABLE IF EXISTS book_stock_daily;
+DROP TABLE IF EXISTS book_sales_daily;
+DROP TABLE IF EXISTS stock_alerts;
+DROP TABLE IF EXISTS reorder_points;
+DROP TABLE IF EXISTS cycle_count_lines;
+DROP TABLE IF EXISTS cycle_counts;
+DROP TABLE IF EXISTS rma_lines;
+DROP TABLE IF EXISTS rmas;
+DROP TABLE IF EXISTS order_lines;
+DROP TABLE IF EXISTS orders;
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS goods_receipt_lines;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
+DROP TABLE IF EXISTS promotions;
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS scheduled_prices;
+DROP TABLE IF EXISTS prices;
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS book_categories;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS book_contributors;
+DROP TABLE IF EXISTS idempotency_keys;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS authors;
+DROP TABLE IF EXISTS books;
+DROP TABLE IF EXISTS publishers;
@@ -0,0 +1,426 @@
+-- +migrate Up
+-- mysql starts from the schema of postgres migration 000031, later migrations are added to every dialect.
+-- MySQL ignores inline REFERENCES, the foreign keys are declared at the end of the tables.
+CREATE TABLE IF NOT EXISTS publishers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    website VARCHAR(255),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE UNIQUE INDEX idx_publishers_name ON publishers ((LOWER(name)));
+
+CREATE TABLE IF NOT EXISTS books (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    book_name VARCHAR(255),
+    title VARCHAR(255),
+    price_amount BIGINT,
+    price_currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    isbn13 VARCHAR(13),
+    isbn10 VARCHAR(10),
+    sku VARCHAR(32),
+    barcode VARCHAR(13),
+    location VARCHAR(50),
+    publisher_id INT,
+    stock INT NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    created_at DATETIME(6),
+    FOREIGN KEY (publisher_id) REFERENCES publishers (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX uq_books_isbn13 ON books (isbn13);
+CREATE UNIQUE INDEX uq_books_sku ON books (sku);
+CREATE UNIQUE INDEX uq_books_barcode ON books (barcode);
+CREATE INDEX idx_books_publisher_id ON books (publisher_id);
+
+CREATE TABLE IF NOT EXISTS authors (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    phone_number VARCHAR(100)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    username VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    password VARCHAR(100)
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    idempotency_key VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INT,
+    content_type VARCHAR(100),
+    response_body TEXT,
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL,
+    UNIQUE (idempotency_key, user_id)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS book_contributors (
+    book_id INT NOT NULL,
+    author_id INT NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'author',
+    position INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (book_id, author_id, role),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (author_id) REFERENCES authors (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_contributors_author_id ON book_contributors (author_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    parent_id INT,
+    name VARCHAR(100) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE RESTRICT
+);
+
+-- sibling categories must have distinct names, root categories share parent 0
+CREATE UNIQUE INDEX idx_categories_parent_name ON categories ((COALESCE(parent_id, 0)), (LOWER(name)));
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id INT NOT NULL,
+    category_id INT NOT NULL,
+    PRIMARY KEY (book_id, category_id),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (category_id) REFERENCES categories (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_categories_category_id ON book_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id INT NOT NULL,
+    tag_id INT NOT NULL,
+    PRIMARY KEY (book_id, tag_id),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_tags_tag_id ON book_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS prices (
+    book_id INT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (book_id, currency),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    book_id INT NOT NULL,
+    price_amount BIGINT NOT NULL CHECK (price_amount > 0),
+    price_currency CHAR(3) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    effective_at DATETIME(6) NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    applied_at DATETIME(6),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE INDEX scheduled_prices_due_idx ON scheduled_prices (status, effective_at);
+
+CREATE TABLE IF NOT EXISTS price_history (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    book_id INT NOT NULL,
+    kind VARCHAR(8) NOT NULL,
+    old_amount BIGINT,
+    old_currency CHAR(3),
+    new_amount BIGINT,
+    new_currency CHAR(3),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    scheduled_price_id INT,
+    changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (scheduled_price_id) REFERENCES scheduled_prices (id) ON DELETE SET NULL
+);
+
+CREATE INDEX price_history_book_id_idx ON price_history (book_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('percentage', 'fixed_amount', 'bundle')),
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('all', 'book', 'author', 'category')),
+    scope_id INT,
+    percentage INT NOT NULL DEFAULT 0 CHECK (percentage BETWEEN 0 AND 100),
+    discount_amount BIGINT NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    discount_currency VARCHAR(3) NOT NULL DEFAULT '',
+    buy_quantity INT NOT NULL DEFAULT 0,
+    pay_quantity INT NOT NULL DEFAULT 0,
+    starts_at DATETIME(6) NOT NULL,
+    ends_at DATETIME(6),
+    priority INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CHECK ((scope = 'all') = (scope_id IS NULL)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX promotions_active_idx ON promotions (active, starts_at, ends_at);
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone_number VARCHAR(50) NOT NULL DEFAULT '',
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    lead_time_days INT NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
+
+CREATE UNIQUE INDEX idx_suppliers_name ON suppliers ((LOWER(name)));
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    supplier_id INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'submitted', 'partially_received', 'received', 'cancelled')),
+    currency CHAR(3) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    submitted_at DATETIME(6),
+    closed_at DATETIME(6),
+    FOREIGN KEY (supplier_id) REFERENCES suppliers (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    purchase_order_id INT NOT NULL,
+    book_id INT NOT NULL,
+    quantity_ordered INT NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INT NOT NULL DEFAULT 0 CHECK (quantity_received BETWEEN 0 AND quantity_ordered),
+    unit_cost_amount BIGINT NOT NULL CHECK (unit_cost_amount >= 0),
+    unit_cost_currency CHAR(3) NOT NULL,
+    UNIQUE (purchase_order_id, book_id),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    purchase_order_id INT NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    received_by VARCHAR(255) NOT NULL DEFAULT '',
+    received_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    goods_receipt_id INT NOT NULL,
+    purchase_order_line_id INT NOT NULL,
+    book_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_cost_amount BIGINT NOT NULL,
+    unit_cost_currency CHAR(3) NOT NULL,
+    FOREIGN KEY (goods_receipt_id) REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    FOREIGN KEY (purchase_order_line_id) REFERENCES purchase_order_lines (id) ON DELETE RESTRICT,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    book_id INT NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity <> 0),
+    unit_cost_amount BIGINT NOT NULL DEFAULT 0,
+    unit_cost_currency VARCHAR(3) NOT NULL DEFAULT '',
+    reference_type VARCHAR(30) NOT NULL DEFAULT '',
+    reference_id INT,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_stock_movements_book_id ON stock_movements (book_id, created_at);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    customer_name VARCHAR(100) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    shipping_address VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'picked', 'shipped', 'delivered', 'cancelled', 'returned')),
+    currency CHAR(3) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    paid_at DATETIME(6),
+    picked_at DATETIME(6),
+    shipped_at DATETIME(6),
+    delivered_at DATETIME(6),
+    cancelled_at DATETIME(6),
+    returned_at DATETIME(6)
+);
+
+CREATE INDEX idx_orders_customer_email ON orders ((LOWER(customer_email)));
+CREATE INDEX idx_orders_status ON orders (status);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_id INT NOT NULL,
+    book_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price_amount BIGINT NOT NULL CHECK (unit_price_amount >= 0),
+    unit_price_currency CHAR(3) NOT NULL,
+    UNIQUE (order_id, book_id),
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE RESTRICT
+);
+
+CREATE TABLE IF NOT EXISTS rmas (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    order_id INT NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    closed_at DATETIME(6),
+    FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_rmas_order_id ON rmas (order_id);
+CREATE INDEX idx_rmas_customer_email ON rmas ((LOWER(customer_email)));
+
+CREATE TABLE IF NOT EXISTS rma_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    rma_id INT NOT NULL,
+    order_line_id INT NOT NULL,
+    book_id INT NOT NULL,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'defective', 'wrong_book', 'unwanted', 'other')),
+    unit_price_amount BIGINT NOT NULL,
+    unit_price_currency CHAR(3) NOT NULL,
+    outcome VARCHAR(20) NOT NULL DEFAULT ''
+        CHECK (outcome IN ('', 'restock', 'write_off', 'return_to_supplier')),
+    refund_amount BIGINT NOT NULL DEFAULT 0 CHECK (refund_amount >= 0),
+    refund_currency VARCHAR(3) NOT NULL DEFAULT '',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_by VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_at DATETIME(6),
+    UNIQUE (rma_id, book_id),
+    FOREIGN KEY (rma_id) REFERENCES rmas (id) ON DELETE CASCADE,
+    FOREIGN KEY (order_line_id) REFERENCES order_lines (id) ON DELETE RESTRICT,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_rma_lines_book_id ON rma_lines (book_id);
+
+CREATE TABLE IF NOT EXISTS cycle_counts (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    location VARCHAR(50) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    version INT NOT NULL DEFAULT 1,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    approved_by VARCHAR(255) NOT NULL DEFAULT '',
+    closed_at DATETIME(6)
+);
+
+CREATE INDEX idx_cycle_counts_status ON cycle_counts (status);
+
+CREATE TABLE IF NOT EXISTS cycle_count_lines (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    cycle_count_id INT NOT NULL,
+    book_id INT NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    expected INT NOT NULL,
+    counted INT CHECK (counted >= 0),
+    counted_by VARCHAR(255) NOT NULL DEFAULT '',
+    counted_at DATETIME(6),
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    -- MySQL has no partial indexes, the unique index on open_book_id skips the nulls of closed lines
+    open_book_id INT AS (CASE WHEN NOT closed THEN book_id END) VIRTUAL,
+    UNIQUE (cycle_count_id, book_id),
+    FOREIGN KEY (cycle_count_id) REFERENCES cycle_counts (id) ON DELETE CASCADE,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_cycle_count_lines_open_book ON cycle_count_lines (open_book_id);
+
+CREATE TABLE IF NOT EXISTS reorder_points (
+    book_id INT PRIMARY KEY,
+    reorder_point INT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INT NOT NULL CHECK (reorder_quantity > 0),
+    supplier_id INT,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (supplier_id) REFERENCES suppliers (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    book_id INT NOT NULL,
+    available INT NOT NULL,
+    reorder_point INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    purchase_order_id INT,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    resolved_at DATETIME(6),
+    open_book_id INT AS (CASE WHEN status = 'open' THEN book_id END) VIRTUAL,
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE,
+    FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_stock_alerts_open_book ON stock_alerts (open_book_id);
+
+CREATE TABLE IF NOT EXISTS book_sales_daily (
+    book_id INT NOT NULL,
+    day DATE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    revenue_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, day, currency),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_sales_daily_day ON book_sales_daily (day);
+
+CREATE TABLE IF NOT EXISTS book_stock_daily (
+    book_id INT NOT NULL,
+    day DATE NOT NULL,
+    stock_in INT NOT NULL DEFAULT 0,
+    stock_out INT NOT NULL DEFAULT 0,
+    closing_stock INT NOT NULL,
+    PRIMARY KEY (book_id, day),
+    FOREIGN KEY (book_id) REFERENCES books (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_book_stock_daily_day ON book_stock_daily (day);
+
+CREATE TABLE IF NOT EXISTS analytics_refreshes (
+    name VARCHAR(50) PRIMARY KEY,
+    refreshed_through DATE NOT NULL,
+    refreshed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+);
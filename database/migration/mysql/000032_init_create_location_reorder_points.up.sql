@@ -0,0 +1,10 @@
+-- +migrate Up
+-- the reorder point of the books shelved at a location that have none of their own
+CREATE TABLE IF NOT EXISTS location_reorder_points (
+    location VARCHAR(50) PRIMARY KEY,
+    reorder_point INT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INT NOT NULL CHECK (reorder_quantity > 0),
+    supplier_id INT,
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    FOREIGN KEY (supplier_id) REFERENCES suppliers (id) ON DELETE SET NULL
+);
@@ -0,0 +1,10 @@
+-- +migrate Down
+DROP INDEX IF EXISTS uq_books_barcode;
+DROP INDEX IF EXISTS uq_books_sku;
+DROP INDEX IF EXISTS uq_books_isbn13;
+
+ALTER TABLE books
+    DROP COLUMN IF EXISTS barcode,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS isbn10,
+    DROP COLUMN IF EXISTS isbn13;
@@ -0,0 +1,23 @@
+-- +migrate Up
+ALTER TABLE books
+    ADD COLUMN IF NOT EXISTS isbn13 VARCHAR(13),
+    ADD COLUMN IF NOT EXISTS isbn10 VARCHAR(10),
+    ADD COLUMN IF NOT EXISTS sku VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS barcode VARCHAR(13);
+
+-- existing books get the SKU of their zero padded id under the default SKU_PREFIX, BK. Deployments with
+-- another SKU_PREFIX keep these SKUs and only books created afterwards carry their own prefix
+UPDATE books SET sku = 'BK-' || LPAD(id::text, 8, '0') WHERE sku IS NULL;
+
+-- existing books get an internal barcode in the range identifier.GenerateBarcode draws from: prefix 21,
+-- ten digits and the EAN-13 check digit, the digits are the zero padded id instead of random ones
+UPDATE books SET barcode = b.base || ((10 - (
+        SELECT SUM(SUBSTR(b.base, i, 1)::int * CASE WHEN i % 2 = 0 THEN 3 ELSE 1 END)
+        FROM generate_series(1, 12) i
+    ) % 10) % 10)::text
+FROM (SELECT id, '21' || LPAD(id::text, 10, '0') AS base FROM books) b
+WHERE books.id = b.id AND books.barcode IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_isbn13 ON books (isbn13);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_sku ON books (sku);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_barcode ON books (barcode);
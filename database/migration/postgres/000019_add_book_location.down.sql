@@ -0,0 +1,2 @@
+-- +migrate Down
+ALTER TABLE books DROP COLUMN IF EXISTS location;
@@ -0,0 +1,2 @@
+-- +migrate Up
+ALTER TABLE books ADD COLUMN IF NOT EXISTS location VARCHAR(50);
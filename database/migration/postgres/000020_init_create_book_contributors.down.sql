@@ -0,0 +1,12 @@
+-- +migrate Down
+ALTER TABLE books ADD COLUMN IF NOT EXISTS author_id INT;
+
+UPDATE books SET author_id = c.author_id
+FROM (
+    SELECT DISTINCT ON (book_id) book_id, author_id
+    FROM book_contributors
+    ORDER BY book_id, (role <> 'author'), position
+) c
+WHERE books.id = c.book_id;
+
+DROP TABLE IF EXISTS book_contributors;
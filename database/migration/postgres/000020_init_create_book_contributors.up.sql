@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS book_contributors (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    author_id INT NOT NULL REFERENCES authors (id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'author',
+    position INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (book_id, author_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_contributors_author_id ON book_contributors (author_id);
+
+-- books pointing at an author that no longer exists are left without contributors
+INSERT INTO book_contributors (book_id, author_id, role, position)
+SELECT b.id, b.author_id, 'author', 1
+FROM books b
+JOIN authors a ON a.id = b.author_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE books DROP COLUMN IF EXISTS author_id;
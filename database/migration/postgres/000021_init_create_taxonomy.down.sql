@@ -0,0 +1,7 @@
+-- +migrate Down
+DROP TABLE IF EXISTS book_tags;
+DROP TABLE IF EXISTS book_categories;
+ALTER TABLE books DROP COLUMN IF EXISTS publisher_id;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS publishers;
@@ -0,0 +1,44 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS publishers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    website VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_publishers_name ON publishers (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    parent_id INT REFERENCES categories (id) ON DELETE RESTRICT,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- sibling categories must have distinct names, root categories share parent 0
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_parent_name ON categories (COALESCE(parent_id, 0), LOWER(name));
+
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS publisher_id INT REFERENCES publishers (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_books_publisher_id ON books (publisher_id);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_categories_category_id ON book_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
@@ -0,0 +1,7 @@
+-- +migrate Down
+DROP TABLE IF EXISTS prices;
+
+ALTER TABLE books DROP COLUMN IF EXISTS price_currency;
+UPDATE books SET price_amount = price_amount / 100;
+ALTER TABLE books ALTER COLUMN price_amount TYPE INT;
+ALTER TABLE books RENAME COLUMN price_amount TO price;
@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE books RENAME COLUMN price TO price_amount;
+ALTER TABLE books ALTER COLUMN price_amount TYPE BIGINT;
+
+-- prices used to be whole rupiah, amounts are kept in the currency minor unit now
+UPDATE books SET price_amount = price_amount * 100;
+
+ALTER TABLE books ADD COLUMN IF NOT EXISTS price_currency CHAR(3) NOT NULL DEFAULT 'IDR';
+
+CREATE TABLE IF NOT EXISTS prices (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (book_id, currency)
+);
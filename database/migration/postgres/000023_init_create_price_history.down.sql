@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP TABLE IF EXISTS price_history;
+DROP TABLE IF EXISTS scheduled_prices;
@@ -0,0 +1,32 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+    id SERIAL PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    price_amount BIGINT NOT NULL CHECK (price_amount > 0),
+    price_currency CHAR(3) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    effective_at TIMESTAMP NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    applied_at TIMESTAMP
+);
+
+-- the scheduler only ever looks for pending changes that are due
+CREATE INDEX IF NOT EXISTS scheduled_prices_due_idx ON scheduled_prices (effective_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS price_history (
+    id SERIAL PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    kind VARCHAR(8) NOT NULL,
+    old_amount BIGINT,
+    old_currency CHAR(3),
+    new_amount BIGINT,
+    new_currency CHAR(3),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    scheduled_price_id INT REFERENCES scheduled_prices (id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS price_history_book_id_idx ON price_history (book_id, changed_at);
@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS promotions (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('percentage', 'fixed_amount', 'bundle')),
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('all', 'book', 'author', 'category')),
+    scope_id INT,
+    percentage INT NOT NULL DEFAULT 0 CHECK (percentage BETWEEN 0 AND 100),
+    discount_amount BIGINT NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    discount_currency VARCHAR(3) NOT NULL DEFAULT '',
+    buy_quantity INT NOT NULL DEFAULT 0,
+    pay_quantity INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    priority INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((scope = 'all') = (scope_id IS NULL)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS promotions_active_idx ON promotions (starts_at, ends_at) WHERE active;
@@ -0,0 +1,9 @@
+-- +migrate Down
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS goods_receipt_lines;
+DROP TABLE IF EXISTS goods_receipts;
+DROP TABLE IF EXISTS purchase_order_lines;
+DROP TABLE IF EXISTS purchase_orders;
+DROP TABLE IF EXISTS suppliers;
+
+ALTER TABLE books DROP COLUMN IF EXISTS stock;
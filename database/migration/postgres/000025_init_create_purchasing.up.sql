@@ -0,0 +1,75 @@
+-- +migrate Up
+-- stock is the on hand quantity, it only changes together with a stock_movements row
+ALTER TABLE books ADD COLUMN IF NOT EXISTS stock INT NOT NULL DEFAULT 0 CHECK (stock >= 0);
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone_number VARCHAR(50) NOT NULL DEFAULT '',
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suppliers_name ON suppliers (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id SERIAL PRIMARY KEY,
+    supplier_id INT NOT NULL REFERENCES suppliers (id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'submitted', 'partially_received', 'received', 'cancelled')),
+    currency CHAR(3) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    submitted_at TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity_ordered INT NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INT NOT NULL DEFAULT 0 CHECK (quantity_received BETWEEN 0 AND quantity_ordered),
+    unit_cost_amount BIGINT NOT NULL CHECK (unit_cost_amount >= 0),
+    unit_cost_currency CHAR(3) NOT NULL,
+    UNIQUE (purchase_order_id, book_id)
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id SERIAL PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders (id) ON DELETE RESTRICT,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    received_by VARCHAR(255) NOT NULL DEFAULT '',
+    received_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    id SERIAL PRIMARY KEY,
+    goods_receipt_id INT NOT NULL REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    purchase_order_line_id INT NOT NULL REFERENCES purchase_order_lines (id) ON DELETE RESTRICT,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_cost_amount BIGINT NOT NULL,
+    unit_cost_currency CHAR(3) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id SERIAL PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity <> 0),
+    unit_cost_amount BIGINT NOT NULL DEFAULT 0,
+    unit_cost_currency VARCHAR(3) NOT NULL DEFAULT '',
+    reference_type VARCHAR(30) NOT NULL DEFAULT '',
+    reference_id INT,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_book_id ON stock_movements (book_id, created_at);
@@ -0,0 +1,5 @@
+-- +migrate Down
+DROP TABLE IF EXISTS order_lines;
+DROP TABLE IF EXISTS orders;
+
+ALTER TABLE books DROP COLUMN IF EXISTS reserved;
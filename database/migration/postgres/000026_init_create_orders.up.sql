@@ -0,0 +1,36 @@
+-- +migrate Up
+-- reserved is the part of stock promised to open orders, stock - reserved is what can still be sold
+ALTER TABLE books ADD COLUMN IF NOT EXISTS reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id SERIAL PRIMARY KEY,
+    customer_name VARCHAR(100) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    shipping_address VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'picked', 'shipped', 'delivered', 'cancelled', 'returned')),
+    currency CHAR(3) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP,
+    picked_at TIMESTAMP,
+    shipped_at TIMESTAMP,
+    delivered_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    returned_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_email ON orders (LOWER(customer_email));
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price_amount BIGINT NOT NULL CHECK (unit_price_amount >= 0),
+    unit_price_currency CHAR(3) NOT NULL,
+    UNIQUE (order_id, book_id)
+);
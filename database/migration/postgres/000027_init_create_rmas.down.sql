@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP TABLE IF EXISTS rma_lines;
+DROP TABLE IF EXISTS rmas;
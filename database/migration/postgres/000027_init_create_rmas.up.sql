@@ -0,0 +1,37 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS rmas (
+    id SERIAL PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    customer_email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rmas_order_id ON rmas (order_id);
+CREATE INDEX IF NOT EXISTS idx_rmas_customer_email ON rmas (LOWER(customer_email));
+
+-- outcome stays empty until the returned books are inspected
+CREATE TABLE IF NOT EXISTS rma_lines (
+    id SERIAL PRIMARY KEY,
+    rma_id INT NOT NULL REFERENCES rmas (id) ON DELETE CASCADE,
+    order_line_id INT NOT NULL REFERENCES order_lines (id) ON DELETE RESTRICT,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'defective', 'wrong_book', 'unwanted', 'other')),
+    unit_price_amount BIGINT NOT NULL,
+    unit_price_currency CHAR(3) NOT NULL,
+    outcome VARCHAR(20) NOT NULL DEFAULT ''
+        CHECK (outcome IN ('', 'restock', 'write_off', 'return_to_supplier')),
+    refund_amount BIGINT NOT NULL DEFAULT 0 CHECK (refund_amount >= 0),
+    refund_currency VARCHAR(3) NOT NULL DEFAULT '',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_by VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_at TIMESTAMP,
+    UNIQUE (rma_id, book_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rma_lines_book_id ON rma_lines (book_id);
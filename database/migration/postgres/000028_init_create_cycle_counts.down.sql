@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP TABLE IF EXISTS cycle_count_lines;
+DROP TABLE IF EXISTS cycle_counts;
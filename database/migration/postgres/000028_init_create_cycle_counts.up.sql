@@ -0,0 +1,33 @@
+-- +migrate Up
+-- a cycle count covers the books at one location or a hand picked set of them, version grows with
+-- every edit so clerks working from a stale copy are rejected
+CREATE TABLE IF NOT EXISTS cycle_counts (
+    id SERIAL PRIMARY KEY,
+    location VARCHAR(50) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    version INT NOT NULL DEFAULT 1,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    approved_by VARCHAR(255) NOT NULL DEFAULT '',
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cycle_counts_status ON cycle_counts (status);
+
+-- expected is the stock when the count started, a book is in at most one open count at a time
+CREATE TABLE IF NOT EXISTS cycle_count_lines (
+    id SERIAL PRIMARY KEY,
+    cycle_count_id INT NOT NULL REFERENCES cycle_counts (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    expected INT NOT NULL,
+    counted INT CHECK (counted >= 0),
+    counted_by VARCHAR(255) NOT NULL DEFAULT '',
+    counted_at TIMESTAMP,
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (cycle_count_id, book_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cycle_count_lines_open_book ON cycle_count_lines (book_id) WHERE NOT closed;
@@ -0,0 +1,3 @@
+-- +migrate Down
+DROP TABLE IF EXISTS stock_alerts;
+DROP TABLE IF EXISTS reorder_points;
@@ -0,0 +1,22 @@
+-- +migrate Up
+-- a book is low on stock once stock - reserved drops to its reorder point
+CREATE TABLE IF NOT EXISTS reorder_points (
+    book_id INT PRIMARY KEY REFERENCES books (id) ON DELETE CASCADE,
+    reorder_point INT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INT NOT NULL CHECK (reorder_quantity > 0),
+    supplier_id INT REFERENCES suppliers (id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id SERIAL PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    available INT NOT NULL,
+    reorder_point INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    purchase_order_id INT REFERENCES purchase_orders (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_alerts_open_book ON stock_alerts (book_id) WHERE status = 'open';
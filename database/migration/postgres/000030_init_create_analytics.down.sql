@@ -0,0 +1,4 @@
+-- +migrate Down
+DROP TABLE IF EXISTS analytics_refreshes;
+DROP TABLE IF EXISTS book_stock_daily;
+DROP TABLE IF EXISTS book_sales_daily;
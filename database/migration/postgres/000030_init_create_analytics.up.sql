@@ -0,0 +1,33 @@
+-- +migrate Up
+-- the analytics tables are rollups of stock_movements refreshed by the rest server, never written by requests
+
+-- net copies sold and revenue per book, day and currency, returns and refunds already subtracted
+CREATE TABLE IF NOT EXISTS book_sales_daily (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    revenue_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, day, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_sales_daily_day ON book_sales_daily (day);
+
+-- stock moved per book and day, closing_stock is the stock on hand at the end of the day
+CREATE TABLE IF NOT EXISTS book_stock_daily (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    stock_in INT NOT NULL DEFAULT 0,
+    stock_out INT NOT NULL DEFAULT 0,
+    closing_stock INT NOT NULL,
+    PRIMARY KEY (book_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_stock_daily_day ON book_stock_daily (day);
+
+-- refreshed_through is the last day the rollups were built for, the next refresh rebuilds it and later days
+CREATE TABLE IF NOT EXISTS analytics_refreshes (
+    name VARCHAR(50) PRIMARY KEY,
+    refreshed_through DATE NOT NULL,
+    refreshed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
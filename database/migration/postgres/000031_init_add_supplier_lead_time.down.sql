@@ -0,0 +1,2 @@
+-- +migrate Down
+ALTER TABLE suppliers DROP COLUMN IF EXISTS lead_time_days;
@@ -0,0 +1,3 @@
+-- +migrate Up
+-- lead_time_days is how long the supplier takes to deliver an order, 0 when unknown
+ALTER TABLE suppliers ADD COLUMN IF NOT EXISTS lead_time_days INT NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0);
@@ -0,0 +1,2 @@
+-- +migrate Down
+ALTER TABLE scheduled_prices DROP COLUMN IF EXISTS failure_reason;
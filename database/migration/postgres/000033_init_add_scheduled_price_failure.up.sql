@@ -0,0 +1,3 @@
+-- +migrate Up
+-- failure_reason is why the scheduler could not apply a schedule it marked failed
+ALTER TABLE scheduled_prices ADD COLUMN IF NOT EXISTS failure_reason VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,389 @@
+-- +migrate Up
+-- sqlite starts from the schema of postgres migration 000031, later migrations are added to every dialect.
+CREATE TABLE IF NOT EXISTS publishers (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    website VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_publishers_name ON publishers (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS books (
+    id INTEGER PRIMARY KEY,
+    book_name VARCHAR(255),
+    title VARCHAR(255),
+    price_amount BIGINT,
+    price_currency CHAR(3) NOT NULL DEFAULT 'IDR',
+    isbn13 VARCHAR(13),
+    isbn10 VARCHAR(10),
+    sku VARCHAR(32),
+    barcode VARCHAR(13),
+    location VARCHAR(50),
+    publisher_id INT REFERENCES publishers (id) ON DELETE SET NULL,
+    stock INT NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    reserved INT NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    created_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_isbn13 ON books (isbn13);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_sku ON books (sku);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_books_barcode ON books (barcode);
+CREATE INDEX IF NOT EXISTS idx_books_publisher_id ON books (publisher_id);
+
+CREATE TABLE IF NOT EXISTS authors (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    phone_number VARCHAR(100)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id INTEGER PRIMARY KEY,
+    username VARCHAR(100) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    password VARCHAR(100)
+);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id INTEGER PRIMARY KEY,
+    idempotency_key VARCHAR(255) NOT NULL,
+    user_id INT NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    request_hash VARCHAR(64) NOT NULL,
+    status_code INT,
+    content_type VARCHAR(100),
+    response_body TEXT,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (idempotency_key, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS book_contributors (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    author_id INT NOT NULL REFERENCES authors (id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL DEFAULT 'author',
+    position INT NOT NULL DEFAULT 1,
+    PRIMARY KEY (book_id, author_id, role)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_contributors_author_id ON book_contributors (author_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id INTEGER PRIMARY KEY,
+    parent_id INT REFERENCES categories (id) ON DELETE RESTRICT,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- sibling categories must have distinct names, root categories share parent 0
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_parent_name ON categories (COALESCE(parent_id, 0), LOWER(name));
+
+CREATE TABLE IF NOT EXISTS tags (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS book_categories (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    category_id INT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_categories_category_id ON book_categories (category_id);
+
+CREATE TABLE IF NOT EXISTS book_tags (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (book_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_tags_tag_id ON book_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS prices (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    currency CHAR(3) NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (book_id, currency)
+);
+
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+    id INTEGER PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    price_amount BIGINT NOT NULL CHECK (price_amount > 0),
+    price_currency CHAR(3) NOT NULL,
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    effective_at TIMESTAMP NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    applied_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_prices_due_idx ON scheduled_prices (effective_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS price_history (
+    id INTEGER PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    kind VARCHAR(8) NOT NULL,
+    old_amount BIGINT,
+    old_currency CHAR(3),
+    new_amount BIGINT,
+    new_currency CHAR(3),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    scheduled_price_id INT REFERENCES scheduled_prices (id) ON DELETE SET NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_history_book_id_idx ON price_history (book_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('percentage', 'fixed_amount', 'bundle')),
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('all', 'book', 'author', 'category')),
+    scope_id INT,
+    percentage INT NOT NULL DEFAULT 0 CHECK (percentage BETWEEN 0 AND 100),
+    discount_amount BIGINT NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    discount_currency VARCHAR(3) NOT NULL DEFAULT '',
+    buy_quantity INT NOT NULL DEFAULT 0,
+    pay_quantity INT NOT NULL DEFAULT 0,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    priority INT NOT NULL DEFAULT 0,
+    stackable BOOLEAN NOT NULL DEFAULT FALSE,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((scope = 'all') = (scope_id IS NULL)),
+    CHECK (ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS promotions_active_idx ON promotions (starts_at, ends_at) WHERE active;
+
+CREATE TABLE IF NOT EXISTS suppliers (
+    id INTEGER PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    phone_number VARCHAR(50) NOT NULL DEFAULT '',
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    lead_time_days INT NOT NULL DEFAULT 0 CHECK (lead_time_days >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_suppliers_name ON suppliers (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id INTEGER PRIMARY KEY,
+    supplier_id INT NOT NULL REFERENCES suppliers (id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'submitted', 'partially_received', 'received', 'cancelled')),
+    currency CHAR(3) NOT NULL,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    submitted_at TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_purchase_orders_supplier_id ON purchase_orders (supplier_id);
+
+CREATE TABLE IF NOT EXISTS purchase_order_lines (
+    id INTEGER PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity_ordered INT NOT NULL CHECK (quantity_ordered > 0),
+    quantity_received INT NOT NULL DEFAULT 0 CHECK (quantity_received BETWEEN 0 AND quantity_ordered),
+    unit_cost_amount BIGINT NOT NULL CHECK (unit_cost_amount >= 0),
+    unit_cost_currency CHAR(3) NOT NULL,
+    UNIQUE (purchase_order_id, book_id)
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipts (
+    id INTEGER PRIMARY KEY,
+    purchase_order_id INT NOT NULL REFERENCES purchase_orders (id) ON DELETE RESTRICT,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    received_by VARCHAR(255) NOT NULL DEFAULT '',
+    received_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS goods_receipt_lines (
+    id INTEGER PRIMARY KEY,
+    goods_receipt_id INT NOT NULL REFERENCES goods_receipts (id) ON DELETE CASCADE,
+    purchase_order_line_id INT NOT NULL REFERENCES purchase_order_lines (id) ON DELETE RESTRICT,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_cost_amount BIGINT NOT NULL,
+    unit_cost_currency CHAR(3) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id INTEGER PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    quantity INT NOT NULL CHECK (quantity <> 0),
+    unit_cost_amount BIGINT NOT NULL DEFAULT 0,
+    unit_cost_currency VARCHAR(3) NOT NULL DEFAULT '',
+    reference_type VARCHAR(30) NOT NULL DEFAULT '',
+    reference_id INT,
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_movements_book_id ON stock_movements (book_id, created_at);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id INTEGER PRIMARY KEY,
+    customer_name VARCHAR(100) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    shipping_address VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'picked', 'shipped', 'delivered', 'cancelled', 'returned')),
+    currency CHAR(3) NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paid_at TIMESTAMP,
+    picked_at TIMESTAMP,
+    shipped_at TIMESTAMP,
+    delivered_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    returned_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_email ON orders (LOWER(customer_email));
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status);
+
+CREATE TABLE IF NOT EXISTS order_lines (
+    id INTEGER PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    quantity INT NOT NULL CHECK (quantity > 0),
+    unit_price_amount BIGINT NOT NULL CHECK (unit_price_amount >= 0),
+    unit_price_currency CHAR(3) NOT NULL,
+    UNIQUE (order_id, book_id)
+);
+
+CREATE TABLE IF NOT EXISTS rmas (
+    id INTEGER PRIMARY KEY,
+    order_id INT NOT NULL REFERENCES orders (id) ON DELETE RESTRICT,
+    customer_email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rmas_order_id ON rmas (order_id);
+CREATE INDEX IF NOT EXISTS idx_rmas_customer_email ON rmas (LOWER(customer_email));
+
+CREATE TABLE IF NOT EXISTS rma_lines (
+    id INTEGER PRIMARY KEY,
+    rma_id INT NOT NULL REFERENCES rmas (id) ON DELETE CASCADE,
+    order_line_id INT NOT NULL REFERENCES order_lines (id) ON DELETE RESTRICT,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE RESTRICT,
+    quantity INT NOT NULL CHECK (quantity > 0),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('damaged', 'defective', 'wrong_book', 'unwanted', 'other')),
+    unit_price_amount BIGINT NOT NULL,
+    unit_price_currency CHAR(3) NOT NULL,
+    outcome VARCHAR(20) NOT NULL DEFAULT ''
+        CHECK (outcome IN ('', 'restock', 'write_off', 'return_to_supplier')),
+    refund_amount BIGINT NOT NULL DEFAULT 0 CHECK (refund_amount >= 0),
+    refund_currency VARCHAR(3) NOT NULL DEFAULT '',
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_by VARCHAR(255) NOT NULL DEFAULT '',
+    inspected_at TIMESTAMP,
+    UNIQUE (rma_id, book_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_rma_lines_book_id ON rma_lines (book_id);
+
+CREATE TABLE IF NOT EXISTS cycle_counts (
+    id INTEGER PRIMARY KEY,
+    location VARCHAR(50) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved', 'cancelled')),
+    note VARCHAR(255) NOT NULL DEFAULT '',
+    version INT NOT NULL DEFAULT 1,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    approved_by VARCHAR(255) NOT NULL DEFAULT '',
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cycle_counts_status ON cycle_counts (status);
+
+CREATE TABLE IF NOT EXISTS cycle_count_lines (
+    id INTEGER PRIMARY KEY,
+    cycle_count_id INT NOT NULL REFERENCES cycle_counts (id) ON DELETE CASCADE,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    expected INT NOT NULL,
+    counted INT CHECK (counted >= 0),
+    counted_by VARCHAR(255) NOT NULL DEFAULT '',
+    counted_at TIMESTAMP,
+    closed BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (cycle_count_id, book_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cycle_count_lines_open_book ON cycle_count_lines (book_id) WHERE NOT closed;
+
+CREATE TABLE IF NOT EXISTS reorder_points (
+    book_id INTEGER PRIMARY KEY REFERENCES books (id) ON DELETE CASCADE,
+    reorder_point INT NOT NULL CHECK (reorder_point >= 0),
+    reorder_quantity INT NOT NULL CHECK (reorder_quantity > 0),
+    supplier_id INT REFERENCES suppliers (id) ON DELETE SET NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS stock_alerts (
+    id INTEGER PRIMARY KEY,
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    available INT NOT NULL,
+    reorder_point INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    purchase_order_id INT REFERENCES purchase_orders (id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stock_alerts_open_book ON stock_alerts (book_id) WHERE status = 'open';
+
+-- days are stored as 2006-01-02 text
+CREATE TABLE IF NOT EXISTS book_sales_daily (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    currency CHAR(3) NOT NULL,
+    quantity INT NOT NULL DEFAULT 0,
+    revenue_amount BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (book_id, day, currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_sales_daily_day ON book_sales_daily (day);
+
+CREATE TABLE IF NOT EXISTS book_stock_daily (
+    book_id INT NOT NULL REFERENCES books (id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    stock_in INT NOT NULL DEFAULT 0,
+    stock_out INT NOT NULL DEFAULT 0,
+    closing_stock INT NOT NULL,
+    PRIMARY KEY (book_id, day)
+);
+
+CREATE INDEX IF NOT EXISTS idx_book_stock_daily_day ON book_stock_daily (day);
+
+CREATE TABLE IF NOT EXISTS analytics_refreshes (
+    name VARCHAR(50) PRIMARY KEY,
+    refreshed_through DATE NOT NULL,
+    refreshed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// TopSellingBooks handler
+// @Summary get top selling books
+// @Description get the books that sold most copies in a period, net of returns
+// @Tags analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param limit query int false "limit, 20 when empty"
+// @Param currency query string false "currency"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.TopSellingBook}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/analytics/top-selling [GET]
+func (h *Handler) TopSellingBooks(c *gin.Context) {
+	var req domain.AnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetAnalyticsUseCase().TopSellingBooks(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// SlowMovers handler
+// @Summary get slow moving books
+// @Description get the books in stock that sold fewest copies in a period, the longest unsold first
+// @Tags analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param limit query int false "limit, 20 when empty"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.SlowMover}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/analytics/slow-movers [GET]
+func (h *Handler) SlowMovers(c *gin.Context) {
+	var req domain.AnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetAnalyticsUseCase().SlowMovers(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// DaysOnHand handler
+// @Summary get days of inventory on hand
+// @Description get how many days the stock of each book lasts at its average daily sales of a period, longest first
+// @Tags analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param limit query int false "limit, 20 when empty"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.DaysOnHand}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/analytics/days-on-hand [GET]
+func (h *Handler) DaysOnHand(c *gin.Context) {
+	var req domain.AnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetAnalyticsUseCase().DaysOnHand(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// StockTurnover handler
+// @Summary get stock turnover
+// @Description get the stock turnover and days on hand per bucket, of one book or of all books
+// @Tags analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param bucket query string false "day, week or month"
+// @Param book_id query int false "book id"
+// @Success 200 {object} helper.JSONResponse{data=domain.StockTurnoverReport}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/analytics/stock-turnover [GET]
+func (h *Handler) StockTurnover(c *gin.Context) {
+	var req domain.AnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetAnalyticsUseCase().StockTurnover(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// RevenueByAuthor handler
+// @Summary get revenue by author
+// @Description get the net sales per primary author split into buckets, highest revenue first
+// @Tags analytics
+// @Produce json
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param bucket query string false "day, week or month"
+// @Param author_id query int false "author id"
+// @Param limit query int false "limit, 20 when empty"
+// @Param currency query string false "currency"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.AuthorRevenue}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/analytics/revenue-by-author [GET]
+func (h *Handler) RevenueByAuthor(c *gin.Context) {
+	var req domain.AnalyticsRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetAnalyticsUseCase().RevenueByAuthor(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
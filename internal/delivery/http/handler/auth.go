@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
 )
 
 // Login handler
@@ -23,6 +24,7 @@ func (h *Handler) Login(c *gin.Context) {
 	var req *domain.LoginRequest
 
 	if err := c.ShouldBind(&req); err != nil {
+		metrics.LoginFailed(metrics.LoginInvalidRequest)
 		helper.Error(c, http.StatusBadRequest, "error bad request")
 		return
 	}
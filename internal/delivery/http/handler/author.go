@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
 )
 
 func (h *Handler) CreateAuthorAndBook(c *gin.Context) {
@@ -36,6 +36,7 @@ func (h *Handler) CreateAuthorAndBook(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param input body domain.CreateAuthorRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
 // @Success 200 {object} helper.JSONResponse
 // @Failure 400 {object} helper.JSONResponse
 // @Failure 500 {object} helper.JSONResponse
@@ -43,7 +44,6 @@ func (h *Handler) CreateAuthorAndBook(c *gin.Context) {
 func (h *Handler) CreateAuthor(c *gin.Context) {
 	var req domain.CreateAuthorRequest
 	if err := c.ShouldBind(&req); err != nil {
-		fmt.Println(req)
 		helper.Error(c, http.StatusBadRequest, "error bad request")
 		return
 	}
@@ -67,6 +67,7 @@ func (h *Handler) CreateAuthor(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Param id path string true "author id"
 // @Param input body domain.AddAuthorBookRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
 // @Success 200 {object} helper.JSONResponse
 // @Failure 400 {object} helper.JSONResponse
 // @Failure 500 {object} helper.JSONResponse
@@ -95,6 +96,47 @@ func (h *Handler) AddAuthorBook(c *gin.Context) {
 	helper.Success(c, http.StatusCreated)
 }
 
+// PatchAuthor handler
+// @Summary partially update author
+// @Description partially update author with a json merge patch (RFC 7396)
+// @Tags author
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "author id"
+// @Param input body domain.PatchAuthorRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/author/{id} [PATCH]
+func (h *Handler) PatchAuthor(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	patch, err := mergepatch.Parse(body)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetAuthorUseCase().PatchAuthor(c, id, patch)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
 // GetListBookByAuthor handler
 // @Summary get list book by author
 // @Description get list book by author
@@ -103,6 +145,7 @@ func (h *Handler) AddAuthorBook(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @Param id path string true "author id"
+// @Param currency query string false "show prices in this currency"
 // @Success 200 {object} helper.JSONResponse{data=[]domain.Book}
 // @Failure 400 {object} helper.JSONResponse
 // @Failure 500 {object} helper.JSONResponse
@@ -115,7 +158,7 @@ func (h *Handler) GetListBookByAuthor(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.usecase.GetAuthorUseCase().GetListBookByAuthor(c, id)
+	resp, err := h.usecase.GetAuthorUseCase().GetListBookByAuthor(c, id, c.Query("currency"))
 	if err != nil {
 		helper.InternalError(c, err)
 		return
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
 )
 
 // AddBook handler
@@ -17,6 +18,7 @@ import (
 // @Produce json
 // @Security ApiKeyAuth
 // @Param input body domain.CreateBookRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
 // @Success 200 {object} helper.JSONResponse
 // @Failure 400 {object} helper.JSONResponse
 // @Failure 500 {object} helper.JSONResponse
@@ -38,6 +40,50 @@ func (h *Handler) AddBook(c *gin.Context) {
 	helper.Success(c, http.StatusCreated)
 }
 
+// BatchBooks handler
+// @Summary create, update and delete books in batch
+// @Description apply a list of book operations, with atomic=true every operation runs in one transaction
+// @Tags book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param atomic query bool false "rollback the whole batch on the first failure"
+// @Param input body domain.BookBatchRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 200 {object} helper.JSONResponse{data=domain.BookBatchResponse}
+// @Success 207 {object} helper.JSONResponse{data=domain.BookBatchResponse}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/batch [POST]
+func (h *Handler) BatchBooks(c *gin.Context) {
+	var req domain.BookBatchRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.Atomic, err = strconv.ParseBool(c.DefaultQuery("atomic", "false"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetBookUseCase().BatchBooks(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	if resp.Failed > 0 {
+		helper.Success(c, http.StatusMultiStatus, resp)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
 // UpdateBook handler
 // @Summary update book
 // @Description update book
@@ -75,6 +121,84 @@ func (h *Handler) UpdateBook(c *gin.Context) {
 	helper.Success(c, http.StatusOK)
 }
 
+// SetBookPrices handler
+// @Summary set book list prices
+// @Description replace the list prices of a book in currencies other than its base price, an empty list removes them
+// @Tags book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @param id path string true "book id"
+// @Param input body domain.SetBookPricesRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/prices [PUT]
+func (h *Handler) SetBookPrices(c *gin.Context) {
+	var req domain.SetBookPricesRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.BookID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetBookUseCase().SetBookPrices(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// PatchBook handler
+// @Summary partially update book
+// @Description partially update book with a json merge patch (RFC 7396)
+// @Tags book
+// @Accept application/merge-patch+json
+// @Produce json
+// @Security ApiKeyAuth
+// @param id path string true "book id"
+// @Param input body domain.PatchBookRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id} [PATCH]
+func (h *Handler) PatchBook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	patch, err := mergepatch.Parse(body)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetBookUseCase().PatchBook(c, id, patch)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
 // DeleteBook handler
 // @Summary delete book
 // @Description delete book
@@ -112,6 +236,7 @@ func (h *Handler) DeleteBook(c *gin.Context) {
 // @Produce json
 // @Security ApiKeyAuth
 // @param id path string true "book id"
+// @Param currency query string false "show prices in this currency"
 // @Success 200 {object} helper.JSONResponse{data=domain.DetailBook}
 // @Failure 400 {object} helper.JSONResponse
 // @Failure 500 {object} helper.JSONResponse
@@ -124,7 +249,75 @@ func (h *Handler) GetDetailBook(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.usecase.GetBookUseCase().GetDetailBook(c, id)
+	resp, err := h.usecase.GetBookUseCase().GetDetailBook(c, id, c.Query("currency"))
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// ListBooks handler
+// @Summary list and search books
+// @Description list books matching every given filter, category_id also matches its subcategories and every tag must be present
+// @Tags book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param q query string false "search in title and book name"
+// @Param author_id query int false "author id"
+// @Param publisher_id query int false "publisher id"
+// @Param category_id query int false "category id"
+// @Param tag query []string false "tag" collectionFormat(multi)
+// @Param page query int false "page, starts at 1"
+// @Param limit query int false "page size, default 20"
+// @Param currency query string false "show prices in this currency"
+// @Success 200 {object} helper.JSONResponse{data=domain.BookListResponse}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/books [GET]
+func (h *Handler) ListBooks(c *gin.Context) {
+	var filter domain.BookFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetBookUseCase().ListBooks(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// LookupBook handler
+// @Summary lookup book by identifier
+// @Description lookup book by exactly one of isbn, sku or barcode
+// @Tags book
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param isbn query string false "isbn-10 or isbn-13"
+// @Param sku query string false "sku"
+// @Param barcode query string false "ean-13 barcode"
+// @Param currency query string false "show prices in this currency"
+// @Success 200 {object} helper.JSONResponse{data=domain.DetailBook}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/books/lookup [GET]
+func (h *Handler) LookupBook(c *gin.Context) {
+	var req domain.BookLookupRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetBookUseCase().LookupBook(c, &req)
 	if err != nil {
 		helper.InternalError(c, err)
 		return
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateCategory handler
+// @Summary create category
+// @Description create a root category or a subcategory of parent_id
+// @Tags category
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateCategoryRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/category [POST]
+func (h *Handler) CreateCategory(c *gin.Context) {
+	var req domain.CreateCategoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetCategoryUseCase().CreateCategory(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// GetCategoryTree handler
+// @Summary list categories
+// @Description list every category nested under its parent
+// @Tags category
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} helper.JSONResponse{data=[]domain.CategoryNode}
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/categories [GET]
+func (h *Handler) GetCategoryTree(c *gin.Context) {
+	resp, err := h.usecase.GetCategoryUseCase().GetCategoryTree(c)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetCategory handler
+// @Summary get category
+// @Description get category
+// @Tags category
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "category id"
+// @Success 200 {object} helper.JSONResponse{data=domain.Category}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/categories/{id} [GET]
+func (h *Handler) GetCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCategoryUseCase().GetCategory(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdateCategory handler
+// @Summary update category
+// @Description rename a category or move it under another parent
+// @Tags category
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "category id"
+// @Param input body domain.UpdateCategoryRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/category/{id} [PUT]
+func (h *Handler) UpdateCategory(c *gin.Context) {
+	var req domain.UpdateCategoryRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetCategoryUseCase().UpdateCategory(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// DeleteCategory handler
+// @Summary delete category
+// @Description delete a category without subcategories
+// @Tags category
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "category id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/category/{id} [DELETE]
+func (h *Handler) DeleteCategory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetCategoryUseCase().DeleteCategory(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
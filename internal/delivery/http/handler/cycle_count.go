@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateCycleCount handler
+// @Summary create cycle count
+// @Description start counting the books at a location or a set of books, their stock is snapshotted as the expected quantity
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateCycleCountRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse{data=domain.CycleCount}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/cycle-count [POST]
+func (h *Handler) CreateCycleCount(c *gin.Context) {
+	var req domain.CreateCycleCountRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCycleCountUseCase().CreateCycleCount(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated, resp)
+}
+
+// ListCycleCounts handler
+// @Summary list cycle counts
+// @Description list cycle counts without their lines, newest first
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "status"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.CycleCount}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/cycle-counts [GET]
+func (h *Handler) ListCycleCounts(c *gin.Context) {
+	var filter domain.CycleCountFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCycleCountUseCase().ListCycleCounts(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetCycleCount handler
+// @Summary get cycle count
+// @Description get cycle count with its lines
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "cycle count id"
+// @Success 200 {object} helper.JSONResponse{data=domain.CycleCount}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/cycle-counts/{id} [GET]
+func (h *Handler) GetCycleCount(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCycleCountUseCase().GetCycleCount(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetCycleCountVariance handler
+// @Summary get cycle count variance
+// @Description get counted minus expected quantity per book
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "cycle count id"
+// @Success 200 {object} helper.JSONResponse{data=domain.CycleCountVariance}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/cycle-counts/{id}/variance [GET]
+func (h *Handler) GetCycleCountVariance(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCycleCountUseCase().GetVariance(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// SubmitCounts handler
+// @Summary submit counted quantities
+// @Description record counted quantities, pass the version of the count you worked from to be refused when someone changed it since
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "cycle count id"
+// @Param input body domain.SubmitCountsRequest true "data"
+// @Success 200 {object} helper.JSONResponse{data=domain.CycleCount}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/cycle-count/{id}/counts [PUT]
+func (h *Handler) SubmitCounts(c *gin.Context) {
+	var req domain.SubmitCountsRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetCycleCountUseCase().SubmitCounts(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// ApproveCycleCount handler
+// @Summary approve cycle count
+// @Description approve a fully counted cycle count and post a stock adjustment for every variance
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "cycle count id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/cycle-count/{id}/approve [POST]
+func (h *Handler) ApproveCycleCount(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetCycleCountUseCase().ApproveCycleCount(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// CancelCycleCount handler
+// @Summary cancel cycle count
+// @Description cancel an open cycle count without adjusting stock
+// @Tags cycle count
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "cycle count id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/cycle-count/{id}/cancel [POST]
+func (h *Handler) CancelCycleCount(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetCycleCountUseCase().CancelCycleCount(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
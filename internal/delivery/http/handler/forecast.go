@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// ForecastDemand handler
+// @Summary forecast demand
+// @Description forecast the daily demand of the books from their shipments and suggest reorder points and quantities
+// @Tags forecasts
+// @Produce json
+// @Security ApiKeyAuth
+// @Param book_id query int false "book id, every book sold in the history when empty"
+// @Param method query string false "moving_average or exponential_smoothing"
+// @Param window query int false "moving average window in days"
+// @Param alpha query number false "smoothing factor"
+// @Param season query int false "seasonal cycle in days, 0 for none"
+// @Param history query int false "days of history"
+// @Param horizon query int false "days to forecast"
+// @Param reorder_only query bool false "only the books to reorder"
+// @Param page query int false "page"
+// @Param limit query int false "limit"
+// @Success 200 {object} helper.JSONResponse{data=domain.ForecastResponse}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/forecasts [GET]
+func (h *Handler) ForecastDemand(c *gin.Context) {
+	var req domain.ForecastRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetForecastUseCase().ForecastDemand(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// RenderBarcode handler
+// @Summary render book barcode
+// @Description render code128 or qr of the sku, or ean13 of the barcode, as png or svg
+// @Tags label
+// @Produce png
+// @Produce image/svg+xml
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Param symbology query string false "code128, ean13 or qr"
+// @Param format query string false "png or svg"
+// @Param width query int false "width in pixels"
+// @Param height query int false "height in pixels"
+// @Success 200 {file} file
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/books/{id}/barcode [GET]
+func (h *Handler) RenderBarcode(c *gin.Context) {
+	var req domain.BarcodeRequest
+
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.BookID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetLabelUseCase().RenderBarcode(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, resp.ContentType, resp.Data)
+}
+
+// RenderLabelSheet handler
+// @Summary render shelf label sheet
+// @Description render an A4 pdf of shelf labels with title, author, price, location and barcodes
+// @Tags label
+// @Accept json
+// @Produce application/pdf
+// @Security ApiKeyAuth
+// @Param input body domain.LabelSheetRequest true "data"
+// @Success 200 {file} file
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/books/labels [POST]
+func (h *Handler) RenderLabelSheet(c *gin.Context) {
+	var req domain.LabelSheetRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetLabelUseCase().RenderLabelSheet(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="labels.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", resp)
+}
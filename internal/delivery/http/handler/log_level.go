@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/logger"
+)
+
+// LogLevelHandler reads and changes the log levels of the running server.
+type LogLevelHandler struct {
+	logger *logger.Logger
+}
+
+func NewLogLevelHandler(logger *logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		logger: logger,
+	}
+}
+
+// GetLogLevels handler
+// @Summary get log levels
+// @Description get the default log level and the levels set for components
+// @Tags admin
+// @Produce json
+// @Security AdminTokenAuth
+// @Success 200 {object} helper.JSONResponse{data=domain.LogLevels}
+// @Failure 401 {object} helper.JSONResponse
+// @Router /inventorysvc/admin/log-levels [GET]
+func (h *LogLevelHandler) GetLogLevels(c *gin.Context) {
+	helper.Success(c, http.StatusOK, h.levels())
+}
+
+// SetLogLevels handler
+// @Summary set log levels
+// @Description set the log levels of the listed components, "default" is the default level and an empty level resets a component to it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminTokenAuth
+// @Param request body domain.LogLevels true "log levels"
+// @Success 200 {object} helper.JSONResponse{data=domain.LogLevels}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 401 {object} helper.JSONResponse
+// @Router /inventorysvc/admin/log-levels [PUT]
+func (h *LogLevelHandler) SetLogLevels(c *gin.Context) {
+	var req domain.LogLevels
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	levels := make(map[string]*slog.Level, len(req.Levels))
+	for component, name := range req.Levels {
+		if name == "" {
+			if component == domain.DefaultLogComponent {
+				helper.Error(c, http.StatusBadRequest, "the default level cannot be reset")
+				return
+			}
+
+			levels[component] = nil
+			continue
+		}
+
+		level := new(slog.Level)
+		if err := level.UnmarshalText([]byte(name)); err != nil {
+			helper.Error(c, http.StatusBadRequest, "invalid log level "+name+" of "+component)
+			return
+		}
+
+		levels[component] = level
+	}
+
+	for component, level := range levels {
+		if component == domain.DefaultLogComponent {
+			component = ""
+		}
+
+		if level == nil {
+			h.logger.ResetLevel(component)
+			continue
+		}
+
+		h.logger.SetLevel(component, *level)
+	}
+
+	helper.Success(c, http.StatusOK, h.levels())
+}
+
+func (h *LogLevelHandler) levels() *domain.LogLevels {
+	resp := &domain.LogLevels{Levels: make(map[string]string)}
+	for component, level := range h.logger.Levels() {
+		if component == "" {
+			component = domain.DefaultLogComponent
+		}
+
+		resp.Levels[component] = strings.ToLower(level.String())
+	}
+
+	return resp
+}
@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateOrder handler
+// @Summary create order
+// @Description place a customer order priced from the books, the ordered stock is reserved until the order ships or is cancelled
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateOrderRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse{data=domain.Order}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders [POST]
+func (h *Handler) CreateOrder(c *gin.Context) {
+	var req domain.CreateOrderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetOrderUseCase().CreateOrder(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated, resp)
+}
+
+// ListOrders handler
+// @Summary list orders
+// @Description list orders, newest first
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "status"
+// @Param customer_email query string false "customer email"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.Order}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders [GET]
+func (h *Handler) ListOrders(c *gin.Context) {
+	var filter domain.OrderFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetOrderUseCase().ListOrders(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetOrder handler
+// @Summary get order
+// @Description get order with its lines
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse{data=domain.Order}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id} [GET]
+func (h *Handler) GetOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetOrderUseCase().GetOrder(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// PayOrder handler
+// @Summary pay order
+// @Description mark a pending order as paid
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/pay [POST]
+func (h *Handler) PayOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().PayOrder)
+}
+
+// PickOrder handler
+// @Summary pick order
+// @Description mark a paid order as picked in the warehouse
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/pick [POST]
+func (h *Handler) PickOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().PickOrder)
+}
+
+// ShipOrder handler
+// @Summary ship order
+// @Description ship a picked order, its reserved stock leaves the warehouse
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/ship [POST]
+func (h *Handler) ShipOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().ShipOrder)
+}
+
+// DeliverOrder handler
+// @Summary deliver order
+// @Description mark a shipped order as delivered
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/deliver [POST]
+func (h *Handler) DeliverOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().DeliverOrder)
+}
+
+// CancelOrder handler
+// @Summary cancel order
+// @Description cancel an order that has not shipped yet and release its reserved stock
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/cancel [POST]
+func (h *Handler) CancelOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().CancelOrder)
+}
+
+// ReturnOrder handler
+// @Summary return order
+// @Description take back a shipped or delivered order and put its books back in stock
+// @Tags order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/orders/{id}/return [POST]
+func (h *Handler) ReturnOrder(c *gin.Context) {
+	h.transitionOrder(c, h.usecase.GetOrderUseCase().ReturnOrder)
+}
+
+func (h *Handler) transitionOrder(c *gin.Context, transition func(ctx context.Context, id int) error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = transition(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
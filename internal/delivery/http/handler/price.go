@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// GetPriceTimeline handler
+// @Summary get book price timeline
+// @Description get the current prices of a book, every past price change and the scheduled ones
+// @Tags price
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Success 200 {object} helper.JSONResponse{data=domain.PriceTimeline}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/prices [GET]
+func (h *Handler) GetPriceTimeline(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPriceUseCase().GetPriceTimeline(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// SchedulePrice handler
+// @Summary schedule book price change
+// @Description schedule a future price, a price in the base price currency changes the base price and any other currency sets its list price
+// @Tags price
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Param input body domain.SchedulePriceRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/prices/schedules [POST]
+func (h *Handler) SchedulePrice(c *gin.Context) {
+	var req domain.SchedulePriceRequest
+
+	err := c.ShouldBind(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.BookID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPriceUseCase().SchedulePrice(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// CancelScheduledPrice handler
+// @Summary cancel scheduled book price change
+// @Description cancel a scheduled price that has not been applied yet
+// @Tags price
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Param scheduleid path string true "scheduled price id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/prices/schedules/{scheduleid} [DELETE]
+func (h *Handler) CancelScheduledPrice(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	scheduleID, err := strconv.Atoi(c.Param("scheduleid"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPriceUseCase().CancelScheduledPrice(c, id, scheduleID)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
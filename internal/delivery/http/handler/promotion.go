@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreatePromotion handler
+// @Summary create promotion
+// @Description create a percentage, fixed_amount or bundle promotion for all books, a book, an author or a category
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreatePromotionRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/promotion [POST]
+func (h *Handler) CreatePromotion(c *gin.Context) {
+	var req domain.CreatePromotionRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetPromotionUseCase().CreatePromotion(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// ListPromotions handler
+// @Summary list promotions
+// @Description list promotions in the order they apply
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} helper.JSONResponse{data=[]domain.Promotion}
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/promotions [GET]
+func (h *Handler) ListPromotions(c *gin.Context) {
+	resp, err := h.usecase.GetPromotionUseCase().ListPromotions(c)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetPromotion handler
+// @Summary get promotion
+// @Description get promotion
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion id"
+// @Success 200 {object} helper.JSONResponse{data=domain.Promotion}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/promotions/{id} [GET]
+func (h *Handler) GetPromotion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPromotionUseCase().GetPromotion(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdatePromotion handler
+// @Summary update promotion
+// @Description update promotion
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion id"
+// @Param input body domain.UpdatePromotionRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/promotion/{id} [PUT]
+func (h *Handler) UpdatePromotion(c *gin.Context) {
+	var req domain.UpdatePromotionRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPromotionUseCase().UpdatePromotion(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// DeletePromotion handler
+// @Summary delete promotion
+// @Description delete promotion
+// @Tags promotion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "promotion id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/promotion/{id} [DELETE]
+func (h *Handler) DeletePromotion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPromotionUseCase().DeletePromotion(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// QuoteBasket handler
+// @Summary quote basket
+// @Description price a basket with the promotions valid now and explain which ones applied
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.QuoteRequest true "data"
+// @Success 200 {object} helper.JSONResponse{data=domain.Quote}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/pricing/quote [POST]
+func (h *Handler) QuoteBasket(c *gin.Context) {
+	var req domain.QuoteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPromotionUseCase().QuoteBasket(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
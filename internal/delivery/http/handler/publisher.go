@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreatePublisher handler
+// @Summary create publisher
+// @Description create publisher
+// @Tags publisher
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreatePublisherRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/publisher [POST]
+func (h *Handler) CreatePublisher(c *gin.Context) {
+	var req domain.CreatePublisherRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetPublisherUseCase().CreatePublisher(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// ListPublishers handler
+// @Summary list publishers
+// @Description list publishers by name
+// @Tags publisher
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} helper.JSONResponse{data=[]domain.Publisher}
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/publishers [GET]
+func (h *Handler) ListPublishers(c *gin.Context) {
+	resp, err := h.usecase.GetPublisherUseCase().ListPublishers(c)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetPublisher handler
+// @Summary get publisher
+// @Description get publisher
+// @Tags publisher
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "publisher id"
+// @Success 200 {object} helper.JSONResponse{data=domain.Publisher}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/publishers/{id} [GET]
+func (h *Handler) GetPublisher(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPublisherUseCase().GetPublisher(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdatePublisher handler
+// @Summary update publisher
+// @Description update publisher
+// @Tags publisher
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "publisher id"
+// @Param input body domain.UpdatePublisherRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/publisher/{id} [PUT]
+func (h *Handler) UpdatePublisher(c *gin.Context) {
+	var req domain.UpdatePublisherRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPublisherUseCase().UpdatePublisher(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// DeletePublisher handler
+// @Summary delete publisher
+// @Description delete publisher, its books are kept without a publisher
+// @Tags publisher
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "publisher id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/publisher/{id} [DELETE]
+func (h *Handler) DeletePublisher(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPublisherUseCase().DeletePublisher(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
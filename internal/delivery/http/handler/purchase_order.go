@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreatePurchaseOrder handler
+// @Summary create purchase order
+// @Description create a draft purchase order, unit costs must be in the order currency
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreatePurchaseOrderRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/purchase-order [POST]
+func (h *Handler) CreatePurchaseOrder(c *gin.Context) {
+	var req domain.CreatePurchaseOrderRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetPurchaseOrderUseCase().CreatePurchaseOrder(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// ListPurchaseOrders handler
+// @Summary list purchase orders
+// @Description list purchase orders, newest first
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "status"
+// @Param supplier_id query int false "supplier id"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.PurchaseOrder}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/purchase-orders [GET]
+func (h *Handler) ListPurchaseOrders(c *gin.Context) {
+	var filter domain.PurchaseOrderFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPurchaseOrderUseCase().ListPurchaseOrders(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetPurchaseOrder handler
+// @Summary get purchase order
+// @Description get purchase order with its lines and goods receipts
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} helper.JSONResponse{data=domain.PurchaseOrder}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/purchase-orders/{id} [GET]
+func (h *Handler) GetPurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetPurchaseOrderUseCase().GetPurchaseOrder(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdatePurchaseOrder handler
+// @Summary update purchase order
+// @Description replace the supplier, note and lines of a draft purchase order
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "purchase order id"
+// @Param input body domain.UpdatePurchaseOrderRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/purchase-order/{id} [PUT]
+func (h *Handler) UpdatePurchaseOrder(c *gin.Context) {
+	var req domain.UpdatePurchaseOrderRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPurchaseOrderUseCase().UpdatePurchaseOrder(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// SubmitPurchaseOrder handler
+// @Summary submit purchase order
+// @Description submit a draft purchase order to the supplier, it can no longer be changed
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/purchase-order/{id}/submit [POST]
+func (h *Handler) SubmitPurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPurchaseOrderUseCase().SubmitPurchaseOrder(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// CancelPurchaseOrder handler
+// @Summary cancel purchase order
+// @Description cancel a purchase order that has not been fully received, received goods stay in stock
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "purchase order id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/purchase-order/{id}/cancel [POST]
+func (h *Handler) CancelPurchaseOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPurchaseOrderUseCase().CancelPurchaseOrder(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// ReceiveGoods handler
+// @Summary receive goods
+// @Description post a goods receipt against a submitted purchase order and add the goods to stock
+// @Tags purchase order
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "purchase order id"
+// @Param input body domain.GoodsReceiptRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/purchase-order/{id}/receipts [POST]
+func (h *Handler) ReceiveGoods(c *gin.Context) {
+	var req domain.GoodsReceiptRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.PurchaseOrderID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetPurchaseOrderUseCase().ReceiveGoods(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateRMA handler
+// @Summary create rma
+// @Description authorise the return of books from a shipped or delivered order
+// @Tags rma
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateRMARequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse{data=domain.RMA}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/rmas [POST]
+func (h *Handler) CreateRMA(c *gin.Context) {
+	var req domain.CreateRMARequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetRMAUseCase().CreateRMA(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated, resp)
+}
+
+// ListRMAs handler
+// @Summary list rmas
+// @Description list rmas, newest first, filter on status=open with a customer email or a book to see what is still to inspect
+// @Tags rma
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "status"
+// @Param customer_email query string false "customer email"
+// @Param book_id query int false "book id"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.RMA}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/rmas [GET]
+func (h *Handler) ListRMAs(c *gin.Context) {
+	var filter domain.RMAFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetRMAUseCase().ListRMAs(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetRMA handler
+// @Summary get rma
+// @Description get rma with its lines
+// @Tags rma
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "rma id"
+// @Success 200 {object} helper.JSONResponse{data=domain.RMA}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/rmas/{id} [GET]
+func (h *Handler) GetRMA(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetRMAUseCase().GetRMA(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// InspectRMA handler
+// @Summary inspect rma
+// @Description record the outcome and refund of returned books, the rma closes once every book is inspected
+// @Tags rma
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "rma id"
+// @Param input body domain.InspectRMARequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/rmas/{id}/inspect [POST]
+func (h *Handler) InspectRMA(c *gin.Context) {
+	var req domain.InspectRMARequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetRMAUseCase().InspectRMA(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// CancelRMA handler
+// @Summary cancel rma
+// @Description cancel an open rma none of whose books have been inspected
+// @Tags rma
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "rma id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/rmas/{id}/cancel [POST]
+func (h *Handler) CancelRMA(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetRMAUseCase().CancelRMA(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+)
+
+// GetStockLedger handler
+// @Summary get book stock
+// @Description get the stock on hand of a book and every stock movement behind it, oldest first
+// @Tags stock
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Success 200 {object} helper.JSONResponse{data=domain.StockLedger}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/books/{id}/stock [GET]
+func (h *Handler) GetStockLedger(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetStockUseCase().GetStockLedger(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// SetReorderPoint handler
+// @Summary set book reorder point
+// @Description set when a book is low on stock and how much to reorder, with a supplier low stock can draft a purchase order
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Param input body domain.SetReorderPointRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/reorder-point [PUT]
+func (h *Handler) SetReorderPoint(c *gin.Context) {
+	var req domain.SetReorderPointRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.BookID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetStockAlertUseCase().SetReorderPoint(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// GetReorderPoint handler
+// @Summary get book reorder point
+// @Description get book reorder point
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Success 200 {object} helper.JSONResponse{data=domain.ReorderPoint}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/reorder-point [GET]
+func (h *Handler) GetReorderPoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetStockAlertUseCase().GetReorderPoint(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// DeleteReorderPoint handler
+// @Summary delete book reorder point
+// @Description stop watching the stock of a book
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "book id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/book/{id}/reorder-point [DELETE]
+func (h *Handler) DeleteReorderPoint(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetStockAlertUseCase().DeleteReorderPoint(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// SetLocationReorderPoint handler
+// @Summary set location reorder point
+// @Description set when the books shelved at a location are low on stock and how much to reorder, a book with a reorder point of its own keeps it
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param location path string true "location"
+// @Param input body domain.SetReorderPointRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/location/{location}/reorder-point [PUT]
+func (h *Handler) SetLocationReorderPoint(c *gin.Context) {
+	var req domain.SetReorderPointRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.Location = c.Param("location")
+
+	err = h.usecase.GetStockAlertUseCase().SetLocationReorderPoint(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// GetLocationReorderPoint handler
+// @Summary get location reorder point
+// @Description get location reorder point
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param location path string true "location"
+// @Success 200 {object} helper.JSONResponse{data=domain.LocationReorderPoint}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/location/{location}/reorder-point [GET]
+func (h *Handler) GetLocationReorderPoint(c *gin.Context) {
+	resp, err := h.usecase.GetStockAlertUseCase().GetLocationReorderPoint(c, c.Param("location"))
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// DeleteLocationReorderPoint handler
+// @Summary delete location reorder point
+// @Description stop watching the stock of the books at a location that have no reorder point of their own
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param location path string true "location"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/location/{location}/reorder-point [DELETE]
+func (h *Handler) DeleteLocationReorderPoint(c *gin.Context) {
+	err := h.usecase.GetStockAlertUseCase().DeleteLocationReorderPoint(c, c.Param("location"))
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// ListAlerts handler
+// @Summary list stock alerts
+// @Description list low stock alerts, newest first
+// @Tags stock alert
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "status"
+// @Param book_id query int false "book id"
+// @Success 200 {object} helper.JSONResponse{data=[]domain.StockAlert}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/alerts [GET]
+func (h *Handler) ListAlerts(c *gin.Context) {
+	var filter domain.StockAlertFilter
+
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetStockAlertUseCase().ListAlerts(c, &filter)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
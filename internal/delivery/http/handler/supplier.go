@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateSupplier handler
+// @Summary create supplier
+// @Description create supplier
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateSupplierRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/supplier [POST]
+func (h *Handler) CreateSupplier(c *gin.Context) {
+	var req domain.CreateSupplierRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetSupplierUseCase().CreateSupplier(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// ListSuppliers handler
+// @Summary list suppliers
+// @Description list suppliers by name
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} helper.JSONResponse{data=[]domain.Supplier}
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/suppliers [GET]
+func (h *Handler) ListSuppliers(c *gin.Context) {
+	resp, err := h.usecase.GetSupplierUseCase().ListSuppliers(c)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetSupplier handler
+// @Summary get supplier
+// @Description get supplier
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "supplier id"
+// @Success 200 {object} helper.JSONResponse{data=domain.Supplier}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/suppliers/{id} [GET]
+func (h *Handler) GetSupplier(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetSupplierUseCase().GetSupplier(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdateSupplier handler
+// @Summary update supplier
+// @Description update supplier
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "supplier id"
+// @Param input body domain.UpdateSupplierRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/supplier/{id} [PUT]
+func (h *Handler) UpdateSupplier(c *gin.Context) {
+	var req domain.UpdateSupplierRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetSupplierUseCase().UpdateSupplier(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// DeleteSupplier handler
+// @Summary delete supplier
+// @Description delete supplier, suppliers with purchase orders can not be deleted
+// @Tags supplier
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "supplier id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/supplier/{id} [DELETE]
+func (h *Handler) DeleteSupplier(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetSupplierUseCase().DeleteSupplier(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
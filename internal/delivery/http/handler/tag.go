@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// CreateTag handler
+// @Summary create tag
+// @Description create tag, names are stored lower-cased
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param input body domain.CreateTagRequest true "data"
+// @Param Idempotency-Key header string false "idempotency key"
+// @Success 201 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/tag [POST]
+func (h *Handler) CreateTag(c *gin.Context) {
+	var req domain.CreateTagRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err := h.usecase.GetTagUseCase().CreateTag(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusCreated)
+}
+
+// ListTags handler
+// @Summary list tags
+// @Description list tags by name
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} helper.JSONResponse{data=[]domain.Tag}
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/tags [GET]
+func (h *Handler) ListTags(c *gin.Context) {
+	resp, err := h.usecase.GetTagUseCase().ListTags(c)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// UpdateTag handler
+// @Summary rename tag
+// @Description rename tag
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "tag id"
+// @Param input body domain.UpdateTagRequest true "data"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/tag/{id} [PUT]
+func (h *Handler) UpdateTag(c *gin.Context) {
+	var req domain.UpdateTagRequest
+
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	req.ID, err = strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetTagUseCase().UpdateTag(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
+
+// DeleteTag handler
+// @Summary delete tag
+// @Description delete tag and remove it from every book
+// @Tags tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "tag id"
+// @Success 200 {object} helper.JSONResponse
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/managements/tag/{id} [DELETE]
+func (h *Handler) DeleteTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	err = h.usecase.GetTagUseCase().DeleteTag(c, id)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	helper.Success(c, http.StatusOK)
+}
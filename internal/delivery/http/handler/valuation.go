@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+// GetValuation handler
+// @Summary get inventory valuation
+// @Description value the stock on hand at the end of a day from its receipt costs, as json or csv
+// @Tags report
+// @Produce json
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param as_of query string false "date, YYYY-MM-DD, today when empty"
+// @Param method query string false "fifo or weighted_average"
+// @Param currency query string false "currency"
+// @Param format query string false "json or csv"
+// @Success 200 {object} helper.JSONResponse{data=domain.ValuationReport}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/reports/valuation [GET]
+func (h *Handler) GetValuation(c *gin.Context) {
+	var req domain.ValuationRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetValuationUseCase().GetValuation(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	if req.Format == domain.ReportFormatCSV {
+		helper.CSV(c, "valuation-"+resp.AsOf.Format("2006-01-02")+".csv", resp.Records())
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetCOGS handler
+// @Summary get cost of goods sold
+// @Description get the cost of the books shipped in a period net of returns, as json or csv
+// @Tags report
+// @Produce json
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param method query string false "fifo or weighted_average"
+// @Param currency query string false "currency"
+// @Param format query string false "json or csv"
+// @Success 200 {object} helper.JSONResponse{data=domain.COGSReport}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/reports/cogs [GET]
+func (h *Handler) GetCOGS(c *gin.Context) {
+	var req domain.ReportPeriodRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetValuationUseCase().GetCOGS(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	if req.Format == domain.ReportFormatCSV {
+		helper.CSV(c, "cogs-"+resp.From.Format("2006-01-02")+"-"+resp.To.Format("2006-01-02")+".csv", resp.Records())
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
+
+// GetMargin handler
+// @Summary get gross margin
+// @Description get the net sales of a period against their cost per book or per author, as json or csv
+// @Tags report
+// @Produce json
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param from query string true "first day, YYYY-MM-DD"
+// @Param to query string true "last day, YYYY-MM-DD"
+// @Param group_by query string false "book or author"
+// @Param method query string false "fifo or weighted_average"
+// @Param currency query string false "currency"
+// @Param format query string false "json or csv"
+// @Success 200 {object} helper.JSONResponse{data=domain.MarginReport}
+// @Failure 400 {object} helper.JSONResponse
+// @Failure 500 {object} helper.JSONResponse
+// @Router /inventorysvc/reports/margin [GET]
+func (h *Handler) GetMargin(c *gin.Context) {
+	var req domain.ReportPeriodRequest
+
+	if err := c.ShouldBindQuery(&req); err != nil {
+		helper.Error(c, http.StatusBadRequest, "error bad request")
+		return
+	}
+
+	resp, err := h.usecase.GetValuationUseCase().GetMargin(c, &req)
+	if err != nil {
+		helper.InternalError(c, err)
+		return
+	}
+
+	if req.Format == domain.ReportFormatCSV {
+		helper.CSV(c, "margin-"+resp.GroupBy+"-"+resp.From.Format("2006-01-02")+"-"+resp.To.Format("2006-01-02")+".csv", resp.Records())
+		return
+	}
+
+	helper.Success(c, http.StatusOK, resp)
+}
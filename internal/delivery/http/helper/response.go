@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"encoding/csv"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -45,3 +46,15 @@ func InternalError(c *gin.Context, err error) {
 
 	c.JSON(response.Code, response)
 }
+
+// CSV sends records as a csv attachment named filename.
+func CSV(c *gin.Context, filename string, records [][]string) {
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+	c.Writer.Header().Set("Content-Type", "text/csv; charset=utf-8")
+
+	w := csv.NewWriter(c.Writer)
+	if err := w.WriteAll(records); err != nil {
+		_ = c.Error(err)
+	}
+}
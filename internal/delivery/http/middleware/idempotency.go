@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/helper"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/auth"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+)
+
+type IdempotencyMiddleware struct {
+	cfg  *config.MainConfig
+	repo repository.RepositoryImpl
+	log  *slog.Logger
+}
+
+func NewIdempotencyMiddleware(cfg *config.MainConfig, repo repository.RepositoryImpl) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		cfg:  cfg,
+		repo: repo,
+		log:  slog.With("component", "http"),
+	}
+}
+
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotent replays the stored response when a request is retried with the same Idempotency-Key.
+// It must run after JWTAuth because keys are scoped per user. Expired keys are purged by a scheduled
+// job, not by the requests.
+func (m *IdempotencyMiddleware) Idempotent(h ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			m.next(c, h...)
+			return
+		}
+
+		if len(key) > 255 {
+			helper.Error(c, http.StatusBadRequest, "idempotency key is too long")
+			return
+		}
+
+		user := auth.GetUserContext(c)
+		if user == nil {
+			helper.Error(c, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			helper.Error(c, http.StatusBadRequest, "error bad request")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		requestHash := fingerprint(c.Request.Method, c.Request.URL.Path, body)
+		now := time.Now()
+
+		// a replica may not have the key of a retry yet, that would answer it as still in progress
+		record, err := m.repo.GetIdempotencyRepo().GetByKey(repository.ReadYourWrites(c), user.ID, key)
+		if err != nil {
+			helper.InternalError(c, err)
+			return
+		}
+
+		// an expired key that was not purged yet is as good as gone, the new request takes it over
+		if record != nil && record.ExpiresAt.After(now) {
+			m.replay(c, record, requestHash)
+			return
+		}
+
+		if record != nil {
+			if err := m.repo.GetIdempotencyRepo().Delete(c, record.ID); err != nil {
+				helper.InternalError(c, err)
+				return
+			}
+		}
+
+		record = &domain.IdempotencyKey{
+			Key:         key,
+			UserID:      user.ID,
+			Method:      c.Request.Method,
+			Path:        c.Request.URL.Path,
+			RequestHash: requestHash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Duration(m.cfg.IdempotencyKeyTTL) * time.Second),
+		}
+
+		created, err := m.repo.GetIdempotencyRepo().Create(c, record)
+		if err != nil {
+			helper.InternalError(c, err)
+			return
+		}
+
+		if !created {
+			helper.Error(c, http.StatusConflict, "request with this idempotency key is still in progress")
+			return
+		}
+
+		writer := &idempotencyWriter{
+			ResponseWriter: c.Writer,
+			body:           &bytes.Buffer{},
+		}
+		c.Writer = writer
+
+		// a handler that panics leaves no response to store, the key is released before the panic
+		// goes on to the recovery middleware so that the client can retry with it
+		completed := false
+		defer func() {
+			if !completed {
+				m.release(c, record)
+			}
+		}()
+
+		m.next(c, h...)
+		completed = true
+
+		// server errors are not cached so the client can retry with the same key
+		if writer.Status() >= http.StatusInternalServerError {
+			m.release(c, record)
+			return
+		}
+
+		record.StatusCode = writer.Status()
+		record.ContentType = writer.Header().Get("Content-Type")
+		record.ResponseBody = writer.body.String()
+
+		if err := m.repo.GetIdempotencyRepo().UpdateResponse(c, record); err != nil {
+			m.log.ErrorContext(c, "failed to store idempotent response", "idempotency_key", record.Key, "error", err)
+		}
+	}
+}
+
+// release deletes the in progress record of a request that left no response to replay.
+func (m *IdempotencyMiddleware) release(c *gin.Context, record *domain.IdempotencyKey) {
+	if err := m.repo.GetIdempotencyRepo().Delete(c, record.ID); err != nil {
+		m.log.ErrorContext(c, "failed to release idempotency key", "idempotency_key", record.Key, "error", err)
+	}
+}
+
+func (m *IdempotencyMiddleware) replay(c *gin.Context, record *domain.IdempotencyKey, requestHash string) {
+	if record.RequestHash != requestHash {
+		helper.Error(c, http.StatusUnprocessableEntity, "idempotency key was already used with a different request")
+		return
+	}
+
+	if !record.IsCompleted() {
+		helper.Error(c, http.StatusConflict, "request with this idempotency key is still in progress")
+		return
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(record.StatusCode, record.ContentType, []byte(record.ResponseBody))
+}
+
+func (m *IdempotencyMiddleware) next(c *gin.Context, h ...gin.HandlerFunc) {
+	if len(h) > 0 {
+		h[0](c)
+		return
+	}
+
+	c.Next()
+}
+
+func fingerprint(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte(path))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,173 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/auth"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdempotent(t *testing.T) {
+	Convey("Test idempotent", t, func() {
+		gin.SetMode(gin.TestMode)
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		idempotencyRepo := repositoryMock.NewMockIdempotencyRepositoryImpl(ctrl)
+		repoMock.EXPECT().GetIdempotencyRepo().Return(idempotencyRepo).AnyTimes()
+
+		idempotency := NewIdempotencyMiddleware(&config.MainConfig{IdempotencyKeyTTL: 86400}, repoMock)
+
+		var (
+			body   = `{"book_name":"Dune"}`
+			hash   = fingerprint(http.MethodPost, "/books", []byte(body))
+			calls  int
+			answer = func(c *gin.Context) {
+				calls++
+				c.JSON(http.StatusCreated, gin.H{"id": calls})
+			}
+		)
+
+		serve := func(h gin.HandlerFunc) *httptest.ResponseRecorder {
+			app := gin.New()
+			app.Use(Recovery(slog.New(slog.NewTextHandler(io.Discard, nil))))
+			app.POST("/books", func(c *gin.Context) {
+				auth.SetUserContext(c, &domain.User{ID: 7})
+				idempotency.Idempotent(h)(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/books", strings.NewReader(body))
+			req.Header.Set(IdempotencyKeyHeader, "key-1")
+
+			recorder := httptest.NewRecorder()
+			app.ServeHTTP(recorder, req)
+
+			return recorder
+		}
+
+		created := func(_ context.Context, record *domain.IdempotencyKey) (bool, error) {
+			record.ID = 42
+			return true, nil
+		}
+
+		Convey("replays the stored response", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(&domain.IdempotencyKey{
+				ID:           42,
+				RequestHash:  hash,
+				StatusCode:   http.StatusCreated,
+				ContentType:  "application/json; charset=utf-8",
+				ResponseBody: `{"id":1}`,
+				ExpiresAt:    time.Now().Add(time.Hour),
+			}, nil)
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 0)
+			So(resp.Code, ShouldEqual, http.StatusCreated)
+			So(resp.Body.String(), ShouldEqual, `{"id":1}`)
+			So(resp.Header().Get(IdempotencyReplayedHeader), ShouldEqual, "true")
+		})
+
+		Convey("resp err when the key was used with another request", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(&domain.IdempotencyKey{
+				ID:          42,
+				RequestHash: "other",
+				StatusCode:  http.StatusCreated,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}, nil)
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 0)
+			So(resp.Code, ShouldEqual, http.StatusUnprocessableEntity)
+		})
+
+		Convey("resp err while the request of the key is in progress", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(&domain.IdempotencyKey{
+				ID:          42,
+				RequestHash: hash,
+				ExpiresAt:   time.Now().Add(time.Hour),
+			}, nil)
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 0)
+			So(resp.Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("resp err when a concurrent request takes the key first", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(nil, nil)
+			idempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(false, nil)
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 0)
+			So(resp.Code, ShouldEqual, http.StatusConflict)
+		})
+
+		Convey("stores the response", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(nil, nil)
+			idempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created)
+			idempotencyRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, record *domain.IdempotencyKey) error {
+				So(record.ID, ShouldEqual, 42)
+				So(record.RequestHash, ShouldEqual, hash)
+				So(record.StatusCode, ShouldEqual, http.StatusCreated)
+				So(record.ResponseBody, ShouldEqual, `{"id":1}`)
+				So(record.ExpiresAt.Sub(record.CreatedAt), ShouldEqual, 24*time.Hour)
+				return nil
+			})
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 1)
+			So(resp.Code, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("takes over an expired key", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(&domain.IdempotencyKey{
+				ID:          41,
+				RequestHash: "other",
+				StatusCode:  http.StatusCreated,
+				ExpiresAt:   time.Now().Add(-time.Minute),
+			}, nil)
+			idempotencyRepo.EXPECT().Delete(gomock.Any(), 41).Return(nil)
+			idempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created)
+			idempotencyRepo.EXPECT().UpdateResponse(gomock.Any(), gomock.Any()).Return(nil)
+
+			resp := serve(answer)
+			So(calls, ShouldEqual, 1)
+			So(resp.Code, ShouldEqual, http.StatusCreated)
+		})
+
+		Convey("releases the key after a server error", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(nil, nil)
+			idempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created)
+			idempotencyRepo.EXPECT().Delete(gomock.Any(), 42).Return(nil)
+
+			resp := serve(func(c *gin.Context) {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": "error db"})
+			})
+			So(resp.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("releases the key when the handler panics", func() {
+			idempotencyRepo.EXPECT().GetByKey(gomock.Any(), 7, "key-1").Return(nil, nil)
+			idempotencyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(created)
+			idempotencyRepo.EXPECT().Delete(gomock.Any(), 42).Return(nil)
+
+			resp := serve(func(c *gin.Context) {
+				panic("boom")
+			})
+			So(resp.Code, ShouldEqual, http.StatusInternalServerError)
+		})
+	})
+}
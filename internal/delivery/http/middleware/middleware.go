@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 	"strings"
 
@@ -63,3 +64,24 @@ func (m *AuthMiddleware) JWTAuth(h ...gin.HandlerFunc) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// AdminTokenHeader carries the admin token of the operators, users signed in with a JWT are no admins.
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminAuth lets through requests that send the configured admin token.
+func (m *AuthMiddleware) AdminAuth(h ...gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(AdminTokenHeader)
+		if m.cfg.AdminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(m.cfg.AdminToken)) != 1 {
+			helper.Error(c, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+
+		if len(h) > 0 {
+			h[0](c)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAdminAuth(t *testing.T) {
+	Convey("Test admin auth", t, func() {
+		gin.SetMode(gin.TestMode)
+
+		serve := func(cfg *config.MainConfig, token string) *httptest.ResponseRecorder {
+			auth := NewAuthMiddleware(cfg, nil)
+
+			app := gin.New()
+			app.GET("/admin", auth.AdminAuth(func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+			if token != "" {
+				req.Header.Set(AdminTokenHeader, token)
+			}
+
+			recorder := httptest.NewRecorder()
+			app.ServeHTTP(recorder, req)
+
+			return recorder
+		}
+
+		cfg := &config.MainConfig{AdminToken: "s3cret"}
+
+		Convey("resp err without the token", func() {
+			So(serve(cfg, "").Code, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("resp err with another token", func() {
+			So(serve(cfg, "secret").Code, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("resp err when no token is configured", func() {
+			So(serve(&config.MainConfig{}, "").Code, ShouldEqual, http.StatusUnauthorized)
+		})
+
+		Convey("resp success with the token", func() {
+			So(serve(cfg, "s3cret").Code, ShouldEqual, http.StatusNoContent)
+		})
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -21,6 +21,7 @@ import (
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/delivery/http/middleware"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/usecase"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
 )
 
 // NewRest
@@ -38,20 +39,25 @@ import (
 // @securityDefinitions.apiKey ApiKeyAuth
 // @in header
 // @name authorization
+// @securityDefinitions.apiKey AdminTokenAuth
+// @in header
+// @name X-Admin-Token
 
 func NewRest(cfg *config.MainConfig) *gin.Engine {
 	if cfg.Environment != "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	app := gin.Default()
+	app := gin.New()
+	app.Use(middleware.RequestID(), middleware.AccessLog(cfg.Logger.For("http")), middleware.Metrics())
 
 	app.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
+	app.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	app.Use(func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, Idempotency-Key")
 		c.Header("Access-Control-Allow-Methods", "POST, HEAD, PATCH, OPTIONS, GET, PUT, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
@@ -69,37 +75,37 @@ func Serve(app *gin.Engine, cfg *config.MainConfig) (err error) {
 		Handler: app,
 	}
 
+	logger := cfg.Logger.For("http")
+
 	go func() {
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatalf("error: %s\n", err)
+			logger.Error("server failed", "error", err)
+			os.Exit(1)
 		}
 	}()
 
-	log.Println("-------------------------------------------")
-	log.Println("server started")
-	log.Printf("running on port %d\n", cfg.ServicePort)
-	log.Println("-------------------------------------------")
+	logger.Info("server started", "port", cfg.ServicePort)
 
-	return gracefulShutdown(server)
+	return gracefulShutdown(server, logger)
 }
 
-func gracefulShutdown(srv *http.Server) error {
-	done := make(chan os.Signal)
+func gracefulShutdown(srv *http.Server, logger *slog.Logger) error {
+	done := make(chan os.Signal, 1)
 
 	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
 
 	<-done
-	log.Println("Shutting down server...")
+	logger.Info("shutting down server")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Error while shutting down Server. Initiating force shutdown...")
+		logger.Error("failed to shut down the server, initiating force shutdown", "error", err)
 		return err
 	}
 
-	log.Println("Server exiting...")
+	logger.Info("server exited")
 
 	return nil
 }
@@ -112,25 +118,123 @@ type Route struct {
 }
 
 func (r *Route) RegisterRoutes() {
-	r.App.Use(gin.Recovery())
+	r.App.Use(middleware.Recovery(r.Config.Logger.For("http")))
 
 	auth := middleware.NewAuthMiddleware(r.Config, r.Repository)
+	idempotency := middleware.NewIdempotencyMiddleware(r.Config, r.Repository)
 
+	logLevels := handler.NewLogLevelHandler(r.Config.Logger)
 	handler := handler.NewHandler(r.UseCase)
 
 	inventorySvc := r.App.Group("/inventorysvc")
 	inventorySvc.POST("/auth/register", handler.Register)
 	inventorySvc.POST("/auth/login", handler.Login)
 
-	inventorySvc.POST("/managements/book", auth.JWTAuth(handler.AddBook))
+	inventorySvc.POST("/managements/book", auth.JWTAuth(idempotency.Idempotent(handler.AddBook)))
+	inventorySvc.POST("/managements/book/batch", auth.JWTAuth(idempotency.Idempotent(handler.BatchBooks)))
 	inventorySvc.PUT("/managements/book/:id", auth.JWTAuth(handler.UpdateBook))
+	inventorySvc.PATCH("/managements/book/:id", auth.JWTAuth(handler.PatchBook))
+	inventorySvc.GET("/managements/book/:id/prices", auth.JWTAuth(handler.GetPriceTimeline))
+	inventorySvc.PUT("/managements/book/:id/prices", auth.JWTAuth(handler.SetBookPrices))
+	inventorySvc.POST("/managements/book/:id/prices/schedules", auth.JWTAuth(idempotency.Idempotent(handler.SchedulePrice)))
+	inventorySvc.DELETE("/managements/book/:id/prices/schedules/:scheduleid", auth.JWTAuth(handler.CancelScheduledPrice))
+	inventorySvc.GET("/managements/book/:id/reorder-point", auth.JWTAuth(handler.GetReorderPoint))
+	inventorySvc.PUT("/managements/book/:id/reorder-point", auth.JWTAuth(handler.SetReorderPoint))
+	inventorySvc.DELETE("/managements/book/:id/reorder-point", auth.JWTAuth(handler.DeleteReorderPoint))
+	inventorySvc.GET("/managements/location/:location/reorder-point", auth.JWTAuth(handler.GetLocationReorderPoint))
+	inventorySvc.PUT("/managements/location/:location/reorder-point", auth.JWTAuth(handler.SetLocationReorderPoint))
+	inventorySvc.DELETE("/managements/location/:location/reorder-point", auth.JWTAuth(handler.DeleteLocationReorderPoint))
 	inventorySvc.DELETE("/managements/book/:id", auth.JWTAuth(handler.DeleteBook))
 	inventorySvc.GET("/managements/book/:id", auth.JWTAuth(handler.GetDetailBook))
 
-	inventorySvc.POST("/managements/author/book", auth.JWTAuth(handler.CreateAuthorAndBook))
-	inventorySvc.POST("/managements/author", auth.JWTAuth(handler.CreateAuthor))
-	inventorySvc.POST("/managements/author/:id", auth.JWTAuth(handler.AddAuthorBook))
+	inventorySvc.GET("/books", auth.JWTAuth(handler.ListBooks))
+	inventorySvc.GET("/books/lookup", auth.JWTAuth(handler.LookupBook))
+	inventorySvc.GET("/books/:id/barcode", auth.JWTAuth(handler.RenderBarcode))
+	inventorySvc.POST("/books/labels", auth.JWTAuth(handler.RenderLabelSheet))
+	inventorySvc.GET("/books/:id/stock", auth.JWTAuth(handler.GetStockLedger))
+	inventorySvc.GET("/alerts", auth.JWTAuth(handler.ListAlerts))
+	inventorySvc.GET("/reports/valuation", auth.JWTAuth(handler.GetValuation))
+	inventorySvc.GET("/reports/cogs", auth.JWTAuth(handler.GetCOGS))
+	inventorySvc.GET("/reports/margin", auth.JWTAuth(handler.GetMargin))
+	inventorySvc.GET("/analytics/top-selling", auth.JWTAuth(handler.TopSellingBooks))
+	inventorySvc.GET("/analytics/slow-movers", auth.JWTAuth(handler.SlowMovers))
+	inventorySvc.GET("/analytics/days-on-hand", auth.JWTAuth(handler.DaysOnHand))
+	inventorySvc.GET("/analytics/stock-turnover", auth.JWTAuth(handler.StockTurnover))
+	inventorySvc.GET("/analytics/revenue-by-author", auth.JWTAuth(handler.RevenueByAuthor))
+	inventorySvc.GET("/forecasts", auth.JWTAuth(handler.ForecastDemand))
+
+	inventorySvc.POST("/managements/author/book", auth.JWTAuth(idempotency.Idempotent(handler.CreateAuthorAndBook)))
+	inventorySvc.POST("/managements/author", auth.JWTAuth(idempotency.Idempotent(handler.CreateAuthor)))
+	inventorySvc.POST("/managements/author/:id", auth.JWTAuth(idempotency.Idempotent(handler.AddAuthorBook)))
+	inventorySvc.PATCH("/managements/author/:id", auth.JWTAuth(handler.PatchAuthor))
 	inventorySvc.GET("/managements/author/:id/list", auth.JWTAuth(handler.GetListBookByAuthor))
 	inventorySvc.DELETE("managements/author/:id/books/:bookid", auth.JWTAuth(handler.DeleteBookByAuthor))
 
+	inventorySvc.POST("/managements/publisher", auth.JWTAuth(idempotency.Idempotent(handler.CreatePublisher)))
+	inventorySvc.PUT("/managements/publisher/:id", auth.JWTAuth(handler.UpdatePublisher))
+	inventorySvc.DELETE("/managements/publisher/:id", auth.JWTAuth(handler.DeletePublisher))
+	inventorySvc.GET("/publishers", auth.JWTAuth(handler.ListPublishers))
+	inventorySvc.GET("/publishers/:id", auth.JWTAuth(handler.GetPublisher))
+
+	inventorySvc.POST("/managements/promotion", auth.JWTAuth(idempotency.Idempotent(handler.CreatePromotion)))
+	inventorySvc.PUT("/managements/promotion/:id", auth.JWTAuth(handler.UpdatePromotion))
+	inventorySvc.DELETE("/managements/promotion/:id", auth.JWTAuth(handler.DeletePromotion))
+	inventorySvc.GET("/promotions", auth.JWTAuth(handler.ListPromotions))
+	inventorySvc.GET("/promotions/:id", auth.JWTAuth(handler.GetPromotion))
+	inventorySvc.POST("/pricing/quote", auth.JWTAuth(handler.QuoteBasket))
+
+	inventorySvc.POST("/managements/supplier", auth.JWTAuth(idempotency.Idempotent(handler.CreateSupplier)))
+	inventorySvc.PUT("/managements/supplier/:id", auth.JWTAuth(handler.UpdateSupplier))
+	inventorySvc.DELETE("/managements/supplier/:id", auth.JWTAuth(handler.DeleteSupplier))
+	inventorySvc.GET("/suppliers", auth.JWTAuth(handler.ListSuppliers))
+	inventorySvc.GET("/suppliers/:id", auth.JWTAuth(handler.GetSupplier))
+
+	inventorySvc.POST("/managements/purchase-order", auth.JWTAuth(idempotency.Idempotent(handler.CreatePurchaseOrder)))
+	inventorySvc.PUT("/managements/purchase-order/:id", auth.JWTAuth(handler.UpdatePurchaseOrder))
+	inventorySvc.POST("/managements/purchase-order/:id/submit", auth.JWTAuth(handler.SubmitPurchaseOrder))
+	inventorySvc.POST("/managements/purchase-order/:id/cancel", auth.JWTAuth(handler.CancelPurchaseOrder))
+	inventorySvc.POST("/managements/purchase-order/:id/receipts", auth.JWTAuth(idempotency.Idempotent(handler.ReceiveGoods)))
+	inventorySvc.GET("/purchase-orders", auth.JWTAuth(handler.ListPurchaseOrders))
+	inventorySvc.GET("/purchase-orders/:id", auth.JWTAuth(handler.GetPurchaseOrder))
+
+	inventorySvc.POST("/orders", auth.JWTAuth(idempotency.Idempotent(handler.CreateOrder)))
+	inventorySvc.GET("/orders", auth.JWTAuth(handler.ListOrders))
+	inventorySvc.GET("/orders/:id", auth.JWTAuth(handler.GetOrder))
+	inventorySvc.POST("/orders/:id/pay", auth.JWTAuth(handler.PayOrder))
+	inventorySvc.POST("/orders/:id/pick", auth.JWTAuth(handler.PickOrder))
+	inventorySvc.POST("/orders/:id/ship", auth.JWTAuth(handler.ShipOrder))
+	inventorySvc.POST("/orders/:id/deliver", auth.JWTAuth(handler.DeliverOrder))
+	inventorySvc.POST("/orders/:id/cancel", auth.JWTAuth(handler.CancelOrder))
+	inventorySvc.POST("/orders/:id/return", auth.JWTAuth(handler.ReturnOrder))
+
+	inventorySvc.POST("/rmas", auth.JWTAuth(idempotency.Idempotent(handler.CreateRMA)))
+	inventorySvc.GET("/rmas", auth.JWTAuth(handler.ListRMAs))
+	inventorySvc.GET("/rmas/:id", auth.JWTAuth(handler.GetRMA))
+	inventorySvc.POST("/rmas/:id/inspect", auth.JWTAuth(idempotency.Idempotent(handler.InspectRMA)))
+	inventorySvc.POST("/rmas/:id/cancel", auth.JWTAuth(handler.CancelRMA))
+
+	inventorySvc.POST("/managements/cycle-count", auth.JWTAuth(idempotency.Idempotent(handler.CreateCycleCount)))
+	inventorySvc.PUT("/managements/cycle-count/:id/counts", auth.JWTAuth(handler.SubmitCounts))
+	inventorySvc.POST("/managements/cycle-count/:id/approve", auth.JWTAuth(handler.ApproveCycleCount))
+	inventorySvc.POST("/managements/cycle-count/:id/cancel", auth.JWTAuth(handler.CancelCycleCount))
+	inventorySvc.GET("/cycle-counts", auth.JWTAuth(handler.ListCycleCounts))
+	inventorySvc.GET("/cycle-counts/:id", auth.JWTAuth(handler.GetCycleCount))
+	inventorySvc.GET("/cycle-counts/:id/variance", auth.JWTAuth(handler.GetCycleCountVariance))
+
+	inventorySvc.POST("/managements/category", auth.JWTAuth(idempotency.Idempotent(handler.CreateCategory)))
+	inventorySvc.PUT("/managements/category/:id", auth.JWTAuth(handler.UpdateCategory))
+	inventorySvc.DELETE("/managements/category/:id", auth.JWTAuth(handler.DeleteCategory))
+	inventorySvc.GET("/categories", auth.JWTAuth(handler.GetCategoryTree))
+	inventorySvc.GET("/categories/:id", auth.JWTAuth(handler.GetCategory))
+
+	inventorySvc.POST("/managements/tag", auth.JWTAuth(idempotency.Idempotent(handler.CreateTag)))
+	inventorySvc.PUT("/managements/tag/:id", auth.JWTAuth(handler.UpdateTag))
+	inventorySvc.DELETE("/managements/tag/:id", auth.JWTAuth(handler.DeleteTag))
+	inventorySvc.GET("/tags", auth.JWTAuth(handler.ListTags))
+
+	// the admin routes are for operators, not users, and only served once ADMIN_TOKEN is set
+	if r.Config.AdminToken != "" {
+		inventorySvc.GET("/admin/log-levels", auth.AdminAuth(logLevels.GetLogLevels))
+		inventorySvc.PUT("/admin/log-levels", auth.AdminAuth(logLevels.SetLogLevels))
+	}
 }
@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+
+	// AnalyticsRollups names the refresh watermark of book_sales_daily and book_stock_daily
+	AnalyticsRollups = "rollups"
+
+	// MaxAnalyticsBuckets keeps a report from asking for years of daily buckets
+	MaxAnalyticsBuckets = 400
+)
+
+// AnalyticsRequest covers the days From up to and including To, read from the rollups so sales of
+// the current day show up after the next refresh.
+type AnalyticsRequest struct {
+	From     time.Time `form:"from" time_format:"2006-01-02" validate:"required"`
+	To       time.Time `form:"to" time_format:"2006-01-02" validate:"required,gtefield=From"`
+	Bucket   string    `form:"bucket" validate:"omitempty,oneof=day week month"`
+	BookID   int       `form:"book_id" validate:"omitempty,gt=0"`
+	AuthorID int       `form:"author_id" validate:"omitempty,gt=0"`
+	Limit    int       `form:"limit" validate:"omitempty,gt=0,max=100"`
+	Currency string    `form:"currency" validate:"omitempty,currency"`
+}
+
+// End is the first day after the period.
+func (r *AnalyticsRequest) End() time.Time {
+	return r.To.AddDate(0, 0, 1)
+}
+
+// Days is the length of the period, both ends included.
+func (r *AnalyticsRequest) Days() int {
+	return DaysBetween(r.From, r.End())
+}
+
+// Buckets splits the period by day, calendar week starting on monday, or calendar month. The first
+// and last bucket are cut to the period.
+func (r *AnalyticsRequest) Buckets() []*Bucket {
+	var buckets []*Bucket
+	for start := r.From; start.Before(r.End()); {
+		end := BucketStart(start, r.Bucket)
+		switch r.Bucket {
+		case BucketWeek:
+			end = end.AddDate(0, 0, 7)
+		case BucketMonth:
+			end = end.AddDate(0, 1, 0)
+		default:
+			end = end.AddDate(0, 0, 1)
+		}
+
+		if end.After(r.End()) {
+			end = r.End()
+		}
+
+		buckets = append(buckets, &Bucket{Start: start, End: end})
+		start = end
+	}
+
+	return buckets
+}
+
+// Bucket holds the days from Start up to, not including, End.
+type Bucket struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+func (b *Bucket) Days() int {
+	return DaysBetween(b.Start, b.End)
+}
+
+// BucketStart truncates day to the start of its bucket, like date_trunc does in the rollup queries.
+func BucketStart(day time.Time, bucket string) time.Time {
+	y, m, d := day.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, day.Location())
+
+	switch bucket {
+	case BucketWeek:
+		return start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+	case BucketMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, day.Location())
+	}
+
+	return start
+}
+
+// DayKey identifies a calendar day whatever the location of t, dates come back from the database in UTC.
+func DayKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// DaysBetween counts the calendar days from from up to to, ignoring the time of day.
+func DaysBetween(from, to time.Time) int {
+	y1, m1, d1 := from.Date()
+	y2, m2, d2 := to.Date()
+
+	return int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+type AnalyticsRefresh struct {
+	Name             string    `gorm:"column:name;primaryKey"`
+	RefreshedThrough time.Time `gorm:"column:refreshed_through"`
+	RefreshedAt      time.Time `gorm:"column:refreshed_at"`
+}
+
+func (AnalyticsRefresh) TableName() string {
+	return "analytics_refreshes"
+}
+
+// DailyQuantity is a rollup total of one day or, with a bucket, of the bucket starting on Day.
+type DailyQuantity struct {
+	Day      time.Time `gorm:"column:day;serializer:date"`
+	Quantity int64     `gorm:"column:quantity"`
+}
+
+// BookStockSales is a stocked book with the copies it sold in a period and the day it last sold any.
+type BookStockSales struct {
+	BookID     int        `gorm:"column:book_id" json:"book_id"`
+	Title      string     `gorm:"column:title" json:"title"`
+	Stock      int64      `gorm:"column:stock" json:"stock"`
+	Quantity   int64      `gorm:"column:quantity" json:"quantity"`
+	LastSoldOn *time.Time `gorm:"column:last_sold_on;serializer:date" json:"last_sold_on"`
+}
+
+type BookRevenueRow struct {
+	BookID   int         `gorm:"column:book_id"`
+	Title    string      `gorm:"column:title"`
+	Quantity int64       `gorm:"column:quantity"`
+	Revenue  money.Money `gorm:"embedded;embeddedPrefix:revenue_"`
+}
+
+type AuthorRevenueRow struct {
+	AuthorID int         `gorm:"column:author_id"`
+	Name     string      `gorm:"column:name"`
+	Bucket   time.Time   `gorm:"column:bucket;serializer:date"`
+	Quantity int64       `gorm:"column:quantity"`
+	Revenue  money.Money `gorm:"embedded;embeddedPrefix:revenue_"`
+}
+
+type TopSellingBook struct {
+	BookID   int         `json:"book_id"`
+	Title    string      `json:"title"`
+	Quantity int64       `json:"quantity"`
+	Revenue  money.Money `json:"revenue"`
+}
+
+type SlowMover struct {
+	BookStockSales
+	// DaysSinceLastSale is counted up to the end of the period, nil for books that never sold
+	DaysSinceLastSale *int `json:"days_since_last_sale"`
+}
+
+// DaysOnHand is how many days the stock of a book lasts at the average daily sales of the period,
+// nil when it sold nothing.
+type DaysOnHand struct {
+	BookID            int      `json:"book_id"`
+	Title             string   `json:"title"`
+	Stock             int64    `json:"stock"`
+	Quantity          int64    `json:"quantity"`
+	AverageDailySales float64  `json:"average_daily_sales"`
+	DaysOnHand        *float64 `json:"days_on_hand"`
+}
+
+// TurnoverBucket relates the copies sold in a bucket to the average of its opening and closing stock,
+// DaysOnHand is the number of days the average stock lasted at that pace.
+type TurnoverBucket struct {
+	Bucket
+	Quantity     int64    `json:"quantity"`
+	OpeningStock int64    `json:"opening_stock"`
+	ClosingStock int64    `json:"closing_stock"`
+	AverageStock float64  `json:"average_stock"`
+	Turnover     *float64 `json:"turnover"`
+	DaysOnHand   *float64 `json:"days_on_hand"`
+}
+
+type StockTurnoverReport struct {
+	BookID  int               `json:"book_id,omitempty"`
+	Bucket  string            `json:"bucket"`
+	Total   *TurnoverBucket   `json:"total"`
+	Buckets []*TurnoverBucket `json:"buckets"`
+}
+
+type RevenueBucket struct {
+	Bucket
+	Quantity int64       `json:"quantity"`
+	Revenue  money.Money `json:"revenue"`
+}
+
+type AuthorRevenue struct {
+	AuthorID int              `json:"author_id"`
+	Name     string           `json:"name"`
+	Quantity int64            `json:"quantity"`
+	Revenue  money.Money      `json:"revenue"`
+	Buckets  []*RevenueBucket `json:"buckets"`
+}
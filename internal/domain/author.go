@@ -1,15 +1,22 @@
 package domain
 
+import "github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+
 type CreateAuthorAndBookRequest struct {
 	Author CreateAuthorRequest `json:"author" validate:"required"`
 	Book   CreateBookRequest   `json:"book" validate:"required"`
 }
 
 type AddAuthorBookRequest struct {
-	AuthorID int    `json:"-"`
-	BookName string `json:"book_name" validate:"required"`
-	Title    string `json:"title" validate:"required"`
-	Price    int    `json:"price" validate:"required"`
+	AuthorID int `json:"-"`
+	// Contributors credits more authors next to the one in the path
+	Contributors []*ContributorRequest `json:"contributors" validate:"omitempty,dive"`
+	BookName     string                `json:"book_name" validate:"required"`
+	Title        string                `json:"title" validate:"required"`
+	Price        money.Money           `json:"price"`
+	ISBN         string                `json:"isbn" validate:"omitempty,isbn"`
+	Location     string                `json:"location" validate:"omitempty,max=50"`
+	BookTaxonomyRequest
 }
 
 type CreateAuthorRequest struct {
@@ -18,6 +25,13 @@ type CreateAuthorRequest struct {
 	PhoneNumber string `json:"phone_number" validate:"required"`
 }
 
+// PatchAuthorRequest holds the members of a merge patch, nil fields were absent from the patch.
+type PatchAuthorRequest struct {
+	Name        *string `json:"name" validate:"omitempty,notEmpty"`
+	Email       *string `json:"email" validate:"omitempty,email"`
+	PhoneNumber *string `json:"phone_number" validate:"omitempty,notEmpty"`
+}
+
 type Author struct {
 	ID          int    `gorm:"column:id" json:"id"`
 	Name        string `gorm:"column:name" json:"name"`
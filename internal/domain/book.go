@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/identifier"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
 
 type CreateDetailBook struct {
 	Id       int    `json:"id"`
@@ -11,40 +16,287 @@ type CreateDetailBook struct {
 }
 
 type DetailBook struct {
-	ID         int       `gorm:"column:id" json:"id"`
-	AuthorID   int       `gorm:"column:author_id" json:"author_id"`
-	AuthorName string    `gorm:"column:author_name" json:"author_name"`
-	BookName   string    `gorm:"column:book_name" json:"book_name"`
-	Title      string    `gorm:"column:title" json:"title"`
-	Price      int       `gorm:"column:price" json:"price"`
-	CreatedAt  time.Time `gorm:"column:created_at" json:"created_at"`
+	ID         int         `gorm:"column:id" json:"id"`
+	AuthorID   int         `gorm:"column:author_id" json:"author_id"`
+	AuthorName string      `gorm:"column:author_name" json:"author_name"`
+	BookName   string      `gorm:"column:book_name" json:"book_name"`
+	Title      string      `gorm:"column:title" json:"title"`
+	Price      money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
+	ISBN13     *string     `gorm:"column:isbn13" json:"isbn13"`
+	ISBN10     *string     `gorm:"column:isbn10" json:"isbn10"`
+	SKU        string      `gorm:"column:sku" json:"sku"`
+	Barcode    string      `gorm:"column:barcode" json:"barcode"`
+	Location   string      `gorm:"column:location" json:"location"`
+	CreatedAt  time.Time   `gorm:"column:created_at" json:"created_at"`
+	Stock      int         `gorm:"column:stock" json:"stock"`
+	Reserved   int         `gorm:"column:reserved" json:"reserved"`
+
+	Contributors []*DetailContributor `gorm:"-" json:"contributors"`
+	PublisherID  *int                 `gorm:"-" json:"publisher_id"`
+	Publisher    *Publisher           `gorm:"-" json:"publisher"`
+	Categories   []*Category          `gorm:"-" json:"categories"`
+	Tags         []*Tag               `gorm:"-" json:"tags"`
+
+	// PriceConverted is set when price was converted with an exchange rate instead of a list price
+	PriceConverted bool         `gorm:"-" json:"price_converted,omitempty"`
+	Prices         []*BookPrice `gorm:"-" json:"prices"`
+}
+
+// BookTaxonomyRequest links a book to its publisher, categories and tags. Absent fields leave
+// the current links untouched on update, tags that do not exist yet are created.
+type BookTaxonomyRequest struct {
+	PublisherID *int     `json:"publisher_id" validate:"omitempty,gt=0"`
+	CategoryIDs []int    `json:"category_ids" validate:"omitempty,max=20,dive,gt=0"`
+	Tags        []string `json:"tags" validate:"omitempty,max=50,dive,notEmpty,max=50"`
 }
 
 type UpdateBookRequest struct {
-	ID       int
-	AuthorID int    `json:"author_id" validate:"required"`
-	BookName string `json:"book_name"  validate:"required"`
-	Title    string `json:"title"  validate:"required"`
-	Price    int    `json:"price"  validate:"required"`
+	ID           int
+	AuthorID     int                   `json:"author_id" validate:"required_without=Contributors"`
+	Contributors []*ContributorRequest `json:"contributors" validate:"omitempty,dive"`
+	BookName     string                `json:"book_name"  validate:"required"`
+	Title        string                `json:"title"  validate:"required"`
+	Price        money.Money           `json:"price"`
+	PriceReason  string                `json:"price_reason" validate:"omitempty,max=255"`
+	ISBN         string                `json:"isbn" validate:"omitempty,isbn"`
+	Location     string                `json:"location" validate:"omitempty,max=50"`
+	BookTaxonomyRequest
+}
+
+// PatchBookRequest holds the members of a merge patch, nil fields were absent from the patch.
+type PatchBookRequest struct {
+	AuthorID     *int                  `json:"author_id" validate:"omitempty,gt=0"`
+	Contributors []*ContributorRequest `json:"contributors" validate:"omitempty,dive"`
+	BookName     *string               `json:"book_name" validate:"omitempty,notEmpty"`
+	Title        *string               `json:"title" validate:"omitempty,notEmpty"`
+	Price        *money.Money          `json:"price" validate:"omitempty"`
+	PriceReason  *string               `json:"price_reason" validate:"omitempty,max=255"`
+	ISBN         *string               `json:"isbn" validate:"omitempty,isbn"`
+	Location     *string               `json:"location" validate:"omitempty,max=50"`
+	BookTaxonomyRequest
 }
 
 type CreateBookRequest struct {
-	AuthorID  int       `json:"author_id"  validate:"required"`
-	BookName  string    `json:"book_name"  validate:"required"`
-	Title     string    `json:"title"  validate:"required"`
-	Price     int       `json:"price"  validate:"required"`
-	CreatedAt time.Time `json:"created_at"`
+	AuthorID     int                   `json:"author_id" validate:"required_without=Contributors"`
+	Contributors []*ContributorRequest `json:"contributors" validate:"omitempty,dive"`
+	BookName     string                `json:"book_name"  validate:"required"`
+	Title        string                `json:"title"  validate:"required"`
+	Price        money.Money           `json:"price"`
+	ISBN         string                `json:"isbn" validate:"omitempty,isbn"`
+	Location     string                `json:"location" validate:"omitempty,max=50"`
+	CreatedAt    time.Time             `json:"created_at"`
+	BookTaxonomyRequest
+}
+
+// BookLookupRequest finds a book by exactly one of its scanner identifiers.
+type BookLookupRequest struct {
+	ISBN    string `form:"isbn" validate:"omitempty,isbn"`
+	SKU     string `form:"sku"`
+	Barcode string `form:"barcode" validate:"omitempty,len=13,numeric"`
+
+	Currency string `form:"currency" validate:"omitempty,currency"`
 }
 
 type Book struct {
-	ID        int       `gorm:"column:id" json:"id"`
-	AuthorID  int       `gorm:"column:author_id" json:"author_id"`
-	BookName  string    `gorm:"column:book_name" json:"book_name"`
-	Title     string    `gorm:"column:title" json:"title"`
-	Price     int       `gorm:"column:price" json:"price"`
-	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+	ID int `gorm:"column:id" json:"id"`
+	// AuthorID is the primary author, kept for clients that predate contributors
+	AuthorID  int         `gorm:"-" json:"author_id"`
+	BookName  string      `gorm:"column:book_name" json:"book_name"`
+	Title     string      `gorm:"column:title" json:"title"`
+	Price     money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
+	ISBN13    *string     `gorm:"column:isbn13" json:"isbn13"`
+	ISBN10    *string     `gorm:"column:isbn10" json:"isbn10"`
+	SKU       string      `gorm:"column:sku" json:"sku"`
+	Barcode   string      `gorm:"column:barcode" json:"barcode"`
+	Location  string      `gorm:"column:location" json:"location"`
+	CreatedAt time.Time   `gorm:"column:created_at" json:"created_at"`
+	// Stock and Reserved are read only here, they change through stock movements and order reservations
+	Stock    int `gorm:"column:stock;->" json:"stock"`
+	Reserved int `gorm:"column:reserved;->" json:"reserved"`
+
+	Contributors []*BookContributor `gorm:"foreignKey:BookID" json:"contributors"`
+	PublisherID  *int               `gorm:"column:publisher_id" json:"publisher_id"`
+	Publisher    *Publisher         `gorm:"foreignKey:PublisherID" json:"publisher,omitempty"`
+	Categories   []*Category        `gorm:"many2many:book_categories" json:"categories"`
+	Tags         []*Tag             `gorm:"many2many:book_tags" json:"tags"`
+
+	Prices         []*BookPrice `gorm:"foreignKey:BookID" json:"prices"`
+	PriceConverted bool         `gorm:"-" json:"price_converted,omitempty"`
+}
+
+// BookFilter narrows the book listing, zero values are ignored.
+type BookFilter struct {
+	Query       string   `form:"q" validate:"omitempty,max=100"`
+	AuthorID    int      `form:"author_id" validate:"omitempty,gt=0"`
+	PublisherID int      `form:"publisher_id" validate:"omitempty,gt=0"`
+	CategoryID  int      `form:"category_id" validate:"omitempty,gt=0"`
+	Tags        []string `form:"tag" validate:"omitempty,max=10"`
+	Page        int      `form:"page" validate:"omitempty,gt=0"`
+	Limit       int      `form:"limit" validate:"omitempty,gt=0,max=100"`
+	Currency    string   `form:"currency" validate:"omitempty,currency"`
+
+	// CategoryIDs holds CategoryID and all its descendants, it is resolved by the usecase
+	CategoryIDs []int `form:"-" swaggerignore:"true"`
+}
+
+func (f *BookFilter) Offset() int {
+	return (f.Page - 1) * f.Limit
+}
+
+type BookListResponse struct {
+	Books []*Book `json:"books"`
+	Total int64   `json:"total"`
+	Page  int     `json:"page"`
+	Limit int     `json:"limit"`
 }
 
 func (Book) TableName() string {
 	return "books"
 }
+
+// PrimaryAuthorID returns the first contributor with the author role, or the first contributor
+// when the book has no author, e.g. an anthology credited to its editor.
+func (b *Book) PrimaryAuthorID() int {
+	for _, contributor := range b.Contributors {
+		if contributor.Role == ContributorRoleAuthor {
+			return contributor.AuthorID
+		}
+	}
+
+	if len(b.Contributors) > 0 {
+		return b.Contributors[0].AuthorID
+	}
+
+	return 0
+}
+
+// SetPrimaryAuthor replaces the first author with authorID, the remaining contributors keep their order.
+func (b *Book) SetPrimaryAuthor(authorID int) {
+	if b.PrimaryAuthorID() == authorID {
+		b.AuthorID = authorID
+		return
+	}
+
+	reqs := make([]*ContributorRequest, 0, len(b.Contributors))
+	replaced := false
+	for _, contributor := range b.Contributors {
+		if contributor.Role == ContributorRoleAuthor && !replaced {
+			replaced = true
+			continue
+		}
+
+		reqs = append(reqs, &ContributorRequest{
+			AuthorID: contributor.AuthorID,
+			Role:     contributor.Role,
+		})
+	}
+
+	b.SetContributors(authorID, reqs)
+}
+
+func (b *Book) SetContributors(authorID int, reqs []*ContributorRequest) {
+	b.Contributors = NewContributors(authorID, reqs)
+	for _, contributor := range b.Contributors {
+		contributor.BookID = b.ID
+	}
+
+	b.AuthorID = b.PrimaryAuthorID()
+}
+
+// SetISBN stores both ISBN forms and uses the ISBN-13 as barcode. An empty isbn clears it and
+// replaces an ISBN derived barcode with an internal one.
+func (b *Book) SetISBN(isbn string) error {
+	if isbn == "" {
+		b.ISBN13, b.ISBN10 = nil, nil
+		if b.Barcode != "" && !identifier.IsValidISBN13(b.Barcode) {
+			return nil
+		}
+
+		barcode, err := identifier.GenerateBarcode()
+		if err != nil {
+			return err
+		}
+
+		b.Barcode = barcode
+		return nil
+	}
+
+	isbn13, err := identifier.ToISBN13(isbn)
+	if err != nil {
+		return err
+	}
+
+	b.ISBN13, b.ISBN10 = &isbn13, nil
+	if isbn10, err := identifier.ToISBN10(isbn13); err == nil {
+		b.ISBN10 = &isbn10
+	}
+
+	b.Barcode = isbn13
+
+	return nil
+}
+
+const (
+	BatchOperationCreate = "create"
+	BatchOperationUpdate = "update"
+	BatchOperationDelete = "delete"
+)
+
+type BookBatchRequest struct {
+	Atomic     bool                  `json:"-"`
+	Operations []*BookBatchOperation `json:"operations" validate:"required,min=1,max=500"`
+}
+
+type BookBatchOperation struct {
+	Operation    string                `json:"operation" validate:"required,oneof=create update delete"`
+	ID           int                   `json:"id"`
+	AuthorID     int                   `json:"author_id"`
+	Contributors []*ContributorRequest `json:"contributors"`
+	BookName     string                `json:"book_name"`
+	Title        string                `json:"title"`
+	Price        money.Money           `json:"price" validate:"-"`
+	PriceReason  string                `json:"price_reason"`
+	ISBN         string                `json:"isbn"`
+	Location     string                `json:"location"`
+	BookTaxonomyRequest
+}
+
+type BookBatchResult struct {
+	Index     int    `json:"index"`
+	Operation string `json:"operation"`
+	ID        int    `json:"id,omitempty"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+type BookBatchResponse struct {
+	Atomic    bool               `json:"atomic"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []*BookBatchResult `json:"results"`
+}
+
+func (r *BookBatchResult) SetOutcome(id int, err error) {
+	if id != 0 {
+		r.ID = id
+	}
+
+	r.Success = err == nil
+	r.Error = ""
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+func (r *BookBatchResponse) Summarize() *BookBatchResponse {
+	r.Succeeded, r.Failed = 0, 0
+	for _, result := range r.Results {
+		if result.Success {
+			r.Succeeded++
+			continue
+		}
+		r.Failed++
+	}
+
+	return r
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type CreateCategoryRequest struct {
+	ParentID *int   `json:"parent_id" validate:"omitempty,gt=0"`
+	Name     string `json:"name" validate:"required,notEmpty,max=100"`
+}
+
+// UpdateCategoryRequest renames and moves a category, a nil parent_id moves it to the root.
+type UpdateCategoryRequest struct {
+	ID       int    `json:"-"`
+	ParentID *int   `json:"parent_id" validate:"omitempty,gt=0"`
+	Name     string `json:"name" validate:"required,notEmpty,max=100"`
+}
+
+type Category struct {
+	ID        int       `gorm:"column:id" json:"id"`
+	ParentID  *int      `gorm:"column:parent_id" json:"parent_id"`
+	Name      string    `gorm:"column:name" json:"name"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (Category) TableName() string {
+	return "categories"
+}
+
+type CategoryNode struct {
+	*Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// BuildCategoryTree nests categories under their parents and keeps the input order among siblings.
+// Categories whose parent is not in the list become roots.
+func BuildCategoryTree(categories []*Category) []*CategoryNode {
+	nodes := make(map[int]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: []*CategoryNode{}}
+	}
+
+	roots := []*CategoryNode{}
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+
+		roots = append(roots, node)
+	}
+
+	return roots
+}
@@ -0,0 +1,85 @@
+package domain
+
+const (
+	ContributorRoleAuthor      = "author"
+	ContributorRoleEditor      = "editor"
+	ContributorRoleTranslator  = "translator"
+	ContributorRoleIllustrator = "illustrator"
+)
+
+type ContributorRequest struct {
+	AuthorID int    `json:"author_id" validate:"required,gt=0"`
+	Role     string `json:"role" validate:"required,oneof=author editor translator illustrator"`
+}
+
+type DetailContributor struct {
+	AuthorID   int    `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	Role       string `json:"role"`
+	Position   int    `json:"position"`
+}
+
+type BookContributor struct {
+	BookID   int    `gorm:"column:book_id;primaryKey" json:"-"`
+	AuthorID int    `gorm:"column:author_id;primaryKey" json:"author_id"`
+	Role     string `gorm:"column:role;primaryKey" json:"role"`
+	Position int    `gorm:"column:position" json:"position"`
+}
+
+func (BookContributor) TableName() string {
+	return "book_contributors"
+}
+
+// NewContributors keeps the request order as position and drops repeated author and role pairs.
+// authorID is the legacy single author field, when it is set and not already listed as an author
+// it becomes the first author.
+func NewContributors(authorID int, reqs []*ContributorRequest) []*BookContributor {
+	var contributors []*BookContributor
+
+	seen := make(map[BookContributor]bool)
+	add := func(authorID int, role string) {
+		key := BookContributor{AuthorID: authorID, Role: role}
+		if seen[key] {
+			return
+		}
+
+		seen[key] = true
+		contributors = append(contributors, &BookContributor{
+			AuthorID: authorID,
+			Role:     role,
+			Position: len(contributors) + 1,
+		})
+	}
+
+	listed := false
+	for _, req := range reqs {
+		if req.AuthorID == authorID && req.Role == ContributorRoleAuthor {
+			listed = true
+		}
+	}
+
+	if authorID != 0 && !listed {
+		add(authorID, ContributorRoleAuthor)
+	}
+
+	for _, req := range reqs {
+		add(req.AuthorID, req.Role)
+	}
+
+	return contributors
+}
+
+// ContributorAuthorIDs returns every distinct author id in position order.
+func ContributorAuthorIDs(contributors []*BookContributor) []int {
+	seen := make(map[int]bool)
+
+	var ids []int
+	for _, contributor := range contributors {
+		if !seen[contributor.AuthorID] {
+			seen[contributor.AuthorID] = true
+			ids = append(ids, contributor.AuthorID)
+		}
+	}
+
+	return ids
+}
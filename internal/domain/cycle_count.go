@@ -0,0 +1,125 @@
+package domain
+
+import "time"
+
+const (
+	CycleCountOpen      = "open"
+	CycleCountApproved  = "approved"
+	CycleCountCancelled = "cancelled"
+)
+
+// CreateCycleCountRequest starts a count of every book at location or of the given books.
+type CreateCycleCountRequest struct {
+	Location string `json:"location" validate:"required_without=BookIDs,omitempty,notEmpty,max=50"`
+	BookIDs  []int  `json:"book_ids" validate:"required_without=Location,omitempty,max=1000,unique,dive,gt=0"`
+	Note     string `json:"note" validate:"omitempty,max=255"`
+}
+
+type CountedQuantity struct {
+	BookID  int  `json:"book_id" validate:"required,gt=0"`
+	Counted *int `json:"counted" validate:"required,min=0"`
+}
+
+// SubmitCountsRequest records counted quantities, Version is the version of the count the clerk worked
+// from and is checked when it is set.
+type SubmitCountsRequest struct {
+	ID      int                `json:"-"`
+	Version int                `json:"version" validate:"omitempty,gt=0"`
+	Counts  []*CountedQuantity `json:"counts" validate:"required,min=1,max=1000,dive"`
+}
+
+type CycleCountFilter struct {
+	Status string `form:"status" validate:"omitempty,oneof=open approved cancelled"`
+}
+
+type CycleCount struct {
+	ID         int        `gorm:"column:id" json:"id"`
+	Location   string     `gorm:"column:location" json:"location"`
+	Status     string     `gorm:"column:status" json:"status"`
+	Note       string     `gorm:"column:note" json:"note"`
+	Version    int        `gorm:"column:version" json:"version"`
+	CreatedBy  string     `gorm:"column:created_by" json:"created_by"`
+	CreatedAt  time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	ApprovedBy string     `gorm:"column:approved_by" json:"approved_by"`
+	ClosedAt   *time.Time `gorm:"column:closed_at" json:"closed_at"`
+
+	Lines []*CycleCountLine `gorm:"foreignKey:CycleCountID" json:"lines"`
+}
+
+func (CycleCount) TableName() string {
+	return "cycle_counts"
+}
+
+// Line returns the line counting the book.
+func (c *CycleCount) Line(bookID int) *CycleCountLine {
+	for _, line := range c.Lines {
+		if line.BookID == bookID {
+			return line
+		}
+	}
+
+	return nil
+}
+
+// Variance compares every line with what was expected when the count started.
+func (c *CycleCount) Variance() *CycleCountVariance {
+	report := &CycleCountVariance{
+		CycleCountID: c.ID,
+		Status:       c.Status,
+		Lines:        make([]*VarianceLine, 0, len(c.Lines)),
+	}
+
+	for _, line := range c.Lines {
+		item := &VarianceLine{
+			BookID:   line.BookID,
+			Title:    line.Title,
+			Expected: line.Expected,
+			Counted:  line.Counted,
+		}
+
+		if line.Counted == nil {
+			report.Uncounted++
+		} else {
+			item.Variance = *line.Counted - line.Expected
+			report.NetVariance += item.Variance
+		}
+
+		report.Lines = append(report.Lines, item)
+	}
+
+	return report
+}
+
+type CycleCountLine struct {
+	ID           int        `gorm:"column:id" json:"id"`
+	CycleCountID int        `gorm:"column:cycle_count_id" json:"cycle_count_id"`
+	BookID       int        `gorm:"column:book_id" json:"book_id"`
+	Title        string     `gorm:"column:title" json:"title"`
+	Expected     int        `gorm:"column:expected" json:"expected"`
+	Counted      *int       `gorm:"column:counted" json:"counted"`
+	CountedBy    string     `gorm:"column:counted_by" json:"counted_by"`
+	CountedAt    *time.Time `gorm:"column:counted_at" json:"counted_at"`
+	Closed       bool       `gorm:"column:closed" json:"-"`
+}
+
+func (CycleCountLine) TableName() string {
+	return "cycle_count_lines"
+}
+
+type VarianceLine struct {
+	BookID   int    `json:"book_id"`
+	Title    string `json:"title"`
+	Expected int    `json:"expected"`
+	Counted  *int   `json:"counted"`
+	Variance int    `json:"variance"`
+}
+
+// CycleCountVariance lists counted minus expected per book, NetVariance sums the counted books only.
+type CycleCountVariance struct {
+	CycleCountID int             `json:"cycle_count_id"`
+	Status       string          `json:"status"`
+	Uncounted    int             `json:"uncounted"`
+	NetVariance  int             `json:"net_variance"`
+	Lines        []*VarianceLine `json:"lines"`
+}
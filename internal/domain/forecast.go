@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// ForecastRequest forecasts the daily demand of one book, or of every book that sold in the history
+// window, and suggests what to reorder. Zero values fall back to the FORECAST_* configuration.
+type ForecastRequest struct {
+	BookID  int     `form:"book_id" validate:"omitempty,gt=0"`
+	Method  string  `form:"method" validate:"omitempty,oneof=moving_average exponential_smoothing"`
+	Window  int     `form:"window" validate:"omitempty,min=1,max=365"`
+	Alpha   float64 `form:"alpha" validate:"omitempty,gt=0,lte=1"`
+	Season  *int    `form:"season" validate:"omitempty,min=0,max=90"`
+	History int     `form:"history" validate:"omitempty,min=7,max=730"`
+	Horizon int     `form:"horizon" validate:"omitempty,min=1,max=365"`
+	// ReorderOnly leaves out the books that need no reorder yet
+	ReorderOnly bool `form:"reorder_only"`
+	Page        int  `form:"page" validate:"omitempty,gt=0"`
+	Limit       int  `form:"limit" validate:"omitempty,gt=0,max=500"`
+}
+
+// BookDemand is the number of copies of a book shipped to customers on a day.
+type BookDemand struct {
+	BookID   int       `gorm:"column:book_id"`
+	Day      time.Time `gorm:"column:day;serializer:date"`
+	Quantity int64     `gorm:"column:quantity"`
+}
+
+// BookSupplier is the supplier a book is reordered from, the one named by its reorder point or else
+// the one of its latest purchase order.
+type BookSupplier struct {
+	BookID       int `gorm:"column:book_id"`
+	SupplierID   int `gorm:"column:supplier_id"`
+	LeadTimeDays int `gorm:"column:lead_time_days"`
+}
+
+// BookOnOrder is the quantity of a book ordered from suppliers and not received yet.
+type BookOnOrder struct {
+	BookID   int   `gorm:"column:book_id"`
+	Quantity int64 `gorm:"column:quantity"`
+}
+
+// BookForecast is the daily demand forecast of a book and the reorder it suggests: when the stock
+// available plus on order is at or below the demand over the lead time plus safety stock, order up to
+// the demand over the lead time and the review period plus safety stock.
+type BookForecast struct {
+	BookID      int    `json:"book_id"`
+	Title       string `json:"title"`
+	Method      string `json:"method"`
+	HistoryDays int    `json:"history_days"`
+	// Level is the forecast demand of an average day, before seasonality
+	Level           float64   `json:"level"`
+	SeasonalIndices []float64 `json:"seasonal_indices,omitempty"`
+	Forecast        []float64 `json:"forecast"`
+	ForecastTotal   float64   `json:"forecast_total"`
+
+	Available    int   `json:"available"`
+	OnOrder      int64 `json:"on_order"`
+	SupplierID   *int  `json:"supplier_id"`
+	LeadTimeDays int   `json:"lead_time_days"`
+
+	SafetyStock           float64 `json:"safety_stock"`
+	SuggestedReorderPoint int64   `json:"suggested_reorder_point"`
+	SuggestedQuantity     int64   `json:"suggested_quantity"`
+}
+
+type ForecastResponse struct {
+	Books []*BookForecast `json:"books"`
+	Total int             `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
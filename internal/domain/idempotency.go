@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+type IdempotencyKey struct {
+	ID           int       `gorm:"column:id"`
+	Key          string    `gorm:"column:idempotency_key"`
+	UserID       int       `gorm:"column:user_id"`
+	Method       string    `gorm:"column:method"`
+	Path         string    `gorm:"column:path"`
+	RequestHash  string    `gorm:"column:request_hash"`
+	StatusCode   int       `gorm:"column:status_code"`
+	ContentType  string    `gorm:"column:content_type"`
+	ResponseBody string    `gorm:"column:response_body"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	ExpiresAt    time.Time `gorm:"column:expires_at"`
+}
+
+// IsCompleted reports whether the original request has finished and its response was stored.
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.StatusCode != 0
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
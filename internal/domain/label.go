@@ -0,0 +1,19 @@
+package domain
+
+type BarcodeRequest struct {
+	BookID    int    `form:"-"`
+	Symbology string `form:"symbology" validate:"omitempty,oneof=code128 ean13 qr"`
+	Format    string `form:"format" validate:"omitempty,oneof=png svg"`
+	Width     int    `form:"width" validate:"omitempty,min=50,max=2000"`
+	Height    int    `form:"height" validate:"omitempty,min=20,max=2000"`
+}
+
+type BarcodeImage struct {
+	ContentType string
+	Data        []byte
+}
+
+type LabelSheetRequest struct {
+	BookIDs []int `json:"book_ids" validate:"required,min=1,max=500"`
+	Copies  int   `json:"copies" validate:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+// DefaultLogComponent names the default level in LogLevels, components without a level of their
+// own log at it.
+const DefaultLogComponent = "default"
+
+// LogLevels are the log levels of components by name: debug, info, warn or error. Setting a
+// component to the empty level makes it log at the default level again.
+type LogLevels struct {
+	Levels map[string]string `json:"levels" binding:"required"`
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	OrderPending   = "pending"
+	OrderPaid      = "paid"
+	OrderPicked    = "picked"
+	OrderShipped   = "shipped"
+	OrderDelivered = "delivered"
+	OrderCancelled = "cancelled"
+	OrderReturned  = "returned"
+)
+
+// orderTransitions lists the statuses an order may move to from each status, orders can be cancelled
+// until they leave the warehouse and returned once they did.
+var orderTransitions = map[string][]string{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderPicked, OrderCancelled},
+	OrderPicked:    {OrderShipped, OrderCancelled},
+	OrderShipped:   {OrderDelivered, OrderReturned},
+	OrderDelivered: {OrderReturned},
+}
+
+type OrderLineRequest struct {
+	BookID   int `json:"book_id" validate:"required,gt=0"`
+	Quantity int `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+// CreateOrderRequest places an order priced from the books in currency, it defaults to the default currency.
+type CreateOrderRequest struct {
+	CustomerName    string              `json:"customer_name" validate:"required,notEmpty,max=100"`
+	CustomerEmail   string              `json:"customer_email" validate:"required,email,max=255"`
+	ShippingAddress string              `json:"shipping_address" validate:"required,notEmpty,max=255"`
+	Currency        string              `json:"currency" validate:"omitempty,currency"`
+	Lines           []*OrderLineRequest `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+type OrderFilter struct {
+	Status        string `form:"status" validate:"omitempty,oneof=pending paid picked shipped delivered cancelled returned"`
+	CustomerEmail string `form:"customer_email"`
+}
+
+type Order struct {
+	ID              int        `gorm:"column:id" json:"id"`
+	CustomerName    string     `gorm:"column:customer_name" json:"customer_name"`
+	CustomerEmail   string     `gorm:"column:customer_email" json:"customer_email"`
+	ShippingAddress string     `gorm:"column:shipping_address" json:"shipping_address"`
+	Status          string     `gorm:"column:status" json:"status"`
+	Currency        string     `gorm:"column:currency" json:"currency"`
+	CreatedBy       string     `gorm:"column:created_by" json:"created_by"`
+	CreatedAt       time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	PaidAt          *time.Time `gorm:"column:paid_at" json:"paid_at"`
+	PickedAt        *time.Time `gorm:"column:picked_at" json:"picked_at"`
+	ShippedAt       *time.Time `gorm:"column:shipped_at" json:"shipped_at"`
+	DeliveredAt     *time.Time `gorm:"column:delivered_at" json:"delivered_at"`
+	CancelledAt     *time.Time `gorm:"column:cancelled_at" json:"cancelled_at"`
+	ReturnedAt      *time.Time `gorm:"column:returned_at" json:"returned_at"`
+
+	Lines []*OrderLine `gorm:"foreignKey:OrderID" json:"lines"`
+}
+
+func (Order) TableName() string {
+	return "orders"
+}
+
+func (o *Order) CanTransition(to string) bool {
+	for _, status := range orderTransitions[o.Status] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition moves the order to status at the given time and returns the columns that changed,
+// it fails when the state machine does not allow the move.
+func (o *Order) Transition(to string, at time.Time) ([]string, error) {
+	if !o.CanTransition(to) {
+		return nil, fmt.Errorf("order can not move from %s to %s", o.Status, to)
+	}
+
+	o.Status, o.UpdatedAt = to, at
+
+	var column string
+	switch to {
+	case OrderPaid:
+		o.PaidAt, column = &at, "paid_at"
+	case OrderPicked:
+		o.PickedAt, column = &at, "picked_at"
+	case OrderShipped:
+		o.ShippedAt, column = &at, "shipped_at"
+	case OrderDelivered:
+		o.DeliveredAt, column = &at, "delivered_at"
+	case OrderCancelled:
+		o.CancelledAt, column = &at, "cancelled_at"
+	case OrderReturned:
+		o.ReturnedAt, column = &at, "returned_at"
+	}
+
+	return []string{"status", "updated_at", column}, nil
+}
+
+// Line returns the line ordering the book.
+func (o *Order) Line(bookID int) *OrderLine {
+	for _, line := range o.Lines {
+		if line.BookID == bookID {
+			return line
+		}
+	}
+
+	return nil
+}
+
+// Total is the price of every line.
+func (o *Order) Total() money.Money {
+	total := money.New(0, o.Currency)
+	for _, line := range o.Lines {
+		total.Amount += line.UnitPrice.Mul(int64(line.Quantity)).Amount
+	}
+
+	return total
+}
+
+type OrderLine struct {
+	ID        int         `gorm:"column:id" json:"id"`
+	OrderID   int         `gorm:"column:order_id" json:"order_id"`
+	BookID    int         `gorm:"column:book_id" json:"book_id"`
+	Title     string      `gorm:"column:title" json:"title"`
+	Quantity  int         `gorm:"column:quantity" json:"quantity"`
+	UnitPrice money.Money `gorm:"embedded;embeddedPrefix:unit_price_" json:"unit_price"`
+}
+
+func (OrderLine) TableName() string {
+	return "order_lines"
+}
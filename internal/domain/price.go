@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+// BookPrice is the list price of a book in a currency other than its base price currency.
+type BookPrice struct {
+	BookID    int       `gorm:"column:book_id;primaryKey" json:"-"`
+	Currency  string    `gorm:"column:currency;primaryKey" json:"currency"`
+	Amount    int64     `gorm:"column:amount" json:"amount"`
+	UpdatedAt time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (BookPrice) TableName() string {
+	return "prices"
+}
+
+func (p *BookPrice) Money() money.Money {
+	return money.New(p.Amount, p.Currency)
+}
+
+// SetBookPricesRequest replaces every currency specific list price of a book.
+type SetBookPricesRequest struct {
+	BookID int           `json:"-"`
+	Prices []money.Money `json:"prices" validate:"max=20,dive"`
+	Reason string        `json:"reason" validate:"omitempty,max=255"`
+}
+
+// ListPrice returns the price the book is listed at in currency, if it has one.
+func (b *Book) ListPrice(currency string) (money.Money, bool) {
+	if b.Price.Currency == currency {
+		return b.Price, true
+	}
+
+	for _, price := range b.Prices {
+		if price.Currency == currency {
+			return price.Money(), true
+		}
+	}
+
+	return money.Money{}, false
+}
+
+const (
+	PriceKindBase = "base"
+	PriceKindList = "list"
+
+	ScheduledPricePending   = "pending"
+	ScheduledPriceApplied   = "applied"
+	ScheduledPriceCancelled = "cancelled"
+	ScheduledPriceFailed    = "failed"
+)
+
+// PriceChange is one entry of the price history of a book. The old price is empty when a list price
+// was added and the new price is empty when a list price was removed.
+type PriceChange struct {
+	ID               int       `gorm:"column:id;primaryKey" json:"id"`
+	BookID           int       `gorm:"column:book_id" json:"book_id"`
+	Kind             string    `gorm:"column:kind" json:"kind"`
+	OldAmount        *int64    `gorm:"column:old_amount" json:"old_amount"`
+	OldCurrency      *string   `gorm:"column:old_currency" json:"old_currency"`
+	NewAmount        *int64    `gorm:"column:new_amount" json:"new_amount"`
+	NewCurrency      *string   `gorm:"column:new_currency" json:"new_currency"`
+	Reason           string    `gorm:"column:reason" json:"reason"`
+	ChangedBy        string    `gorm:"column:changed_by" json:"changed_by"`
+	ScheduledPriceID *int      `gorm:"column:scheduled_price_id" json:"scheduled_price_id,omitempty"`
+	ChangedAt        time.Time `gorm:"column:changed_at" json:"changed_at"`
+}
+
+func (PriceChange) TableName() string {
+	return "price_history"
+}
+
+// NewPriceChange records a change from old to new, either of which may be nil.
+func NewPriceChange(kind string, old, new *money.Money) *PriceChange {
+	change := &PriceChange{Kind: kind}
+
+	if old != nil {
+		amount, currency := old.Amount, old.Currency
+		change.OldAmount, change.OldCurrency = &amount, &currency
+	}
+
+	if new != nil {
+		amount, currency := new.Amount, new.Currency
+		change.NewAmount, change.NewCurrency = &amount, &currency
+	}
+
+	return change
+}
+
+// ScheduledPrice is a future price change that the price scheduler applies once it is due. A price in the
+// base price currency changes the base price, any other currency sets the list price in that currency.
+// A schedule the scheduler could not apply is failed with the reason why.
+type ScheduledPrice struct {
+	ID            int         `gorm:"column:id;primaryKey" json:"id"`
+	BookID        int         `gorm:"column:book_id" json:"book_id"`
+	Price         money.Money `gorm:"embedded;embeddedPrefix:price_" json:"price"`
+	Reason        string      `gorm:"column:reason" json:"reason"`
+	EffectiveAt   time.Time   `gorm:"column:effective_at" json:"effective_at"`
+	Status        string      `gorm:"column:status" json:"status"`
+	CreatedBy     string      `gorm:"column:created_by" json:"created_by"`
+	CreatedAt     time.Time   `gorm:"column:created_at" json:"created_at"`
+	AppliedAt     *time.Time  `gorm:"column:applied_at" json:"applied_at"`
+	FailureReason string      `gorm:"column:failure_reason" json:"failure_reason,omitempty"`
+}
+
+func (ScheduledPrice) TableName() string {
+	return "scheduled_prices"
+}
+
+type SchedulePriceRequest struct {
+	BookID      int         `json:"-"`
+	Price       money.Money `json:"price"`
+	EffectiveAt time.Time   `json:"effective_at" validate:"required"`
+	Reason      string      `json:"reason" validate:"omitempty,max=255"`
+}
+
+// PriceTimeline shows the current prices of a book, how they got there and what is going to change.
+type PriceTimeline struct {
+	BookID    int               `json:"book_id"`
+	Price     money.Money       `json:"price"`
+	Prices    []*BookPrice      `json:"prices"`
+	History   []*PriceChange    `json:"history"`
+	Scheduled []*ScheduledPrice `json:"scheduled"`
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	PromotionTypePercentage = "percentage"
+	PromotionTypeFixed      = "fixed_amount"
+	PromotionTypeBundle     = "bundle"
+
+	PromotionScopeAll      = "all"
+	PromotionScopeBook     = "book"
+	PromotionScopeAuthor   = "author"
+	PromotionScopeCategory = "category"
+)
+
+// CreatePromotionRequest holds every rule type, only the fields of Type are used: percentage for
+// percentage, discount per copy for fixed_amount and buy_quantity with pay_quantity for bundle.
+type CreatePromotionRequest struct {
+	Name        string       `json:"name" validate:"required,notEmpty,max=100"`
+	Type        string       `json:"type" validate:"required,oneof=percentage fixed_amount bundle"`
+	Scope       string       `json:"scope" validate:"required,oneof=all book author category"`
+	ScopeID     *int         `json:"scope_id" validate:"omitempty,gt=0"`
+	Percentage  int          `json:"percentage" validate:"omitempty,min=1,max=100"`
+	Discount    *money.Money `json:"discount" validate:"omitempty"`
+	BuyQuantity int          `json:"buy_quantity" validate:"omitempty,min=2"`
+	PayQuantity int          `json:"pay_quantity" validate:"omitempty,min=1,ltfield=BuyQuantity"`
+	StartsAt    time.Time    `json:"starts_at" validate:"required"`
+	EndsAt      *time.Time   `json:"ends_at"`
+	Priority    int          `json:"priority"`
+	Stackable   bool         `json:"stackable"`
+	Active      *bool        `json:"active"`
+}
+
+type UpdatePromotionRequest struct {
+	ID int `json:"-"`
+	CreatePromotionRequest
+}
+
+// Promotion is a discount rule. Promotions are applied by descending priority, a stackable promotion
+// combines with the ones applied before it while any other promotion only applies to undiscounted
+// lines and keeps later promotions off the lines it discounted.
+type Promotion struct {
+	ID          int         `gorm:"column:id" json:"id"`
+	Name        string      `gorm:"column:name" json:"name"`
+	Type        string      `gorm:"column:type" json:"type"`
+	Scope       string      `gorm:"column:scope" json:"scope"`
+	ScopeID     *int        `gorm:"column:scope_id" json:"scope_id"`
+	Percentage  int         `gorm:"column:percentage" json:"percentage,omitempty"`
+	Discount    money.Money `gorm:"embedded;embeddedPrefix:discount_" json:"discount"`
+	BuyQuantity int         `gorm:"column:buy_quantity" json:"buy_quantity,omitempty"`
+	PayQuantity int         `gorm:"column:pay_quantity" json:"pay_quantity,omitempty"`
+	StartsAt    time.Time   `gorm:"column:starts_at" json:"starts_at"`
+	EndsAt      *time.Time  `gorm:"column:ends_at" json:"ends_at"`
+	Priority    int         `gorm:"column:priority" json:"priority"`
+	Stackable   bool        `gorm:"column:stackable" json:"stackable"`
+	Active      bool        `gorm:"column:active" json:"active"`
+	CreatedAt   time.Time   `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time   `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// IsValidAt reports whether the promotion is active and inside its validity window at t.
+func (p *Promotion) IsValidAt(t time.Time) bool {
+	return p.Active && !t.Before(p.StartsAt) && (p.EndsAt == nil || t.Before(*p.EndsAt))
+}
+
+// Describe explains the rule in a sentence for quotes, e.g. "20% off books by author 3".
+func (p *Promotion) Describe() string {
+	var rule string
+	switch p.Type {
+	case PromotionTypePercentage:
+		rule = fmt.Sprintf("%d%% off", p.Percentage)
+	case PromotionTypeFixed:
+		rule = fmt.Sprintf("%s off every copy", p.Discount.Format())
+	case PromotionTypeBundle:
+		rule = fmt.Sprintf("buy %d pay %d", p.BuyQuantity, p.PayQuantity)
+	}
+
+	switch p.Scope {
+	case PromotionScopeBook:
+		return fmt.Sprintf("%s book %d", rule, *p.ScopeID)
+	case PromotionScopeAuthor:
+		return fmt.Sprintf("%s books by author %d", rule, *p.ScopeID)
+	case PromotionScopeCategory:
+		return fmt.Sprintf("%s books in category %d", rule, *p.ScopeID)
+	default:
+		return rule + " all books"
+	}
+}
+
+type QuoteItem struct {
+	BookID   int `json:"book_id" validate:"required,gt=0"`
+	Quantity int `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+// QuoteRequest prices a basket in currency, the default currency when it is empty.
+type QuoteRequest struct {
+	Currency string       `json:"currency" validate:"omitempty,currency"`
+	Items    []*QuoteItem `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// AppliedPromotion is the discount one promotion gave to a line or, in the quote summary, to the whole basket.
+type AppliedPromotion struct {
+	PromotionID int         `json:"promotion_id"`
+	Name        string      `json:"name"`
+	Explanation string      `json:"explanation"`
+	Discount    money.Money `json:"discount"`
+}
+
+type QuoteLine struct {
+	BookID     int                 `json:"book_id"`
+	Title      string              `json:"title"`
+	Quantity   int                 `json:"quantity"`
+	UnitPrice  money.Money         `json:"unit_price"`
+	Subtotal   money.Money         `json:"subtotal"`
+	Discount   money.Money         `json:"discount"`
+	Total      money.Money         `json:"total"`
+	Promotions []*AppliedPromotion `json:"promotions"`
+}
+
+type Quote struct {
+	Currency   string              `json:"currency"`
+	Lines      []*QuoteLine        `json:"lines"`
+	Subtotal   money.Money         `json:"subtotal"`
+	Discount   money.Money         `json:"discount"`
+	Total      money.Money         `json:"total"`
+	Promotions []*AppliedPromotion `json:"promotions"`
+}
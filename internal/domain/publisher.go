@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+type CreatePublisherRequest struct {
+	Name    string `json:"name" validate:"required,notEmpty,max=100"`
+	Website string `json:"website" validate:"omitempty,url,max=255"`
+}
+
+type UpdatePublisherRequest struct {
+	ID      int    `json:"-"`
+	Name    string `json:"name" validate:"required,notEmpty,max=100"`
+	Website string `json:"website" validate:"omitempty,url,max=255"`
+}
+
+type Publisher struct {
+	ID        int       `gorm:"column:id" json:"id"`
+	Name      string    `gorm:"column:name" json:"name"`
+	Website   string    `gorm:"column:website" json:"website"`
+	CreatedAt time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (Publisher) TableName() string {
+	return "publishers"
+}
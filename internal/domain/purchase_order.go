@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderSubmitted         = "submitted"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// purchaseOrderTransitions lists the statuses an order may move to from each status.
+var purchaseOrderTransitions = map[string][]string{
+	PurchaseOrderDraft:             {PurchaseOrderSubmitted, PurchaseOrderCancelled},
+	PurchaseOrderSubmitted:         {PurchaseOrderPartiallyReceived, PurchaseOrderReceived, PurchaseOrderCancelled},
+	PurchaseOrderPartiallyReceived: {PurchaseOrderPartiallyReceived, PurchaseOrderReceived, PurchaseOrderCancelled},
+}
+
+type PurchaseOrderLineRequest struct {
+	BookID   int         `json:"book_id" validate:"required,gt=0"`
+	Quantity int         `json:"quantity" validate:"required,min=1,max=100000"`
+	UnitCost money.Money `json:"unit_cost"`
+}
+
+// CreatePurchaseOrderRequest creates a draft order, currency defaults to the default currency and
+// every unit cost must be in it.
+type CreatePurchaseOrderRequest struct {
+	SupplierID int                         `json:"supplier_id" validate:"required,gt=0"`
+	Currency   string                      `json:"currency" validate:"omitempty,currency"`
+	Note       string                      `json:"note" validate:"omitempty,max=255"`
+	Lines      []*PurchaseOrderLineRequest `json:"lines" validate:"required,min=1,max=200,dive"`
+}
+
+type UpdatePurchaseOrderRequest struct {
+	ID int `json:"-"`
+	CreatePurchaseOrderRequest
+}
+
+type PurchaseOrderFilter struct {
+	Status     string `form:"status" validate:"omitempty,oneof=draft submitted partially_received received cancelled"`
+	SupplierID int    `form:"supplier_id"`
+}
+
+type PurchaseOrder struct {
+	ID          int        `gorm:"column:id" json:"id"`
+	SupplierID  int        `gorm:"column:supplier_id" json:"supplier_id"`
+	Supplier    *Supplier  `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	Status      string     `gorm:"column:status" json:"status"`
+	Currency    string     `gorm:"column:currency" json:"currency"`
+	Note        string     `gorm:"column:note" json:"note"`
+	CreatedBy   string     `gorm:"column:created_by" json:"created_by"`
+	CreatedAt   time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	SubmittedAt *time.Time `gorm:"column:submitted_at" json:"submitted_at"`
+	ClosedAt    *time.Time `gorm:"column:closed_at" json:"closed_at"`
+
+	Lines    []*PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID" json:"lines"`
+	Receipts []*GoodsReceipt      `gorm:"foreignKey:PurchaseOrderID" json:"receipts"`
+}
+
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+func (o *PurchaseOrder) CanTransition(to string) bool {
+	for _, status := range purchaseOrderTransitions[o.Status] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Line returns the line ordering the book.
+func (o *PurchaseOrder) Line(bookID int) *PurchaseOrderLine {
+	for _, line := range o.Lines {
+		if line.BookID == bookID {
+			return line
+		}
+	}
+
+	return nil
+}
+
+// IsFullyReceived reports whether every line received all it ordered.
+func (o *PurchaseOrder) IsFullyReceived() bool {
+	for _, line := range o.Lines {
+		if line.Outstanding() > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Total is the cost of everything ordered.
+func (o *PurchaseOrder) Total() money.Money {
+	total := money.New(0, o.Currency)
+	for _, line := range o.Lines {
+		total.Amount += line.UnitCost.Mul(int64(line.QuantityOrdered)).Amount
+	}
+
+	return total
+}
+
+type PurchaseOrderLine struct {
+	ID               int         `gorm:"column:id" json:"id"`
+	PurchaseOrderID  int         `gorm:"column:purchase_order_id" json:"purchase_order_id"`
+	BookID           int         `gorm:"column:book_id" json:"book_id"`
+	QuantityOrdered  int         `gorm:"column:quantity_ordered" json:"quantity_ordered"`
+	QuantityReceived int         `gorm:"column:quantity_received" json:"quantity_received"`
+	UnitCost         money.Money `gorm:"embedded;embeddedPrefix:unit_cost_" json:"unit_cost"`
+}
+
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+func (l *PurchaseOrderLine) Outstanding() int {
+	return l.QuantityOrdered - l.QuantityReceived
+}
+
+type GoodsReceiptLineRequest struct {
+	BookID   int `json:"book_id" validate:"required,gt=0"`
+	Quantity int `json:"quantity" validate:"required,min=1,max=100000"`
+}
+
+type GoodsReceiptRequest struct {
+	PurchaseOrderID int                        `json:"-"`
+	Note            string                     `json:"note" validate:"omitempty,max=255"`
+	Lines           []*GoodsReceiptLineRequest `json:"lines" validate:"required,min=1,max=200,dive"`
+}
+
+// GoodsReceipt records goods arriving for a purchase order, each line adds stock at the unit cost of its order line.
+type GoodsReceipt struct {
+	ID              int                 `gorm:"column:id" json:"id"`
+	PurchaseOrderID int                 `gorm:"column:purchase_order_id" json:"purchase_order_id"`
+	Note            string              `gorm:"column:note" json:"note"`
+	ReceivedBy      string              `gorm:"column:received_by" json:"received_by"`
+	ReceivedAt      time.Time           `gorm:"column:received_at" json:"received_at"`
+	Lines           []*GoodsReceiptLine `gorm:"foreignKey:GoodsReceiptID" json:"lines"`
+}
+
+func (GoodsReceipt) TableName() string {
+	return "goods_receipts"
+}
+
+type GoodsReceiptLine struct {
+	ID                  int         `gorm:"column:id" json:"id"`
+	GoodsReceiptID      int         `gorm:"column:goods_receipt_id" json:"goods_receipt_id"`
+	PurchaseOrderLineID int         `gorm:"column:purchase_order_line_id" json:"purchase_order_line_id"`
+	BookID              int         `gorm:"column:book_id" json:"book_id"`
+	Quantity            int         `gorm:"column:quantity" json:"quantity"`
+	UnitCost            money.Money `gorm:"embedded;embeddedPrefix:unit_cost_" json:"unit_cost"`
+}
+
+func (GoodsReceiptLine) TableName() string {
+	return "goods_receipt_lines"
+}
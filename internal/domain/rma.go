@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	RMAOpen      = "open"
+	RMAClosed    = "closed"
+	RMACancelled = "cancelled"
+
+	RMAReasonDamaged   = "damaged"
+	RMAReasonDefective = "defective"
+	RMAReasonWrongBook = "wrong_book"
+	RMAReasonUnwanted  = "unwanted"
+	RMAReasonOther     = "other"
+
+	RMAOutcomeRestock    = "restock"
+	RMAOutcomeWriteOff   = "write_off"
+	RMAOutcomeToSupplier = "return_to_supplier"
+)
+
+type RMALineRequest struct {
+	BookID   int    `json:"book_id" validate:"required,gt=0"`
+	Quantity int    `json:"quantity" validate:"required,min=1,max=1000"`
+	Reason   string `json:"reason" validate:"required,oneof=damaged defective wrong_book unwanted other"`
+}
+
+// CreateRMARequest authorises the return of books from a shipped or delivered order.
+type CreateRMARequest struct {
+	OrderID int               `json:"order_id" validate:"required,gt=0"`
+	Note    string            `json:"note" validate:"omitempty,max=255"`
+	Lines   []*RMALineRequest `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+// InspectRMALineRequest decides what happens to a returned book, the refund is in minor units of the
+// order currency and defaults to the full price paid.
+type InspectRMALineRequest struct {
+	BookID       int    `json:"book_id" validate:"required,gt=0"`
+	Outcome      string `json:"outcome" validate:"required,oneof=restock write_off return_to_supplier"`
+	RefundAmount *int64 `json:"refund_amount" validate:"omitempty,min=0"`
+	Note         string `json:"note" validate:"omitempty,max=255"`
+}
+
+type InspectRMARequest struct {
+	ID    int                      `json:"-"`
+	Lines []*InspectRMALineRequest `json:"lines" validate:"required,min=1,max=100,dive"`
+}
+
+type RMAFilter struct {
+	Status        string `form:"status" validate:"omitempty,oneof=open closed cancelled"`
+	CustomerEmail string `form:"customer_email"`
+	BookID        int    `form:"book_id"`
+}
+
+// RMA is a return merchandise authorisation, it closes once every line has been inspected.
+type RMA struct {
+	ID            int        `gorm:"column:id" json:"id"`
+	OrderID       int        `gorm:"column:order_id" json:"order_id"`
+	CustomerEmail string     `gorm:"column:customer_email" json:"customer_email"`
+	Status        string     `gorm:"column:status" json:"status"`
+	Note          string     `gorm:"column:note" json:"note"`
+	CreatedBy     string     `gorm:"column:created_by" json:"created_by"`
+	CreatedAt     time.Time  `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at" json:"updated_at"`
+	ClosedAt      *time.Time `gorm:"column:closed_at" json:"closed_at"`
+
+	Lines []*RMALine `gorm:"foreignKey:RMAID" json:"lines"`
+}
+
+func (RMA) TableName() string {
+	return "rmas"
+}
+
+// Line returns the line returning the book.
+func (r *RMA) Line(bookID int) *RMALine {
+	for _, line := range r.Lines {
+		if line.BookID == bookID {
+			return line
+		}
+	}
+
+	return nil
+}
+
+func (r *RMA) IsInspected() bool {
+	for _, line := range r.Lines {
+		if !line.IsInspected() {
+			return false
+		}
+	}
+
+	return true
+}
+
+type RMALine struct {
+	ID          int         `gorm:"column:id" json:"id"`
+	RMAID       int         `gorm:"column:rma_id" json:"rma_id"`
+	OrderLineID int         `gorm:"column:order_line_id" json:"order_line_id"`
+	BookID      int         `gorm:"column:book_id" json:"book_id"`
+	Quantity    int         `gorm:"column:quantity" json:"quantity"`
+	Reason      string      `gorm:"column:reason" json:"reason"`
+	UnitPrice   money.Money `gorm:"embedded;embeddedPrefix:unit_price_" json:"unit_price"`
+	Outcome     string      `gorm:"column:outcome" json:"outcome"`
+	Refund      money.Money `gorm:"embedded;embeddedPrefix:refund_" json:"refund"`
+	Note        string      `gorm:"column:note" json:"note"`
+	InspectedBy string      `gorm:"column:inspected_by" json:"inspected_by"`
+	InspectedAt *time.Time  `gorm:"column:inspected_at" json:"inspected_at"`
+}
+
+func (RMALine) TableName() string {
+	return "rma_lines"
+}
+
+func (l *RMALine) IsInspected() bool {
+	return l.Outcome != ""
+}
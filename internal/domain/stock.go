@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	StockMovementPurchaseReceipt = "purchase_receipt"
+	StockMovementSaleShipment    = "sale_shipment"
+	StockMovementSaleReturn      = "sale_return"
+	StockMovementWriteOff        = "write_off"
+	StockMovementSupplierReturn  = "supplier_return"
+	StockMovementCountAdjustment = "count_adjustment"
+
+	StockReferenceGoodsReceipt = "goods_receipt"
+	StockReferenceOrder        = "order"
+	StockReferenceRMA          = "rma"
+	StockReferenceCycleCount   = "cycle_count"
+)
+
+// StockMovement is one entry of the stock ledger, a positive quantity adds stock and a negative one
+// removes it. The stock of a book is the sum of its movements.
+type StockMovement struct {
+	ID            int         `gorm:"column:id" json:"id"`
+	BookID        int         `gorm:"column:book_id" json:"book_id"`
+	Type          string      `gorm:"column:type" json:"type"`
+	Quantity      int         `gorm:"column:quantity" json:"quantity"`
+	UnitCost      money.Money `gorm:"embedded;embeddedPrefix:unit_cost_" json:"unit_cost"`
+	ReferenceType string      `gorm:"column:reference_type" json:"reference_type"`
+	ReferenceID   *int        `gorm:"column:reference_id" json:"reference_id"`
+	Note          string      `gorm:"column:note" json:"note"`
+	CreatedBy     string      `gorm:"column:created_by" json:"created_by"`
+	CreatedAt     time.Time   `gorm:"column:created_at" json:"created_at"`
+}
+
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// StockLedger shows the stock on hand, the part of it reserved by open orders and what is left to sell.
+type StockLedger struct {
+	BookID    int              `json:"book_id"`
+	Stock     int              `json:"stock"`
+	Reserved  int              `json:"reserved"`
+	Available int              `json:"available"`
+	Movements []*StockMovement `json:"movements"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	StockAlertOpen     = "open"
+	StockAlertResolved = "resolved"
+)
+
+// SetReorderPointRequest sets when a book, or every book shelved at a location, counts as low on stock
+// and how much to reorder, a supplier lets the evaluator draft the purchase order.
+type SetReorderPointRequest struct {
+	BookID          int    `json:"-"`
+	Location        string `json:"-" validate:"omitempty,notEmpty,max=50"`
+	ReorderPoint    *int   `json:"reorder_point" validate:"required,min=0"`
+	ReorderQuantity int    `json:"reorder_quantity" validate:"required,min=1,max=100000"`
+	SupplierID      *int   `json:"supplier_id" validate:"omitempty,gt=0"`
+}
+
+type ReorderPoint struct {
+	BookID          int       `gorm:"column:book_id;primaryKey" json:"book_id"`
+	ReorderPoint    int       `gorm:"column:reorder_point" json:"reorder_point"`
+	ReorderQuantity int       `gorm:"column:reorder_quantity" json:"reorder_quantity"`
+	SupplierID      *int      `gorm:"column:supplier_id" json:"supplier_id"`
+	UpdatedAt       time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (ReorderPoint) TableName() string {
+	return "reorder_points"
+}
+
+// LocationReorderPoint is the reorder point of the books shelved at a location, the way cycle counts
+// scope by location. A book with a reorder point of its own is watched at that one instead.
+type LocationReorderPoint struct {
+	Location        string    `gorm:"column:location;primaryKey" json:"location"`
+	ReorderPoint    int       `gorm:"column:reorder_point" json:"reorder_point"`
+	ReorderQuantity int       `gorm:"column:reorder_quantity" json:"reorder_quantity"`
+	SupplierID      *int      `gorm:"column:supplier_id" json:"supplier_id"`
+	UpdatedAt       time.Time `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (LocationReorderPoint) TableName() string {
+	return "location_reorder_points"
+}
+
+// LowStock is a book at or below its reorder point without an open alert. The reorder point is the
+// one of the book, or the one of its location when the book has none.
+type LowStock struct {
+	ReorderPoint
+	Location  string `gorm:"column:location"`
+	Title     string `gorm:"column:title"`
+	Available int    `gorm:"column:available"`
+}
+
+type StockAlertFilter struct {
+	Status string `form:"status" validate:"omitempty,oneof=open resolved"`
+	BookID int    `form:"book_id"`
+}
+
+type StockAlert struct {
+	ID              int        `gorm:"column:id" json:"id"`
+	BookID          int        `gorm:"column:book_id" json:"book_id"`
+	Book            *Book      `gorm:"foreignKey:BookID" json:"book,omitempty"`
+	Available       int        `gorm:"column:available" json:"available"`
+	ReorderPoint    int        `gorm:"column:reorder_point" json:"reorder_point"`
+	Status          string     `gorm:"column:status" json:"status"`
+	PurchaseOrderID *int       `gorm:"column:purchase_order_id" json:"purchase_order_id"`
+	CreatedAt       time.Time  `gorm:"column:created_at" json:"created_at"`
+	ResolvedAt      *time.Time `gorm:"column:resolved_at" json:"resolved_at"`
+}
+
+func (StockAlert) TableName() string {
+	return "stock_alerts"
+}
+
+// Describe tells what the alert is about in a sentence for notifications.
+func (a *StockAlert) Describe(title string, reorderQuantity int) string {
+	text := fmt.Sprintf("%s (book %d) has %d available, its reorder point is %d. Suggested reorder: %d.",
+		title, a.BookID, a.Available, a.ReorderPoint, reorderQuantity)
+	if a.PurchaseOrderID != nil {
+		text += fmt.Sprintf(" Draft purchase order %d was created.", *a.PurchaseOrderID)
+	}
+
+	return text
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type CreateSupplierRequest struct {
+	Name        string `json:"name" validate:"required,notEmpty,max=100"`
+	Email       string `json:"email" validate:"omitempty,email,max=255"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,max=50"`
+	Address     string `json:"address" validate:"omitempty,max=255"`
+	// LeadTimeDays is how many days deliveries take, forecasts assume FORECAST_LEAD_TIME_DAYS when 0
+	LeadTimeDays int `json:"lead_time_days" validate:"omitempty,min=0,max=365"`
+}
+
+type UpdateSupplierRequest struct {
+	ID int `json:"-"`
+	CreateSupplierRequest
+}
+
+type Supplier struct {
+	ID           int       `gorm:"column:id" json:"id"`
+	Name         string    `gorm:"column:name" json:"name"`
+	Email        string    `gorm:"column:email" json:"email"`
+	PhoneNumber  string    `gorm:"column:phone_number" json:"phone_number"`
+	Address      string    `gorm:"column:address" json:"address"`
+	LeadTimeDays int       `gorm:"column:lead_time_days" json:"lead_time_days"`
+	CreatedAt    time.Time `gorm:"column:created_at" json:"created_at"`
+}
+
+func (Supplier) TableName() string {
+	return "suppliers"
+}
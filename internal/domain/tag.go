@@ -0,0 +1,44 @@
+package domain
+
+import "strings"
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,notEmpty,max=50"`
+}
+
+type UpdateTagRequest struct {
+	ID   int    `json:"-"`
+	Name string `json:"name" validate:"required,notEmpty,max=50"`
+}
+
+type Tag struct {
+	ID   int    `gorm:"column:id" json:"id"`
+	Name string `gorm:"column:name" json:"name"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// NormalizeTag lower-cases a tag and collapses its whitespace so "Sci  Fi" and "sci fi" are the same tag.
+func NormalizeTag(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// NormalizeTags normalizes every name and drops empty and repeated ones.
+func NormalizeTags(names []string) []string {
+	seen := make(map[string]bool, len(names))
+
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || seen[name] {
+			continue
+		}
+
+		seen[name] = true
+		tags = append(tags, name)
+	}
+
+	return tags
+}
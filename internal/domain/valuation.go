@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+)
+
+const (
+	ReportGroupByBook   = "book"
+	ReportGroupByAuthor = "author"
+
+	ReportFormatJSON = "json"
+	ReportFormatCSV  = "csv"
+)
+
+// ValuationRequest values the stock at the end of the AsOf day, today when it is zero.
+type ValuationRequest struct {
+	AsOf     time.Time `form:"as_of" time_format:"2006-01-02"`
+	Method   string    `form:"method" validate:"omitempty,oneof=fifo weighted_average"`
+	Currency string    `form:"currency" validate:"omitempty,currency"`
+	Format   string    `form:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// ReportPeriodRequest covers the days From up to and including To.
+type ReportPeriodRequest struct {
+	From     time.Time `form:"from" time_format:"2006-01-02" validate:"required"`
+	To       time.Time `form:"to" time_format:"2006-01-02" validate:"required,gtefield=From"`
+	Method   string    `form:"method" validate:"omitempty,oneof=fifo weighted_average"`
+	Currency string    `form:"currency" validate:"omitempty,currency"`
+	GroupBy  string    `form:"group_by" validate:"omitempty,oneof=book author"`
+	Format   string    `form:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// End is the first instant after the period.
+func (r *ReportPeriodRequest) End() time.Time {
+	return r.To.AddDate(0, 0, 1)
+}
+
+// SaleRevenue is what customers paid for the copies of a book sold in a period, net of refunds.
+type SaleRevenue struct {
+	BookID  int         `gorm:"column:book_id"`
+	Revenue money.Money `gorm:"embedded;embeddedPrefix:revenue_"`
+}
+
+// CostLayer is a quantity still on hand at one unit cost, weighted average costing has a single layer.
+type CostLayer struct {
+	Quantity   int64       `json:"quantity"`
+	UnitCost   money.Money `json:"unit_cost"`
+	ReceivedAt time.Time   `json:"received_at"`
+}
+
+type ValuationLine struct {
+	BookID   int          `json:"book_id"`
+	Title    string       `json:"title"`
+	Quantity int64        `json:"quantity"`
+	UnitCost money.Money  `json:"unit_cost"`
+	Value    money.Money  `json:"value"`
+	Layers   []*CostLayer `json:"layers"`
+}
+
+type ValuationReport struct {
+	AsOf          time.Time        `json:"as_of"`
+	Method        string           `json:"method"`
+	Currency      string           `json:"currency"`
+	TotalQuantity int64            `json:"total_quantity"`
+	TotalValue    money.Money      `json:"total_value"`
+	Lines         []*ValuationLine `json:"lines"`
+}
+
+func (r *ValuationReport) Records() [][]string {
+	records := [][]string{{"book_id", "title", "quantity", "unit_cost", "value", "currency"}}
+	for _, line := range r.Lines {
+		records = append(records, []string{
+			strconv.Itoa(line.BookID),
+			line.Title,
+			strconv.FormatInt(line.Quantity, 10),
+			decimal(line.UnitCost),
+			decimal(line.Value),
+			r.Currency,
+		})
+	}
+
+	return records
+}
+
+// COGSLine is the cost of the copies of a book shipped in a period, less the cost of those that came back.
+type COGSLine struct {
+	BookID   int         `json:"book_id"`
+	Title    string      `json:"title"`
+	Quantity int64       `json:"quantity"`
+	Cost     money.Money `json:"cost"`
+}
+
+type COGSReport struct {
+	From     time.Time   `json:"from"`
+	To       time.Time   `json:"to"`
+	Method   string      `json:"method"`
+	Currency string      `json:"currency"`
+	Total    money.Money `json:"total"`
+	Lines    []*COGSLine `json:"lines"`
+}
+
+func (r *COGSReport) Records() [][]string {
+	records := [][]string{{"book_id", "title", "quantity", "cost", "currency"}}
+	for _, line := range r.Lines {
+		records = append(records, []string{
+			strconv.Itoa(line.BookID),
+			line.Title,
+			strconv.FormatInt(line.Quantity, 10),
+			decimal(line.Cost),
+			r.Currency,
+		})
+	}
+
+	return records
+}
+
+// MarginLine is the gross margin of a book, or of the books of an author when grouped by author.
+type MarginLine struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	Quantity int64       `json:"quantity"`
+	Revenue  money.Money `json:"revenue"`
+	Cost     money.Money `json:"cost"`
+	Margin   money.Money `json:"margin"`
+	// MarginPercent is the margin as a percentage of the revenue, nil without revenue
+	MarginPercent *float64 `json:"margin_percent"`
+}
+
+type MarginReport struct {
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Method   string        `json:"method"`
+	Currency string        `json:"currency"`
+	GroupBy  string        `json:"group_by"`
+	Revenue  money.Money   `json:"revenue"`
+	Cost     money.Money   `json:"cost"`
+	Margin   money.Money   `json:"margin"`
+	Lines    []*MarginLine `json:"lines"`
+}
+
+func (r *MarginReport) Records() [][]string {
+	records := [][]string{{r.GroupBy + "_id", "name", "quantity", "revenue", "cost", "margin", "margin_percent", "currency"}}
+	for _, line := range r.Lines {
+		percent := ""
+		if line.MarginPercent != nil {
+			percent = strconv.FormatFloat(*line.MarginPercent, 'f', 2, 64)
+		}
+
+		records = append(records, []string{
+			strconv.Itoa(line.ID),
+			line.Name,
+			strconv.FormatInt(line.Quantity, 10),
+			decimal(line.Revenue),
+			decimal(line.Cost),
+			decimal(line.Margin),
+			percent,
+			r.Currency,
+		})
+	}
+
+	return records
+}
+
+// decimal renders the amount without its currency, spreadsheets read "12.50" as a number.
+func decimal(m money.Money) string {
+	s := m.String()
+	return s[len(m.Currency)+1:]
+}
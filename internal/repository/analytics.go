@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AnalyticsRepositoryImpl interface {
+	LockRefresh(ctx context.Context, name string) (*domain.AnalyticsRefresh, error)
+	SetRefreshed(ctx context.Context, refresh *domain.AnalyticsRefresh) error
+	RefreshSales(ctx context.Context, from time.Time) error
+	RefreshStock(ctx context.Context, from time.Time) error
+	TopSelling(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookRevenueRow, error)
+	SlowMovers(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error)
+	DaysOnHand(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error)
+	OpeningStock(ctx context.Context, day time.Time, bookID int) (int64, error)
+	ListStockChanges(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error)
+	ListSales(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error)
+	ListAuthorRevenue(ctx context.Context, from, to time.Time, bucket string, authorID int) ([]*domain.AuthorRevenueRow, error)
+}
+
+type AnalyticsRepository struct {
+	TransactionRepository
+}
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepositoryImpl {
+	return &AnalyticsRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// refreshSales rolls the order shipments and returns, and the rma returns with their refunds, up to
+// book_sales_daily. Rma returns count in the currency of their order even when nothing was refunded.
+// %[1]s is the date of m.created_at.
+const refreshSales = `INSERT INTO book_sales_daily (book_id, day, currency, quantity, revenue_amount)
+SELECT book_id, day, currency, SUM(quantity), SUM(amount) FROM (
+	SELECT m.book_id, %[1]s AS day, ol.unit_price_currency AS currency,
+			-m.quantity AS quantity, -m.quantity * ol.unit_price_amount AS amount
+		FROM stock_movements m JOIN order_lines ol ON ol.order_id = m.reference_id AND ol.book_id = m.book_id
+		WHERE m.reference_type = @order AND m.type IN @types AND m.created_at >= @from
+	UNION ALL
+	SELECT m.book_id, %[1]s, o.currency, -m.quantity, -rl.refund_amount
+		FROM stock_movements m
+		JOIN rma_lines rl ON rl.rma_id = m.reference_id AND rl.book_id = m.book_id
+		JOIN rmas r ON r.id = rl.rma_id
+		JOIN orders o ON o.id = r.order_id
+		WHERE m.reference_type = @rma AND m.type = @return AND m.created_at >= @from
+) sales GROUP BY book_id, day, currency`
+
+// refreshStock rolls the ledger up to book_stock_daily, the closing stock carries on from the last
+// day before from that is already rolled up. %[1]s is the date of created_at.
+const refreshStock = `INSERT INTO book_stock_daily (book_id, day, stock_in, stock_out, closing_stock)
+SELECT d.book_id, d.day, d.stock_in, d.stock_out,
+		COALESCE((SELECT p.closing_stock FROM book_stock_daily p
+			WHERE p.book_id = d.book_id AND p.day < @from_day ORDER BY p.day DESC LIMIT 1), 0)
+		+ SUM(d.stock_in - d.stock_out) OVER (PARTITION BY d.book_id ORDER BY d.day)
+	FROM (
+		SELECT book_id, %[1]s AS day,
+				SUM(CASE WHEN quantity > 0 THEN quantity ELSE 0 END) AS stock_in,
+				SUM(CASE WHEN quantity < 0 THEN -quantity ELSE 0 END) AS stock_out
+			FROM stock_movements WHERE created_at >= @from
+			GROUP BY book_id, %[1]s
+	) d`
+
+const topSelling = `WITH top AS (
+	SELECT book_id, SUM(quantity) AS quantity FROM book_sales_daily
+		WHERE day >= @from AND day < @to
+		GROUP BY book_id HAVING SUM(quantity) > 0
+		ORDER BY SUM(quantity) DESC, book_id LIMIT @limit
+)
+SELECT t.book_id, b.title, t.quantity, s.currency AS revenue_currency, SUM(s.revenue_amount) AS revenue_amount
+	FROM top t
+	JOIN books b ON b.id = t.book_id
+	JOIN book_sales_daily s ON s.book_id = t.book_id AND s.day >= @from AND s.day < @to
+	GROUP BY t.book_id, b.title, t.quantity, s.currency
+	ORDER BY t.quantity DESC, t.book_id, s.currency`
+
+// authorRevenue credits the sales of a book to its primary author, the first author by position or
+// the first contributor of books without an author. %[1]s is the start of the bucket of s.day.
+const authorRevenue = `SELECT a.id AS author_id, a.name, %[1]s AS bucket,
+		SUM(s.quantity) AS quantity, s.currency AS revenue_currency, SUM(s.revenue_amount) AS revenue_amount
+	FROM book_sales_daily s
+	JOIN authors a ON a.id = (
+		SELECT bc.author_id FROM book_contributors bc WHERE bc.book_id = s.book_id
+			ORDER BY CASE WHEN bc.role = @author THEN 0 ELSE 1 END, bc.position LIMIT 1
+	)
+	WHERE s.day >= @from AND s.day < @to AND (@author_id = 0 OR a.id = @author_id)
+	GROUP BY a.id, a.name, %[1]s, s.currency
+	ORDER BY a.id, bucket, s.currency`
+
+// LockRefresh returns the refresh watermark locked for update, creating it at the epoch so the first
+// refresh rolls up the whole ledger. Concurrent refreshes wait on each other.
+func (r *AnalyticsRepository) LockRefresh(ctx context.Context, name string) (*domain.AnalyticsRefresh, error) {
+	refresh := &domain.AnalyticsRefresh{
+		Name:             name,
+		RefreshedThrough: time.Unix(0, 0),
+		RefreshedAt:      time.Now(),
+	}
+
+	if err := r.tx(ctx).Clauses(r.dialect().IgnoreConflicts(clause.OnConflict{})).Create(refresh).Error; err != nil {
+		return nil, err
+	}
+
+	db := r.tx(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", name).First(refresh)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return refresh, nil
+}
+
+func (r *AnalyticsRepository) SetRefreshed(ctx context.Context, refresh *domain.AnalyticsRefresh) error {
+	return r.tx(ctx).Model(refresh).Select("refreshed_through", "refreshed_at").Updates(refresh).Error
+}
+
+// RefreshSales rebuilds the sales rollup from the day from on.
+func (r *AnalyticsRepository) RefreshSales(ctx context.Context, from time.Time) error {
+	if err := r.tx(ctx).Exec("DELETE FROM book_sales_daily WHERE day >= ?", r.dialect().DateArg(from)).Error; err != nil {
+		return err
+	}
+
+	return r.tx(ctx).Exec(fmt.Sprintf(refreshSales, r.dialect().Date("m.created_at")), map[string]interface{}{
+		"order":  domain.StockReferenceOrder,
+		"rma":    domain.StockReferenceRMA,
+		"types":  []string{domain.StockMovementSaleShipment, domain.StockMovementSaleReturn},
+		"return": domain.StockMovementSaleReturn,
+		"from":   from,
+	}).Error
+}
+
+// RefreshStock rebuilds the stock rollup from the day from on, the days before it must be rolled up already.
+func (r *AnalyticsRepository) RefreshStock(ctx context.Context, from time.Time) error {
+	if err := r.tx(ctx).Exec("DELETE FROM book_stock_daily WHERE day >= ?", r.dialect().DateArg(from)).Error; err != nil {
+		return err
+	}
+
+	return r.tx(ctx).Exec(fmt.Sprintf(refreshStock, r.dialect().Date("created_at")), map[string]interface{}{
+		"from":     from,
+		"from_day": r.dialect().DateArg(from),
+	}).Error
+}
+
+// TopSelling returns the revenue per currency of the limit books that sold most copies from from up to to.
+func (r *AnalyticsRepository) TopSelling(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookRevenueRow, error) {
+	var rows []*domain.BookRevenueRow
+
+	db := r.tx(ctx).Raw(topSelling, map[string]interface{}{
+		"from":  r.dialect().DateArg(from),
+		"to":    r.dialect().DateArg(to),
+		"limit": limit,
+	}).Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// SlowMovers returns the books in stock that sold the fewest copies, those unsold longest first.
+func (r *AnalyticsRepository) SlowMovers(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error) {
+	var rows []*domain.BookStockSales
+
+	db := r.stocked(ctx, from, to).
+		Order("COALESCE(s.quantity, 0), " + r.dialect().NullsFirst("l.last_sold_on") + ", b.id").
+		Limit(limit).
+		Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// DaysOnHand returns the books in stock that last longest at their pace of sales, unsold books first.
+func (r *AnalyticsRepository) DaysOnHand(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error) {
+	var rows []*domain.BookStockSales
+
+	db := r.stocked(ctx, from, to).
+		Order("CASE WHEN COALESCE(s.quantity, 0) > 0 THEN 1 ELSE 0 END, b.stock * 1.0 / NULLIF(s.quantity, 0) DESC, b.id").
+		Limit(limit).
+		Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// stocked selects the books with stock on hand, what they sold from from up to to and the day they last sold.
+func (r *AnalyticsRepository) stocked(ctx context.Context, from, to time.Time) *gorm.DB {
+	sales := r.tx(ctx).Table("book_sales_daily").
+		Select("book_id, SUM(quantity) AS quantity").
+		Where("day >= ? AND day < ?", r.dialect().DateArg(from), r.dialect().DateArg(to)).
+		Group("book_id")
+
+	last := r.tx(ctx).Table("book_sales_daily").
+		Select("book_id, MAX(day) AS last_sold_on").
+		Where("quantity > 0 AND day < ?", r.dialect().DateArg(to)).
+		Group("book_id")
+
+	return r.tx(ctx).Table("books b").
+		Select("b.id AS book_id, b.title, b.stock, COALESCE(s.quantity, 0) AS quantity, l.last_sold_on").
+		Joins("LEFT JOIN (?) s ON s.book_id = b.id", sales).
+		Joins("LEFT JOIN (?) l ON l.book_id = b.id", last).
+		Where("b.stock > 0")
+}
+
+// OpeningStock is the stock on hand at the start of day, of one book or, when bookID is 0, of all books.
+func (r *AnalyticsRepository) OpeningStock(ctx context.Context, day time.Time, bookID int) (int64, error) {
+	var stock int64
+
+	db := r.tx(ctx).Table("book_stock_daily d").
+		Select("COALESCE(SUM(d.closing_stock), 0)").
+		Where("d.day = (SELECT MAX(p.day) FROM book_stock_daily p WHERE p.book_id = d.book_id AND p.day < ?)", r.dialect().DateArg(day))
+	if bookID != 0 {
+		db = db.Where("d.book_id = ?", bookID)
+	}
+
+	if err := db.Scan(&stock).Error; err != nil {
+		return 0, err
+	}
+
+	return stock, nil
+}
+
+// ListStockChanges returns the net stock moved per day from from up to to.
+func (r *AnalyticsRepository) ListStockChanges(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error) {
+	return r.daily(ctx, "book_stock_daily", "SUM(stock_in - stock_out)", from, to, bookID)
+}
+
+// ListSales returns the net copies sold per day from from up to to.
+func (r *AnalyticsRepository) ListSales(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error) {
+	return r.daily(ctx, "book_sales_daily", "SUM(quantity)", from, to, bookID)
+}
+
+func (r *AnalyticsRepository) daily(ctx context.Context, table, sum string, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error) {
+	var rows []*domain.DailyQuantity
+
+	db := r.tx(ctx).Table(table).
+		Select("day, "+sum+" AS quantity").
+		Where("day >= ? AND day < ?", r.dialect().DateArg(from), r.dialect().DateArg(to))
+	if bookID != 0 {
+		db = db.Where("book_id = ?", bookID)
+	}
+
+	if err := db.Group("day").Order("day").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ListAuthorRevenue returns the sales per primary author, bucket and currency, of one author when authorID is set.
+func (r *AnalyticsRepository) ListAuthorRevenue(ctx context.Context, from, to time.Time, bucket string, authorID int) ([]*domain.AuthorRevenueRow, error) {
+	var rows []*domain.AuthorRevenueRow
+
+	db := r.tx(ctx).Raw(fmt.Sprintf(authorRevenue, r.dialect().DateTrunc(bucket, "s.day")), map[string]interface{}{
+		"author":    domain.ContributorRoleAuthor,
+		"author_id": authorID,
+		"from":      r.dialect().DateArg(from),
+		"to":        r.dialect().DateArg(to),
+	}).Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
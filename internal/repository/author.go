@@ -12,6 +12,8 @@ type AuthorRepositoryImpl interface {
 	Create(ctx context.Context, req *domain.Author) error
 	GetByName(ctx context.Context, name string) (*domain.Author, error)
 	GetByID(ctx context.Context, id int) (*domain.Author, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*domain.Author, error)
+	Update(ctx context.Context, req *domain.Author, columns ...string) error
 }
 
 type AuthorRepository struct {
@@ -32,7 +34,7 @@ func (r *AuthorRepository) Create(ctx context.Context, req *domain.Author) error
 
 func (r *AuthorRepository) GetByName(ctx context.Context, name string) (*domain.Author, error) {
 	var author domain.Author
-	db := r.tx(ctx).Model(&author).Where("name ilike ?", name).First(&author)
+	db := r.tx(ctx).Model(&author).Where(r.dialect().ILike("name"), name).First(&author)
 	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -57,3 +59,27 @@ func (r *AuthorRepository) GetByID(ctx context.Context, id int) (*domain.Author,
 
 	return &author, nil
 }
+
+func (r *AuthorRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Author, error) {
+	var authors []*domain.Author
+	if len(ids) == 0 {
+		return authors, nil
+	}
+
+	db := r.tx(ctx).Model(&domain.Author{}).Where("id IN ?", ids).Find(&authors)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
+// Update writes only the given columns when they are provided, otherwise every non-zero field of req.
+func (r *AuthorRepository) Update(ctx context.Context, req *domain.Author, columns ...string) error {
+	db := r.tx(ctx).Omit("id").Model(&domain.Author{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
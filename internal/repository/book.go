@@ -6,6 +6,7 @@ import (
 
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BookRepositoryImpl interface {
@@ -13,9 +14,19 @@ type BookRepositoryImpl interface {
 	GetListBookByAuthorID(ctx context.Context, authorID int) ([]*domain.Book, error)
 	DeleteBookByAuthorID(ctx context.Context, authorID, bookID int) error
 	GetByID(ctx context.Context, id int) (*domain.Book, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*domain.Book, error)
+	GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error)
+	GetBySKU(ctx context.Context, sku string) (*domain.Book, error)
+	GetByBarcode(ctx context.Context, barcode string) (*domain.Book, error)
+	GetByLocation(ctx context.Context, location string) ([]*domain.Book, error)
 	Delete(ctx context.Context, id int) error
-	Update(ctx context.Context, req *domain.Book) error
+	Update(ctx context.Context, req *domain.Book, columns ...string) error
 	Create(ctx context.Context, req *domain.Book) error
+	ReplaceContributors(ctx context.Context, bookID int, contributors []*domain.BookContributor) error
+	ReplaceCategories(ctx context.Context, bookID int, categories []*domain.Category) error
+	ReplaceTags(ctx context.Context, bookID int, tags []*domain.Tag) error
+	ReplacePrices(ctx context.Context, bookID int, prices []*domain.BookPrice) error
+	List(ctx context.Context, filter *domain.BookFilter) ([]*domain.Book, int64, error)
 }
 
 type BookRepository struct {
@@ -30,30 +41,103 @@ func NewBookRepository(db *gorm.DB) BookRepositoryImpl {
 	}
 }
 
+// books loads contributors in position order, the publisher, categories and tags with every book query.
+func (r *BookRepository) books(ctx context.Context) *gorm.DB {
+	return r.tx(ctx).Model(&domain.Book{}).
+		Preload("Contributors", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Publisher").
+		Preload("Categories", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		Preload("Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		Preload("Prices", func(db *gorm.DB) *gorm.DB {
+			return db.Order("currency")
+		})
+}
+
+func (r *BookRepository) List(ctx context.Context, filter *domain.BookFilter) ([]*domain.Book, int64, error) {
+	var total int64
+	if err := r.filter(r.tx(ctx).Model(&domain.Book{}), filter).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var books []*domain.Book
+	db := r.filter(r.books(ctx), filter).Order("id").Offset(filter.Offset()).Limit(filter.Limit).Find(&books)
+	if err := db.Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, book := range books {
+		book.AuthorID = book.PrimaryAuthorID()
+	}
+
+	return books, total, nil
+}
+
+// filter applies every set field of the filter, tags must all be present on a book.
+func (r *BookRepository) filter(db *gorm.DB, filter *domain.BookFilter) *gorm.DB {
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		db = db.Where("("+r.dialect().ILike("title")+" OR "+r.dialect().ILike("book_name")+")", like, like)
+	}
+
+	if filter.AuthorID != 0 {
+		db = db.Where("id IN (SELECT book_id FROM book_contributors WHERE author_id = ?)", filter.AuthorID)
+	}
+
+	if filter.PublisherID != 0 {
+		db = db.Where("publisher_id = ?", filter.PublisherID)
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		db = db.Where("id IN (SELECT book_id FROM book_categories WHERE category_id IN ?)", filter.CategoryIDs)
+	}
+
+	if len(filter.Tags) > 0 {
+		db = db.Where(`id IN (
+			SELECT bt.book_id FROM book_tags bt JOIN tags t ON t.id = bt.tag_id
+			WHERE t.name IN ? GROUP BY bt.book_id HAVING COUNT(*) = ?)`, filter.Tags, len(filter.Tags))
+	}
+
+	return db
+}
+
 func (r *BookRepository) GetLastBook(ctx context.Context) (*domain.Book, error) {
 	var books *domain.Book
 
-	if err := r.tx(ctx).Order("created_at DESC").First(&books).Error; err != nil {
+	if err := r.books(ctx).Order("created_at DESC").First(&books).Error; err != nil {
 		return nil, err
 	}
 
+	books.AuthorID = books.PrimaryAuthorID()
+
 	return books, nil
 }
 
 func (r *BookRepository) GetListBookByAuthorID(ctx context.Context, authorID int) ([]*domain.Book, error) {
 	var books []*domain.Book
 
-	db := r.tx(ctx).Model(&domain.Book{}).Where("author_id = ?", authorID).Find(&books)
+	db := r.books(ctx).Where("id IN (SELECT book_id FROM book_contributors WHERE author_id = ?)", authorID).Order("id").Find(&books)
 	if err := db.Error; err != nil {
 		return nil, err
 	}
 
+	for _, book := range books {
+		book.AuthorID = book.PrimaryAuthorID()
+	}
+
 	return books, nil
 
 }
 
 func (r *BookRepository) DeleteBookByAuthorID(ctx context.Context, authorID int, bookID int) error {
-	return r.tx(ctx).Model(&domain.Book{}).Delete("id = ? and author_id = ?", bookID, authorID).Error
+	return r.tx(ctx).
+		Where("id = ? AND EXISTS (SELECT 1 FROM book_contributors WHERE book_contributors.book_id = books.id AND book_contributors.author_id = ?)", bookID, authorID).
+		Delete(&domain.Book{}).Error
 }
 
 func (r *BookRepository) Delete(ctx context.Context, id int) error {
@@ -61,8 +145,51 @@ func (r *BookRepository) Delete(ctx context.Context, id int) error {
 }
 
 func (r *BookRepository) GetByID(ctx context.Context, id int) (*domain.Book, error) {
+	return r.getBy(ctx, "id", id)
+}
+
+func (r *BookRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Book, error) {
+	var books []*domain.Book
+
+	db := r.books(ctx).Where("id IN ?", ids).Order("id").Find(&books)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	for _, book := range books {
+		book.AuthorID = book.PrimaryAuthorID()
+	}
+
+	return books, nil
+}
+
+func (r *BookRepository) GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	return r.getBy(ctx, "isbn13", isbn13)
+}
+
+func (r *BookRepository) GetBySKU(ctx context.Context, sku string) (*domain.Book, error) {
+	return r.getBy(ctx, "sku", sku)
+}
+
+func (r *BookRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Book, error) {
+	return r.getBy(ctx, "barcode", barcode)
+}
+
+// GetByLocation returns the books shelved at location, without their associations.
+func (r *BookRepository) GetByLocation(ctx context.Context, location string) ([]*domain.Book, error) {
+	var books []*domain.Book
+
+	db := r.tx(ctx).Model(&domain.Book{}).Where("location = ?", location).Order("id").Find(&books)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return books, nil
+}
+
+func (r *BookRepository) getBy(ctx context.Context, column string, value interface{}) (*domain.Book, error) {
 	var book domain.Book
-	db := r.tx(ctx).Model(&domain.Book{}).Where("id = ?", id).First(&book)
+	db := r.books(ctx).Where(column+" = ?", value).First(&book)
 	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -71,15 +198,93 @@ func (r *BookRepository) GetByID(ctx context.Context, id int) (*domain.Book, err
 		return nil, err
 	}
 
+	book.AuthorID = book.PrimaryAuthorID()
+
 	return &book, nil
 }
 
-func (r *BookRepository) Update(ctx context.Context, req *domain.Book) error {
-	return r.tx(ctx).Omit("id").Model(&domain.Book{}).Where("id = ?", req.ID).Updates(&req).Error
+// Update writes only the given columns when they are provided, otherwise every non-zero field of req.
+// Contributors are never written here, see ReplaceContributors.
+func (r *BookRepository) Update(ctx context.Context, req *domain.Book, columns ...string) error {
+	db := r.tx(ctx).Omit("id", clause.Associations).Model(&domain.Book{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
 }
 
+// Create inserts the book with its contributors and links it to existing publisher, categories and tags.
 func (r *BookRepository) Create(ctx context.Context, req *domain.Book) error {
-	db := r.tx(ctx).Model(&domain.Book{}).Create(&req)
+	db := r.tx(ctx).Model(&domain.Book{}).Omit("Publisher", "Categories.*", "Tags.*", "Prices").Create(&req)
 
 	return db.Error
 }
+
+func (r *BookRepository) ReplaceContributors(ctx context.Context, bookID int, contributors []*domain.BookContributor) error {
+	return r.tx(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", bookID).Delete(&domain.BookContributor{}).Error; err != nil {
+			return err
+		}
+
+		if len(contributors) == 0 {
+			return nil
+		}
+
+		for _, contributor := range contributors {
+			contributor.BookID = bookID
+		}
+
+		return tx.Create(&contributors).Error
+	})
+}
+
+func (r *BookRepository) ReplaceCategories(ctx context.Context, bookID int, categories []*domain.Category) error {
+	links := make([]map[string]interface{}, 0, len(categories))
+	for _, category := range categories {
+		links = append(links, map[string]interface{}{"book_id": bookID, "category_id": category.ID})
+	}
+
+	return r.replaceLinks(ctx, "book_categories", bookID, links)
+}
+
+func (r *BookRepository) ReplaceTags(ctx context.Context, bookID int, tags []*domain.Tag) error {
+	links := make([]map[string]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		links = append(links, map[string]interface{}{"book_id": bookID, "tag_id": tag.ID})
+	}
+
+	return r.replaceLinks(ctx, "book_tags", bookID, links)
+}
+
+func (r *BookRepository) replaceLinks(ctx context.Context, table string, bookID int, links []map[string]interface{}) error {
+	return r.tx(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM "+table+" WHERE book_id = ?", bookID).Error; err != nil {
+			return err
+		}
+
+		if len(links) == 0 {
+			return nil
+		}
+
+		return tx.Table(table).Clauses(r.dialect().IgnoreConflicts(clause.OnConflict{})).Create(&links).Error
+	})
+}
+
+func (r *BookRepository) ReplacePrices(ctx context.Context, bookID int, prices []*domain.BookPrice) error {
+	return r.tx(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("book_id = ?", bookID).Delete(&domain.BookPrice{}).Error; err != nil {
+			return err
+		}
+
+		if len(prices) == 0 {
+			return nil
+		}
+
+		for _, price := range prices {
+			price.BookID = bookID
+		}
+
+		return tx.Create(&prices).Error
+	})
+}
@@ -0,0 +1,34 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+type AuthorRepository struct {
+	repository.AuthorRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *AuthorRepository) GetByID(ctx context.Context, id int) (*domain.Author, error) {
+	if skipCache(ctx) {
+		return r.AuthorRepositoryImpl.GetByID(ctx, id)
+	}
+
+	return cache.Load(ctx, r.cache, kindAuthor, strconv.Itoa(id), func(ctx context.Context) (*domain.Author, error) {
+		return r.AuthorRepositoryImpl.GetByID(repository.ReadYourWrites(ctx), id)
+	})
+}
+
+func (r *AuthorRepository) Update(ctx context.Context, req *domain.Author, columns ...string) error {
+	if err := r.AuthorRepositoryImpl.Update(ctx, req, columns...); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindAuthor, req.ID)
+	return nil
+}
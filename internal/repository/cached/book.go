@@ -0,0 +1,110 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+type BookRepository struct {
+	repository.BookRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *BookRepository) GetByID(ctx context.Context, id int) (*domain.Book, error) {
+	if skipCache(ctx) {
+		return r.BookRepositoryImpl.GetByID(ctx, id)
+	}
+
+	book, err := cache.Load(ctx, r.cache, kindBook, strconv.Itoa(id), func(ctx context.Context) (*domain.Book, error) {
+		return r.BookRepositoryImpl.GetByID(repository.ReadYourWrites(ctx), id)
+	})
+	if err != nil || book == nil {
+		return nil, err
+	}
+
+	// the encoding drops empty slices, a loaded book has its associations empty instead of nil
+	if book.Contributors == nil {
+		book.Contributors = []*domain.BookContributor{}
+	}
+
+	if book.Categories == nil {
+		book.Categories = []*domain.Category{}
+	}
+
+	if book.Tags == nil {
+		book.Tags = []*domain.Tag{}
+	}
+
+	if book.Prices == nil {
+		book.Prices = []*domain.BookPrice{}
+	}
+
+	return book, nil
+}
+
+func (r *BookRepository) Update(ctx context.Context, req *domain.Book, columns ...string) error {
+	if err := r.BookRepositoryImpl.Update(ctx, req, columns...); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, req.ID)
+	return nil
+}
+
+func (r *BookRepository) Delete(ctx context.Context, id int) error {
+	if err := r.BookRepositoryImpl.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, id)
+	return nil
+}
+
+func (r *BookRepository) DeleteBookByAuthorID(ctx context.Context, authorID, bookID int) error {
+	if err := r.BookRepositoryImpl.DeleteBookByAuthorID(ctx, authorID, bookID); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
+
+func (r *BookRepository) ReplaceContributors(ctx context.Context, bookID int, contributors []*domain.BookContributor) error {
+	if err := r.BookRepositoryImpl.ReplaceContributors(ctx, bookID, contributors); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
+
+func (r *BookRepository) ReplaceCategories(ctx context.Context, bookID int, categories []*domain.Category) error {
+	if err := r.BookRepositoryImpl.ReplaceCategories(ctx, bookID, categories); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
+
+func (r *BookRepository) ReplaceTags(ctx context.Context, bookID int, tags []*domain.Tag) error {
+	if err := r.BookRepositoryImpl.ReplaceTags(ctx, bookID, tags); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
+
+func (r *BookRepository) ReplacePrices(ctx context.Context, bookID int, prices []*domain.BookPrice) error {
+	if err := r.BookRepositoryImpl.ReplacePrices(ctx, bookID, prices); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
@@ -0,0 +1,233 @@
+package cached_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/cached"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/memory"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRepository(t *testing.T) {
+	Convey("Test cached repository", t, func() {
+		ctx := context.Background()
+
+		// writes through store are not seen by the cache, they show what it serves
+		store := memory.NewRepository()
+		c := cache.New("test", cache.NewLRU(100), nil, time.Minute)
+		repo := cached.NewRepository(store, c)
+
+		So(store.GetAuthorRepo().Create(ctx, &domain.Author{Name: "Frank Herbert", Email: "frank@example.com"}), ShouldBeNil)
+		So(store.GetBookRepo().Create(ctx, &domain.Book{BookName: "Dune", Title: "Dune", SKU: "BK-1", Barcode: "2000000000015"}), ShouldBeNil)
+
+		title := func(ctx context.Context) string {
+			book, err := repo.GetBookRepo().GetByID(ctx, 1)
+			So(err, ShouldBeNil)
+			return book.Title
+		}
+
+		So(title(ctx), ShouldEqual, "Dune")
+		So(store.GetBookRepo().Update(ctx, &domain.Book{ID: 1, Title: "Stale"}, "title"), ShouldBeNil)
+
+		Convey("serves books from the cache", func() {
+			book, err := repo.GetBookRepo().GetByID(ctx, 1)
+			So(err, ShouldBeNil)
+			So(book.Title, ShouldEqual, "Dune")
+			So(book.Contributors, ShouldNotBeNil)
+			So(c.Stats()["book"], ShouldResemble, cache.Stats{Hits: 1, Misses: 1})
+
+			So(title(repository.ReadYourWrites(ctx)), ShouldEqual, "Stale")
+		})
+
+		Convey("drops a book written through it", func() {
+			So(repo.GetBookRepo().Update(ctx, &domain.Book{ID: 1, Title: "Dune (1965)"}, "title"), ShouldBeNil)
+			So(title(ctx), ShouldEqual, "Dune (1965)")
+		})
+
+		Convey("drops a book written in a transaction once it commits", func() {
+			So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				So(repo.GetBookRepo().Update(txCtx, &domain.Book{ID: 1, Title: "Dune (1965)"}, "title"), ShouldBeNil)
+
+				So(title(txCtx), ShouldEqual, "Dune (1965)")
+				So(title(ctx), ShouldEqual, "Dune")
+				return nil
+			}), ShouldBeNil)
+
+			So(title(ctx), ShouldEqual, "Dune (1965)")
+		})
+
+		Convey("keeps the cache when a transaction rolls back", func() {
+			So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				So(repo.GetBookRepo().Update(txCtx, &domain.Book{ID: 1, Title: "Dune (1965)"}, "title"), ShouldBeNil)
+				return errors.New("failed")
+			}), ShouldNotBeNil)
+
+			So(title(ctx), ShouldEqual, "Dune")
+		})
+
+		Convey("caches authors and users until they are written", func() {
+			author, err := repo.GetAuthorRepo().GetByID(ctx, 1)
+			So(err, ShouldBeNil)
+			So(author.Name, ShouldEqual, "Frank Herbert")
+
+			So(repo.GetAuthorRepo().Update(ctx, &domain.Author{ID: 1, Name: "F. Herbert"}), ShouldBeNil)
+
+			author, err = repo.GetAuthorRepo().GetByID(ctx, 1)
+			So(err, ShouldBeNil)
+			So(author.Name, ShouldEqual, "F. Herbert")
+
+			So(store.GetUserRepo().RegisterUser(ctx, &domain.User{Username: "ada", Email: "ada@example.com"}), ShouldBeNil)
+
+			for i := 0; i < 2; i++ {
+				user, err := repo.GetUserRepo().GetByID(ctx, 1)
+				So(err, ShouldBeNil)
+				So(user.Username, ShouldEqual, "ada")
+			}
+
+			So(c.Stats()["user"], ShouldResemble, cache.Stats{Hits: 1, Misses: 1})
+		})
+	})
+}
+
+// replicaStore keeps books in the memory repository and serves them from a lagging replica unless
+// the lookup reads its writes.
+type replicaStore struct {
+	repository.RepositoryImpl
+	books *laggingBooks
+}
+
+func (s *replicaStore) GetBookRepo() repository.BookRepositoryImpl { return s.books }
+
+type laggingBooks struct {
+	repository.BookRepositoryImpl
+	stale domain.Book
+}
+
+func (r *laggingBooks) GetByID(ctx context.Context, id int) (*domain.Book, error) {
+	if !repository.ReadsYourWrites(ctx) && r.stale.ID == id {
+		stale := r.stale
+		return &stale, nil
+	}
+
+	return r.BookRepositoryImpl.GetByID(ctx, id)
+}
+
+func TestReplica(t *testing.T) {
+	Convey("Test cached repository with a lagging replica", t, func() {
+		ctx := context.Background()
+
+		store := memory.NewRepository()
+		So(store.GetBookRepo().Create(ctx, &domain.Book{BookName: "Dune", Title: "Dune", SKU: "BK-1", Barcode: "2000000000015"}), ShouldBeNil)
+
+		c := cache.New("test", cache.NewLRU(100), nil, time.Minute)
+		repo := cached.NewRepository(&replicaStore{
+			RepositoryImpl: store,
+			books:          &laggingBooks{BookRepositoryImpl: store.GetBookRepo(), stale: domain.Book{ID: 1, Title: "Dune"}},
+		}, c)
+
+		Convey("loads a dropped book from the primary", func() {
+			So(repo.GetBookRepo().Update(ctx, &domain.Book{ID: 1, Title: "Dune (1965)"}, "title"), ShouldBeNil)
+
+			for i := 0; i < 2; i++ {
+				book, err := repo.GetBookRepo().GetByID(ctx, 1)
+				So(err, ShouldBeNil)
+				So(book.Title, ShouldEqual, "Dune (1965)")
+			}
+
+			So(c.Stats()["book"], ShouldResemble, cache.Stats{Hits: 1, Misses: 1})
+		})
+	})
+}
+
+// taxonomyStore keeps books in the memory repository and takes its publishers, categories and tags
+// from mocks.
+type taxonomyStore struct {
+	repository.RepositoryImpl
+	publishers *repositoryMock.MockPublisherRepositoryImpl
+	categories *repositoryMock.MockCategoryRepositoryImpl
+	tags       *repositoryMock.MockTagRepositoryImpl
+}
+
+func (s *taxonomyStore) GetPublisherRepo() repository.PublisherRepositoryImpl { return s.publishers }
+func (s *taxonomyStore) GetCategoryRepo() repository.CategoryRepositoryImpl   { return s.categories }
+func (s *taxonomyStore) GetTagRepo() repository.TagRepositoryImpl             { return s.tags }
+
+func TestTaxonomy(t *testing.T) {
+	Convey("Test cached books of publishers, categories and tags", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		ctx := context.Background()
+
+		store := &taxonomyStore{
+			RepositoryImpl: memory.NewRepository(),
+			publishers:     repositoryMock.NewMockPublisherRepositoryImpl(ctrl),
+			categories:     repositoryMock.NewMockCategoryRepositoryImpl(ctrl),
+			tags:           repositoryMock.NewMockTagRepositoryImpl(ctrl),
+		}
+		repo := cached.NewRepository(store, cache.New("test", cache.NewLRU(100), nil, time.Minute))
+
+		So(store.GetBookRepo().Create(ctx, &domain.Book{BookName: "Dune", Title: "Dune", SKU: "BK-1", Barcode: "2000000000015"}), ShouldBeNil)
+		So(store.GetBookRepo().Create(ctx, &domain.Book{BookName: "Emma", Title: "Emma", SKU: "BK-2", Barcode: "2000000000022"}), ShouldBeNil)
+
+		titles := func() []string {
+			var titles []string
+			for _, id := range []int{1, 2} {
+				book, err := repo.GetBookRepo().GetByID(ctx, id)
+				So(err, ShouldBeNil)
+				titles = append(titles, book.Title)
+			}
+
+			return titles
+		}
+
+		So(titles(), ShouldResemble, []string{"Dune", "Emma"})
+		So(store.GetBookRepo().Update(ctx, &domain.Book{ID: 1, Title: "Dune (1965)"}, "title"), ShouldBeNil)
+		So(store.GetBookRepo().Update(ctx, &domain.Book{ID: 2, Title: "Emma (1815)"}, "title"), ShouldBeNil)
+
+		Convey("drops the books of a renamed publisher", func() {
+			store.publishers.EXPECT().GetBookIDs(gomock.Any(), 3).Return([]int{1}, nil)
+			store.publishers.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			So(repo.GetPublisherRepo().Update(ctx, &domain.Publisher{ID: 3, Name: "Chilton"}), ShouldBeNil)
+			So(titles(), ShouldResemble, []string{"Dune (1965)", "Emma"})
+		})
+
+		Convey("drops the books of a deleted category", func() {
+			store.categories.EXPECT().GetBookIDs(gomock.Any(), 4).Return([]int{1, 2}, nil)
+			store.categories.EXPECT().Delete(gomock.Any(), 4).Return(nil)
+
+			So(repo.GetCategoryRepo().Delete(ctx, 4), ShouldBeNil)
+			So(titles(), ShouldResemble, []string{"Dune (1965)", "Emma (1815)"})
+		})
+
+		Convey("drops the books of a renamed tag once the transaction commits", func() {
+			store.tags.EXPECT().GetBookIDs(gomock.Any(), 5).Return([]int{2}, nil)
+			store.tags.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+
+			So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				So(repo.GetTagRepo().Update(txCtx, &domain.Tag{ID: 5, Name: "classic"}), ShouldBeNil)
+				So(titles(), ShouldResemble, []string{"Dune", "Emma"})
+				return nil
+			}), ShouldBeNil)
+
+			So(titles(), ShouldResemble, []string{"Dune", "Emma (1815)"})
+		})
+
+		Convey("keeps the books when the write fails", func() {
+			store.tags.EXPECT().GetBookIDs(gomock.Any(), 5).Return([]int{2}, nil)
+			store.tags.EXPECT().Delete(gomock.Any(), 5).Return(errors.New("error db"))
+
+			So(repo.GetTagRepo().Delete(ctx, 5), ShouldNotBeNil)
+			So(titles(), ShouldResemble, []string{"Dune", "Emma"})
+		})
+	})
+}
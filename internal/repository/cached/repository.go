@@ -0,0 +1,90 @@
+// Package cached decorates a repository.RepositoryImpl with cache-aside lookups of books, authors and
+// users by id. A write through the decorated repositories drops the entries it changes, inside a
+// transaction once the transaction commits, renaming or deleting a publisher, category or tag drops
+// the books that embed it. Lookups inside a transaction or marked with repository.ReadYourWrites skip
+// the cache. Misses are loaded from the primary, a replica may not have the write that dropped the
+// entry yet and would cache the old row again.
+//
+// Other instances see a write once their local entry expires.
+package cached
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+const (
+	kindBook   = "book"
+	kindAuthor = "author"
+	kindUser   = "user"
+)
+
+type Repository struct {
+	repository.RepositoryImpl
+	cache *cache.Cache
+}
+
+func NewRepository(repo repository.RepositoryImpl, c *cache.Cache) repository.RepositoryImpl {
+	return &Repository{
+		RepositoryImpl: repo,
+		cache:          c,
+	}
+}
+
+func (r *Repository) GetUserRepo() repository.UserRepositoryImpl {
+	return &UserRepository{UserRepositoryImpl: r.RepositoryImpl.GetUserRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetBookRepo() repository.BookRepositoryImpl {
+	return &BookRepository{BookRepositoryImpl: r.RepositoryImpl.GetBookRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetAuthorRepo() repository.AuthorRepositoryImpl {
+	return &AuthorRepository{AuthorRepositoryImpl: r.RepositoryImpl.GetAuthorRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetTransactionRepo() repository.TransactionRepositoryImpl {
+	return &TransactionRepository{TransactionRepositoryImpl: r.RepositoryImpl.GetTransactionRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetPublisherRepo() repository.PublisherRepositoryImpl {
+	return &PublisherRepository{PublisherRepositoryImpl: r.RepositoryImpl.GetPublisherRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetCategoryRepo() repository.CategoryRepositoryImpl {
+	return &CategoryRepository{CategoryRepositoryImpl: r.RepositoryImpl.GetCategoryRepo(), cache: r.cache}
+}
+
+func (r *Repository) GetTagRepo() repository.TagRepositoryImpl {
+	return &TagRepository{TagRepositoryImpl: r.RepositoryImpl.GetTagRepo(), cache: r.cache}
+}
+
+// GetStockRepo drops the books whose stock or reservations change.
+func (r *Repository) GetStockRepo() repository.StockRepositoryImpl {
+	return &StockRepository{StockRepositoryImpl: r.RepositoryImpl.GetStockRepo(), cache: r.cache}
+}
+
+// skipCache reports whether a lookup has to see writes the cache may not have, those of the
+// transaction in ctx or ones marked with ReadYourWrites.
+func skipCache(ctx context.Context) bool {
+	_, inTransaction := ctx.Value(pendingKey{}).(*pending)
+	return inTransaction || repository.ReadsYourWrites(ctx)
+}
+
+// invalidate drops the entries of ids, inside a transaction once it commits.
+func invalidate(ctx context.Context, c *cache.Cache, kind string, ids ...int) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, strconv.Itoa(id))
+	}
+
+	if p, ok := ctx.Value(pendingKey{}).(*pending); ok {
+		p.add(kind, keys...)
+		return
+	}
+
+	c.Delete(ctx, kind, keys...)
+}
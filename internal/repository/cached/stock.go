@@ -0,0 +1,50 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+type StockRepository struct {
+	repository.StockRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *StockRepository) Record(ctx context.Context, movements []*domain.StockMovement) error {
+	if err := r.StockRepositoryImpl.Record(ctx, movements); err != nil {
+		return err
+	}
+
+	ids := make([]int, 0, len(movements))
+	for _, movement := range movements {
+		ids = append(ids, movement.BookID)
+	}
+
+	invalidate(ctx, r.cache, kindBook, ids...)
+	return nil
+}
+
+func (r *StockRepository) Reserve(ctx context.Context, bookID, quantity int) (bool, error) {
+	reserved, err := r.StockRepositoryImpl.Reserve(ctx, bookID, quantity)
+	if err != nil {
+		return false, err
+	}
+
+	if reserved {
+		invalidate(ctx, r.cache, kindBook, bookID)
+	}
+
+	return reserved, nil
+}
+
+func (r *StockRepository) Release(ctx context.Context, bookID, quantity int) error {
+	if err := r.StockRepositoryImpl.Release(ctx, bookID, quantity); err != nil {
+		return err
+	}
+
+	invalidate(ctx, r.cache, kindBook, bookID)
+	return nil
+}
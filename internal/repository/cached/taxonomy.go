@@ -0,0 +1,80 @@
+package cached
+
+import (
+	"context"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+// A cached book embeds its publisher, categories and tags, the repositories below drop the books of
+// the ones they rename or delete.
+
+type PublisherRepository struct {
+	repository.PublisherRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *PublisherRepository) Update(ctx context.Context, req *domain.Publisher) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, req.ID, func() error {
+		return r.PublisherRepositoryImpl.Update(ctx, req)
+	})
+}
+
+func (r *PublisherRepository) Delete(ctx context.Context, id int) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, id, func() error {
+		return r.PublisherRepositoryImpl.Delete(ctx, id)
+	})
+}
+
+type CategoryRepository struct {
+	repository.CategoryRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, req *domain.Category) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, req.ID, func() error {
+		return r.CategoryRepositoryImpl.Update(ctx, req)
+	})
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, id, func() error {
+		return r.CategoryRepositoryImpl.Delete(ctx, id)
+	})
+}
+
+type TagRepository struct {
+	repository.TagRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *TagRepository) Update(ctx context.Context, req *domain.Tag) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, req.ID, func() error {
+		return r.TagRepositoryImpl.Update(ctx, req)
+	})
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id int) error {
+	return writeBooksOf(ctx, r.cache, r.GetBookIDs, id, func() error {
+		return r.TagRepositoryImpl.Delete(ctx, id)
+	})
+}
+
+// writeBooksOf runs write and drops the books bookIDs returns for id. The books are looked up ahead of
+// write, a delete takes their links with it, and on the primary, a replica may not have a book linked
+// a moment ago.
+func writeBooksOf(ctx context.Context, c *cache.Cache, bookIDs func(ctx context.Context, id int) ([]int, error), id int, write func() error) error {
+	ids, err := bookIDs(repository.ReadYourWrites(ctx), id)
+	if err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	invalidate(ctx, c, kindBook, ids...)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cached
+
+import (
+	"context"
+	"sync"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+type pendingKey struct{}
+
+// pending collects the entries a transaction changes, they are dropped when it commits.
+type pending struct {
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func (p *pending) add(kind string, keys ...string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys[kind] = append(p.keys[kind], keys...)
+}
+
+type TransactionRepository struct {
+	repository.TransactionRepositoryImpl
+	cache *cache.Cache
+}
+
+// WithTransaction drops the entries fn changed once the transaction commits, until then other
+// callers still read the committed rows. A transaction that joins another, or a savepoint in it,
+// leaves it to the outer one, one with PropagationRequiresNew commits on its own and drops its own.
+func (r *TransactionRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+	_, inTx := ctx.Value(pendingKey{}).(*pending)
+	if inTx && repository.NewTxOptions(opts...).Propagation != repository.PropagationRequiresNew {
+		return r.TransactionRepositoryImpl.WithTransaction(ctx, fn, opts...)
+	}
+
+	p := &pending{keys: make(map[string][]string)}
+	if err := r.TransactionRepositoryImpl.WithTransaction(context.WithValue(ctx, pendingKey{}, p), fn, opts...); err != nil {
+		return err
+	}
+
+	for kind, keys := range p.keys {
+		r.cache.Delete(ctx, kind, keys...)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package cached
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/cache"
+)
+
+// UserRepository caches the users the JWT middleware loads on every request, users are never changed.
+type UserRepository struct {
+	repository.UserRepositoryImpl
+	cache *cache.Cache
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	if skipCache(ctx) {
+		return r.UserRepositoryImpl.GetByID(ctx, id)
+	}
+
+	return cache.Load(ctx, r.cache, kindUser, strconv.Itoa(id), func(ctx context.Context) (*domain.User, error) {
+		return r.UserRepositoryImpl.GetByID(repository.ReadYourWrites(ctx), id)
+	})
+}
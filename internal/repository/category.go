@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type CategoryRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Category) error
+	GetByID(ctx context.Context, id int) (*domain.Category, error)
+	GetByIDs(ctx context.Context, ids []int) ([]*domain.Category, error)
+	GetByName(ctx context.Context, parentID *int, name string) (*domain.Category, error)
+	GetDescendantIDs(ctx context.Context, id int) ([]int, error)
+	HasChildren(ctx context.Context, id int) (bool, error)
+	List(ctx context.Context) ([]*domain.Category, error)
+	GetBookIDs(ctx context.Context, id int) ([]int, error)
+	Update(ctx context.Context, req *domain.Category) error
+	Delete(ctx context.Context, id int) error
+}
+
+type CategoryRepository struct {
+	TransactionRepository
+}
+
+func NewCategoryRepository(db *gorm.DB) CategoryRepositoryImpl {
+	return &CategoryRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, req *domain.Category) error {
+	return r.tx(ctx).Model(&domain.Category{}).Create(&req).Error
+}
+
+func (r *CategoryRepository) GetByID(ctx context.Context, id int) (*domain.Category, error) {
+	var category domain.Category
+	db := r.tx(ctx).Model(&domain.Category{}).Where("id = ?", id).First(&category)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	if len(ids) == 0 {
+		return categories, nil
+	}
+
+	if err := r.tx(ctx).Model(&domain.Category{}).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// GetByName finds a sibling by name, a nil parentID looks among the root categories.
+func (r *CategoryRepository) GetByName(ctx context.Context, parentID *int, name string) (*domain.Category, error) {
+	db := r.tx(ctx).Model(&domain.Category{}).Where("LOWER(name) = LOWER(?)", name)
+	if parentID == nil {
+		db = db.Where("parent_id IS NULL")
+	} else {
+		db = db.Where("parent_id = ?", *parentID)
+	}
+
+	var category domain.Category
+	db = db.First(&category)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetDescendantIDs returns id followed by the ids of every category below it.
+func (r *CategoryRepository) GetDescendantIDs(ctx context.Context, id int) ([]int, error) {
+	var ids []int
+	err := r.tx(ctx).Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree`, id).Scan(&ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *CategoryRepository) HasChildren(ctx context.Context, id int) (bool, error) {
+	var count int64
+	if err := r.tx(ctx).Model(&domain.Category{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *CategoryRepository) List(ctx context.Context) ([]*domain.Category, error) {
+	var categories []*domain.Category
+	if err := r.tx(ctx).Model(&domain.Category{}).Order("name").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// GetBookIDs returns the ids of the books in the category, not in its descendants.
+func (r *CategoryRepository) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	var ids []int
+	if err := r.tx(ctx).Table("book_categories").Where("category_id = ?", id).Pluck("book_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, req *domain.Category) error {
+	return r.tx(ctx).Model(&domain.Category{}).Where("id = ?", req.ID).Select("parent_id", "name").Updates(req).Error
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.Category{}).Error
+}
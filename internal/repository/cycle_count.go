@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CycleCountRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.CycleCount) error
+	GetByID(ctx context.Context, id int) (*domain.CycleCount, error)
+	GetByIDForUpdate(ctx context.Context, id int) (*domain.CycleCount, error)
+	List(ctx context.Context, filter *domain.CycleCountFilter) ([]*domain.CycleCount, error)
+	Update(ctx context.Context, req *domain.CycleCount, columns ...string) error
+	UpdateLineCount(ctx context.Context, line *domain.CycleCountLine) error
+	CloseLines(ctx context.Context, cycleCountID int) error
+	OpenBookIDs(ctx context.Context, bookIDs []int) ([]int, error)
+}
+
+type CycleCountRepository struct {
+	TransactionRepository
+}
+
+func NewCycleCountRepository(db *gorm.DB) CycleCountRepositoryImpl {
+	return &CycleCountRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *CycleCountRepository) counts(ctx context.Context) *gorm.DB {
+	return r.tx(ctx).Model(&domain.CycleCount{}).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("book_id")
+		})
+}
+
+// Create inserts the count with its lines, a book already in another open count fails the
+// idx_cycle_count_lines_open_book index.
+func (r *CycleCountRepository) Create(ctx context.Context, req *domain.CycleCount) error {
+	return r.tx(ctx).Model(&domain.CycleCount{}).Create(&req).Error
+}
+
+func (r *CycleCountRepository) GetByID(ctx context.Context, id int) (*domain.CycleCount, error) {
+	return r.getBy(r.counts(ctx), id)
+}
+
+// GetByIDForUpdate locks the count until the transaction in ctx ends so clerks never overwrite each other.
+func (r *CycleCountRepository) GetByIDForUpdate(ctx context.Context, id int) (*domain.CycleCount, error) {
+	return r.getBy(r.counts(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *CycleCountRepository) getBy(db *gorm.DB, id int) (*domain.CycleCount, error) {
+	var count domain.CycleCount
+
+	db = db.Where("id = ?", id).First(&count)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &count, nil
+}
+
+func (r *CycleCountRepository) List(ctx context.Context, filter *domain.CycleCountFilter) ([]*domain.CycleCount, error) {
+	db := r.tx(ctx).Model(&domain.CycleCount{})
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	var counts []*domain.CycleCount
+	if err := db.Order("id DESC").Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *CycleCountRepository) Update(ctx context.Context, req *domain.CycleCount, columns ...string) error {
+	db := r.tx(ctx).Omit("id", clause.Associations).Model(&domain.CycleCount{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
+
+func (r *CycleCountRepository) UpdateLineCount(ctx context.Context, line *domain.CycleCountLine) error {
+	return r.tx(ctx).Model(&domain.CycleCountLine{}).Where("id = ?", line.ID).
+		Select("counted", "counted_by", "counted_at").
+		Updates(line).Error
+}
+
+// CloseLines frees the books of the count for the next one.
+func (r *CycleCountRepository) CloseLines(ctx context.Context, cycleCountID int) error {
+	return r.tx(ctx).Model(&domain.CycleCountLine{}).Where("cycle_count_id = ?", cycleCountID).
+		UpdateColumn("closed", true).Error
+}
+
+// OpenBookIDs returns which of the books are in an open count.
+func (r *CycleCountRepository) OpenBookIDs(ctx context.Context, bookIDs []int) ([]int, error) {
+	var ids []int
+
+	db := r.tx(ctx).Model(&domain.CycleCountLine{}).Where("book_id IN ? AND NOT closed", bookIDs).
+		Order("book_id").Pluck("book_id", &ids)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ForecastRepositoryImpl interface {
+	ListDemand(ctx context.Context, from, to time.Time, bookID int) ([]*domain.BookDemand, error)
+	ListSuppliers(ctx context.Context, bookIDs []int) ([]*domain.BookSupplier, error)
+	ListOnOrder(ctx context.Context, bookIDs []int) ([]*domain.BookOnOrder, error)
+}
+
+type ForecastRepository struct {
+	TransactionRepository
+}
+
+func NewForecastRepository(db *gorm.DB) ForecastRepositoryImpl {
+	return &ForecastRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// bookSuppliers picks the supplier of the reorder point, or of the latest purchase order that was not cancelled.
+const bookSuppliers = `SELECT b.id AS book_id, s.id AS supplier_id, s.lead_time_days
+	FROM books b
+	JOIN suppliers s ON s.id = COALESCE(
+		(SELECT rp.supplier_id FROM reorder_points rp WHERE rp.book_id = b.id),
+		(SELECT po.supplier_id FROM purchase_orders po
+			JOIN purchase_order_lines pol ON pol.purchase_order_id = po.id
+			WHERE pol.book_id = b.id AND po.status <> @cancelled
+			ORDER BY po.created_at DESC, po.id DESC LIMIT 1)
+	)
+	WHERE b.id IN @ids`
+
+// ListDemand returns the copies shipped per book and day from from up to to, of one book when bookID is set.
+func (r *ForecastRepository) ListDemand(ctx context.Context, from, to time.Time, bookID int) ([]*domain.BookDemand, error) {
+	var rows []*domain.BookDemand
+
+	day := r.dialect().Date("created_at")
+
+	db := r.tx(ctx).Model(&domain.StockMovement{}).
+		Select("book_id, "+day+" AS day, SUM(-quantity) AS quantity").
+		Where("type = ? AND created_at >= ? AND created_at < ?", domain.StockMovementSaleShipment, from, to)
+	if bookID != 0 {
+		db = db.Where("book_id = ?", bookID)
+	}
+
+	if err := db.Group("book_id, " + day).Order("book_id, day").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *ForecastRepository) ListSuppliers(ctx context.Context, bookIDs []int) ([]*domain.BookSupplier, error) {
+	var rows []*domain.BookSupplier
+
+	db := r.tx(ctx).Raw(bookSuppliers, map[string]interface{}{
+		"cancelled": domain.PurchaseOrderCancelled,
+		"ids":       bookIDs,
+	}).Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ListOnOrder returns what is still to be received of the submitted purchase orders.
+func (r *ForecastRepository) ListOnOrder(ctx context.Context, bookIDs []int) ([]*domain.BookOnOrder, error) {
+	var rows []*domain.BookOnOrder
+
+	db := r.tx(ctx).Table("purchase_order_lines pol").
+		Select("pol.book_id, SUM(pol.quantity_ordered - pol.quantity_received) AS quantity").
+		Joins("JOIN purchase_orders po ON po.id = pol.purchase_order_id").
+		Where("po.status IN ? AND pol.book_id IN ?", []string{domain.PurchaseOrderSubmitted, domain.PurchaseOrderPartiallyReceived}, bookIDs).
+		Group("pol.book_id").
+		Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyRepositoryImpl interface {
+	GetByKey(ctx context.Context, userID int, key string) (*domain.IdempotencyKey, error)
+	Create(ctx context.Context, req *domain.IdempotencyKey) (bool, error)
+	UpdateResponse(ctx context.Context, req *domain.IdempotencyKey) error
+	Delete(ctx context.Context, id int) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type IdempotencyRepository struct {
+	TransactionRepository
+}
+
+func NewIdempotencyRepository(db *gorm.DB) IdempotencyRepositoryImpl {
+	return &IdempotencyRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *IdempotencyRepository) GetByKey(ctx context.Context, userID int, key string) (*domain.IdempotencyKey, error) {
+	var idempotencyKey domain.IdempotencyKey
+	db := r.tx(ctx).Model(&domain.IdempotencyKey{}).Where("idempotency_key = ? and user_id = ?", key, userID).First(&idempotencyKey)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+// Create stores the key as in progress. It returns false when another request already holds the key.
+func (r *IdempotencyRepository) Create(ctx context.Context, req *domain.IdempotencyKey) (bool, error) {
+	db := r.tx(ctx).Model(&domain.IdempotencyKey{}).Clauses(r.dialect().IgnoreConflicts(clause.OnConflict{})).Create(&req)
+	if err := db.Error; err != nil {
+		return false, err
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+func (r *IdempotencyRepository) UpdateResponse(ctx context.Context, req *domain.IdempotencyKey) error {
+	return r.tx(ctx).Model(&domain.IdempotencyKey{}).Where("id = ?", req.ID).Updates(map[string]interface{}{
+		"status_code":   req.StatusCode,
+		"content_type":  req.ContentType,
+		"response_body": req.ResponseBody,
+	}).Error
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.IdempotencyKey{}).Error
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return r.tx(ctx).Where("expires_at < ?", now).Delete(&domain.IdempotencyKey{}).Error
+}
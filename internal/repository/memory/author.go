@@ -0,0 +1,91 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+)
+
+type AuthorRepository struct {
+	TransactionRepository
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, req *domain.Author) error {
+	defer r.lock()()
+
+	id, err := r.store.authors.newID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	req.ID = id
+
+	author := *req
+	put(ctx, r.store.authors, author.ID, &author)
+
+	return nil
+}
+
+func (r *AuthorRepository) GetByName(ctx context.Context, name string) (*domain.Author, error) {
+	defer r.lock()()
+
+	for _, id := range sortedIDs(r.store.authors.rows) {
+		if author := r.store.authors.rows[id]; strings.EqualFold(author.Name, name) {
+			found := *author
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *AuthorRepository) GetByID(ctx context.Context, id int) (*domain.Author, error) {
+	defer r.lock()()
+
+	author, ok := r.store.authors.rows[id]
+	if !ok {
+		return nil, nil
+	}
+
+	found := *author
+	return &found, nil
+}
+
+func (r *AuthorRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Author, error) {
+	defer r.lock()()
+
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	var authors []*domain.Author
+	for _, id := range sortedIDs(r.store.authors.rows) {
+		if wanted[id] {
+			author := *r.store.authors.rows[id]
+			authors = append(authors, &author)
+		}
+	}
+
+	return authors, nil
+}
+
+// Update writes only the given columns when they are provided, otherwise every non-zero field of req.
+func (r *AuthorRepository) Update(ctx context.Context, req *domain.Author, columns ...string) error {
+	defer r.lock()()
+
+	current, ok := r.store.authors.rows[req.ID]
+	if !ok {
+		return nil
+	}
+
+	author := *current
+	if err := update(ctx, &author, req, columns); err != nil {
+		return err
+	}
+
+	put(ctx, r.store.authors, author.ID, &author)
+
+	return nil
+}
@@ -0,0 +1,413 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+// BookRepository keeps the contributors, category and tag links and list prices with their book.
+// Publishers are not kept, Publisher is never loaded. Stock and reserved stay zero, they only
+// change through stock movements.
+type BookRepository struct {
+	TransactionRepository
+}
+
+// cloneBook copies book with its associations, nothing of the copy is shared with book.
+func cloneBook(book *domain.Book) *domain.Book {
+	clone := *book
+	clone.Publisher = nil
+
+	if book.ISBN13 != nil {
+		isbn13 := *book.ISBN13
+		clone.ISBN13 = &isbn13
+	}
+
+	if book.ISBN10 != nil {
+		isbn10 := *book.ISBN10
+		clone.ISBN10 = &isbn10
+	}
+
+	if book.PublisherID != nil {
+		publisherID := *book.PublisherID
+		clone.PublisherID = &publisherID
+	}
+
+	clone.Contributors = make([]*domain.BookContributor, 0, len(book.Contributors))
+	for _, contributor := range book.Contributors {
+		contributor := *contributor
+		clone.Contributors = append(clone.Contributors, &contributor)
+	}
+
+	clone.Categories = make([]*domain.Category, 0, len(book.Categories))
+	for _, category := range book.Categories {
+		category := *category
+		clone.Categories = append(clone.Categories, &category)
+	}
+
+	clone.Tags = make([]*domain.Tag, 0, len(book.Tags))
+	for _, tag := range book.Tags {
+		tag := *tag
+		clone.Tags = append(clone.Tags, &tag)
+	}
+
+	clone.Prices = make([]*domain.BookPrice, 0, len(book.Prices))
+	for _, price := range book.Prices {
+		price := *price
+		clone.Prices = append(clone.Prices, &price)
+	}
+
+	return &clone
+}
+
+// load copies a stored book the way the GORM repository loads it, associations in their order and
+// the primary author set.
+func load(book *domain.Book) *domain.Book {
+	loaded := cloneBook(book)
+
+	sort.SliceStable(loaded.Contributors, func(i, j int) bool {
+		return loaded.Contributors[i].Position < loaded.Contributors[j].Position
+	})
+	sort.SliceStable(loaded.Categories, func(i, j int) bool { return loaded.Categories[i].Name < loaded.Categories[j].Name })
+	sort.SliceStable(loaded.Tags, func(i, j int) bool { return loaded.Tags[i].Name < loaded.Tags[j].Name })
+	sort.SliceStable(loaded.Prices, func(i, j int) bool { return loaded.Prices[i].Currency < loaded.Prices[j].Currency })
+
+	loaded.AuthorID = loaded.PrimaryAuthorID()
+
+	return loaded
+}
+
+func (r *BookRepository) find(match func(book *domain.Book) bool) []*domain.Book {
+	books := []*domain.Book{}
+	for _, id := range sortedIDs(r.store.books.rows) {
+		if book := r.store.books.rows[id]; match(book) {
+			books = append(books, load(book))
+		}
+	}
+
+	return books
+}
+
+func (r *BookRepository) getBy(match func(book *domain.Book) bool) *domain.Book {
+	if books := r.find(match); len(books) > 0 {
+		return books[0]
+	}
+
+	return nil
+}
+
+// checkUnique fails like the unique indexes of books when another book has the identifiers of book.
+func (r *BookRepository) checkUnique(book *domain.Book) error {
+	for _, other := range r.store.books.rows {
+		if other.ID == book.ID {
+			continue
+		}
+
+		switch {
+		case book.ISBN13 != nil && other.ISBN13 != nil && *book.ISBN13 == *other.ISBN13:
+			return fmt.Errorf("duplicate key value violates unique constraint %q", "uq_books_isbn13")
+		case book.SKU == other.SKU:
+			return fmt.Errorf("duplicate key value violates unique constraint %q", "uq_books_sku")
+		case book.Barcode == other.Barcode:
+			return fmt.Errorf("duplicate key value violates unique constraint %q", "uq_books_barcode")
+		}
+	}
+
+	return nil
+}
+
+func hasAuthor(book *domain.Book, authorID int) bool {
+	for _, contributor := range book.Contributors {
+		if contributor.AuthorID == authorID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *BookRepository) List(ctx context.Context, filter *domain.BookFilter) ([]*domain.Book, int64, error) {
+	defer r.lock()()
+
+	books := r.find(func(book *domain.Book) bool { return matchFilter(book, filter) })
+	total := int64(len(books))
+
+	books = books[min(filter.Offset(), len(books)):]
+	if filter.Limit > 0 {
+		books = books[:min(filter.Limit, len(books))]
+	}
+
+	return books, total, nil
+}
+
+// matchFilter applies every set field of the filter, tags must all be present on a book.
+func matchFilter(book *domain.Book, filter *domain.BookFilter) bool {
+	if filter.Query != "" {
+		query := strings.ToLower(filter.Query)
+		if !strings.Contains(strings.ToLower(book.Title), query) && !strings.Contains(strings.ToLower(book.BookName), query) {
+			return false
+		}
+	}
+
+	if filter.AuthorID != 0 && !hasAuthor(book, filter.AuthorID) {
+		return false
+	}
+
+	if filter.PublisherID != 0 && (book.PublisherID == nil || *book.PublisherID != filter.PublisherID) {
+		return false
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		found := false
+		for _, category := range book.Categories {
+			for _, id := range filter.CategoryIDs {
+				found = found || category.ID == id
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if len(filter.Tags) > 0 {
+		matched := 0
+		for _, tag := range book.Tags {
+			for _, name := range filter.Tags {
+				if tag.Name == name {
+					matched++
+					break
+				}
+			}
+		}
+
+		if matched != len(filter.Tags) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *BookRepository) GetLastBook(ctx context.Context) (*domain.Book, error) {
+	defer r.lock()()
+
+	var last *domain.Book
+	for _, id := range sortedIDs(r.store.books.rows) {
+		if book := r.store.books.rows[id]; last == nil || book.CreatedAt.After(last.CreatedAt) {
+			last = book
+		}
+	}
+
+	if last == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return load(last), nil
+}
+
+func (r *BookRepository) GetListBookByAuthorID(ctx context.Context, authorID int) ([]*domain.Book, error) {
+	defer r.lock()()
+
+	return r.find(func(book *domain.Book) bool { return hasAuthor(book, authorID) }), nil
+}
+
+func (r *BookRepository) DeleteBookByAuthorID(ctx context.Context, authorID int, bookID int) error {
+	defer r.lock()()
+
+	if book, ok := r.store.books.rows[bookID]; ok && hasAuthor(book, authorID) {
+		remove(ctx, r.store.books, bookID)
+	}
+
+	return nil
+}
+
+func (r *BookRepository) Delete(ctx context.Context, id int) error {
+	defer r.lock()()
+
+	remove(ctx, r.store.books, id)
+
+	return nil
+}
+
+func (r *BookRepository) GetByID(ctx context.Context, id int) (*domain.Book, error) {
+	defer r.lock()()
+
+	book, ok := r.store.books.rows[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return load(book), nil
+}
+
+func (r *BookRepository) GetByIDs(ctx context.Context, ids []int) ([]*domain.Book, error) {
+	defer r.lock()()
+
+	wanted := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+
+	return r.find(func(book *domain.Book) bool { return wanted[book.ID] }), nil
+}
+
+func (r *BookRepository) GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	defer r.lock()()
+
+	return r.getBy(func(book *domain.Book) bool { return book.ISBN13 != nil && *book.ISBN13 == isbn13 }), nil
+}
+
+func (r *BookRepository) GetBySKU(ctx context.Context, sku string) (*domain.Book, error) {
+	defer r.lock()()
+
+	return r.getBy(func(book *domain.Book) bool { return book.SKU == sku }), nil
+}
+
+func (r *BookRepository) GetByBarcode(ctx context.Context, barcode string) (*domain.Book, error) {
+	defer r.lock()()
+
+	return r.getBy(func(book *domain.Book) bool { return book.Barcode == barcode }), nil
+}
+
+// GetByLocation returns the books shelved at location, without their associations.
+func (r *BookRepository) GetByLocation(ctx context.Context, location string) ([]*domain.Book, error) {
+	defer r.lock()()
+
+	books := r.find(func(book *domain.Book) bool { return book.Location == location })
+	for _, book := range books {
+		book.AuthorID = 0
+		book.Contributors, book.Categories, book.Tags, book.Prices = nil, nil, nil, nil
+	}
+
+	return books, nil
+}
+
+// Update writes only the given columns when they are provided, otherwise every non-zero field of req.
+// Contributors are never written here, see ReplaceContributors.
+func (r *BookRepository) Update(ctx context.Context, req *domain.Book, columns ...string) error {
+	defer r.lock()()
+
+	current, ok := r.store.books.rows[req.ID]
+	if !ok {
+		return nil
+	}
+
+	book := cloneBook(current)
+	if err := update(ctx, book, req, columns); err != nil {
+		return err
+	}
+
+	if err := r.checkUnique(book); err != nil {
+		return err
+	}
+
+	put(ctx, r.store.books, book.ID, book)
+
+	return nil
+}
+
+// Create inserts the book with its contributors and links it to the given categories and tags.
+func (r *BookRepository) Create(ctx context.Context, req *domain.Book) error {
+	defer r.lock()()
+
+	id, err := r.store.books.newID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	req.ID = id
+	if req.CreatedAt.IsZero() {
+		req.CreatedAt = time.Now()
+	}
+
+	for _, contributor := range req.Contributors {
+		contributor.BookID = req.ID
+	}
+
+	book := cloneBook(req)
+	book.Stock, book.Reserved, book.Prices = 0, 0, nil
+
+	if err := r.checkUnique(book); err != nil {
+		return err
+	}
+
+	put(ctx, r.store.books, book.ID, book)
+
+	return nil
+}
+
+// replace stores the book of bookID after change, inserting links of a book that does not exist
+// fails like its foreign key.
+func (r *BookRepository) replace(ctx context.Context, bookID int, links int, change func(book *domain.Book)) error {
+	defer r.lock()()
+
+	current, ok := r.store.books.rows[bookID]
+	if !ok {
+		if links > 0 {
+			return fmt.Errorf("book %d does not exist", bookID)
+		}
+
+		return nil
+	}
+
+	book := cloneBook(current)
+	change(book)
+
+	put(ctx, r.store.books, book.ID, cloneBook(book))
+
+	return nil
+}
+
+func (r *BookRepository) ReplaceContributors(ctx context.Context, bookID int, contributors []*domain.BookContributor) error {
+	for _, contributor := range contributors {
+		contributor.BookID = bookID
+	}
+
+	return r.replace(ctx, bookID, len(contributors), func(book *domain.Book) {
+		book.Contributors = contributors
+	})
+}
+
+func (r *BookRepository) ReplaceCategories(ctx context.Context, bookID int, categories []*domain.Category) error {
+	return r.replace(ctx, bookID, len(categories), func(book *domain.Book) {
+		book.Categories = nil
+
+		linked := make(map[int]bool, len(categories))
+		for _, category := range categories {
+			if !linked[category.ID] {
+				linked[category.ID] = true
+				book.Categories = append(book.Categories, category)
+			}
+		}
+	})
+}
+
+func (r *BookRepository) ReplaceTags(ctx context.Context, bookID int, tags []*domain.Tag) error {
+	return r.replace(ctx, bookID, len(tags), func(book *domain.Book) {
+		book.Tags = nil
+
+		linked := make(map[int]bool, len(tags))
+		for _, tag := range tags {
+			if !linked[tag.ID] {
+				linked[tag.ID] = true
+				book.Tags = append(book.Tags, tag)
+			}
+		}
+	})
+}
+
+func (r *BookRepository) ReplacePrices(ctx context.Context, bookID int, prices []*domain.BookPrice) error {
+	for _, price := range prices {
+		price.BookID = bookID
+	}
+
+	return r.replace(ctx, bookID, len(prices), func(book *domain.Book) {
+		book.Prices = prices
+	})
+}
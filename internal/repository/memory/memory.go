@@ -0,0 +1,246 @@
+// Package memory keeps users, authors and books in memory for tests and demos. It follows the
+// behaviour of the GORM repositories, see repositorytest for the contract both of them pass.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"gorm.io/gorm/schema"
+)
+
+// store holds the rows of every table. Rows are never changed in place, a write stores a new copy,
+// so the previous row is what a rollback puts back.
+type store struct {
+	mu sync.Mutex
+
+	users   *table[domain.User]
+	authors *table[domain.Author]
+	books   *table[domain.Book]
+}
+
+type table[T any] struct {
+	rows map[int]*T
+	// lastID is the sequence of the table, like a database sequence it is not rolled back
+	lastID int
+}
+
+func newTable[T any]() *table[T] {
+	return &table[T]{rows: make(map[int]*T)}
+}
+
+// newID returns id for a new row, the next of the sequence when id is zero. Like an insert with an
+// explicit primary key it fails when the id is taken.
+func (t *table[T]) newID(id int) (int, error) {
+	if id == 0 {
+		t.lastID++
+		return t.lastID, nil
+	}
+
+	if _, ok := t.rows[id]; ok {
+		return 0, fmt.Errorf("duplicate key value violates unique constraint, id %d exists", id)
+	}
+
+	t.lastID = max(t.lastID, id)
+	return id, nil
+}
+
+// sortedIDs returns the ids of rows in ascending order, the order queries return rows in.
+func sortedIDs[T any](rows map[int]*T) []int {
+	ids := make([]int, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+
+	sort.Ints(ids)
+	return ids
+}
+
+// put stores row under id, inside a transaction the previous row is restored on rollback.
+func put[T any](ctx context.Context, t *table[T], id int, row *T) {
+	prev, ok := t.rows[id]
+	t.rows[id] = row
+
+	onRollback(ctx, func() {
+		if ok {
+			t.rows[id] = prev
+			return
+		}
+
+		delete(t.rows, id)
+	})
+}
+
+// remove deletes the row of id, inside a transaction it is restored on rollback.
+func remove[T any](ctx context.Context, t *table[T], id int) {
+	prev, ok := t.rows[id]
+	if !ok {
+		return
+	}
+
+	delete(t.rows, id)
+
+	onRollback(ctx, func() {
+		t.rows[id] = prev
+	})
+}
+
+// models caches the GORM schema of the models, it maps their column names to fields.
+var models sync.Map
+
+// update writes the given columns of src to dst, or every non-zero column when none are given.
+// Read only columns and the primary key are never written.
+func update[T any](ctx context.Context, dst, src *T, names []string) error {
+	model, err := schema.Parse(src, &models, schema.NamingStrategy{})
+	if err != nil {
+		return err
+	}
+
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		if model.LookUpField(name) == nil {
+			return fmt.Errorf("unknown column %q of %s", name, model.Table)
+		}
+
+		selected[name] = true
+	}
+
+	from, to := reflect.ValueOf(src).Elem(), reflect.ValueOf(dst).Elem()
+	for _, field := range model.Fields {
+		if field.DBName == "" || field.PrimaryKey || !field.Updatable {
+			continue
+		}
+
+		value, zero := field.ValueOf(ctx, from)
+		if len(names) > 0 && !selected[field.DBName] || len(names) == 0 && zero {
+			continue
+		}
+
+		if err := field.Set(ctx, to, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Repository is an in-memory repository.RepositoryImpl. Only users, authors, books and
+// transactions are kept, the getters of the other repositories panic.
+type Repository struct {
+	store *store
+}
+
+func NewRepository() repository.RepositoryImpl {
+	return &Repository{
+		store: &store{
+			users:   newTable[domain.User](),
+			authors: newTable[domain.Author](),
+			books:   newTable[domain.Book](),
+		},
+	}
+}
+
+func (r *Repository) GetUserRepo() repository.UserRepositoryImpl {
+	return &UserRepository{TransactionRepository{store: r.store}}
+}
+
+func (r *Repository) GetBookRepo() repository.BookRepositoryImpl {
+	return &BookRepository{TransactionRepository{store: r.store}}
+}
+
+func (r *Repository) GetAuthorRepo() repository.AuthorRepositoryImpl {
+	return &AuthorRepository{TransactionRepository{store: r.store}}
+}
+
+func (r *Repository) GetTransactionRepo() repository.TransactionRepositoryImpl {
+	return &TransactionRepository{store: r.store}
+}
+
+func unsupported(name string) {
+	panic(fmt.Sprintf("memory: the %s repository is not kept in memory", name))
+}
+
+func (r *Repository) GetIdempotencyRepo() repository.IdempotencyRepositoryImpl {
+	unsupported("idempotency")
+	return nil
+}
+
+func (r *Repository) GetPublisherRepo() repository.PublisherRepositoryImpl {
+	unsupported("publisher")
+	return nil
+}
+
+func (r *Repository) GetCategoryRepo() repository.CategoryRepositoryImpl {
+	unsupported("category")
+	return nil
+}
+
+func (r *Repository) GetTagRepo() repository.TagRepositoryImpl {
+	unsupported("tag")
+	return nil
+}
+
+func (r *Repository) GetPriceRepo() repository.PriceRepositoryImpl {
+	unsupported("price")
+	return nil
+}
+
+func (r *Repository) GetPromotionRepo() repository.PromotionRepositoryImpl {
+	unsupported("promotion")
+	return nil
+}
+
+func (r *Repository) GetSupplierRepo() repository.SupplierRepositoryImpl {
+	unsupported("supplier")
+	return nil
+}
+
+func (r *Repository) GetPurchaseOrderRepo() repository.PurchaseOrderRepositoryImpl {
+	unsupported("purchase order")
+	return nil
+}
+
+func (r *Repository) GetStockRepo() repository.StockRepositoryImpl {
+	unsupported("stock")
+	return nil
+}
+
+func (r *Repository) GetOrderRepo() repository.OrderRepositoryImpl {
+	unsupported("order")
+	return nil
+}
+
+func (r *Repository) GetRMARepo() repository.RMARepositoryImpl {
+	unsupported("rma")
+	return nil
+}
+
+func (r *Repository) GetCycleCountRepo() repository.CycleCountRepositoryImpl {
+	unsupported("cycle count")
+	return nil
+}
+
+func (r *Repository) GetStockAlertRepo() repository.StockAlertRepositoryImpl {
+	unsupported("stock alert")
+	return nil
+}
+
+func (r *Repository) GetValuationRepo() repository.ValuationRepositoryImpl {
+	unsupported("valuation")
+	return nil
+}
+
+func (r *Repository) GetAnalyticsRepo() repository.AnalyticsRepositoryImpl {
+	unsupported("analytics")
+	return nil
+}
+
+func (r *Repository) GetForecastRepo() repository.ForecastRepositoryImpl {
+	unsupported("forecast")
+	return nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/memory"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/repositorytest"
+)
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.RepositoryImpl {
+		return memory.NewRepository()
+	})
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+)
+
+type txKey struct{}
+
+// transaction keeps how to undo the writes made in it, newest last.
+type transaction struct {
+	undo []func()
+}
+
+// onRollback adds undo to the transaction in ctx, outside a transaction a write is final.
+// It is called with the store locked.
+func onRollback(ctx context.Context, undo func()) {
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok {
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+type TransactionRepository struct {
+	store *store
+}
+
+// WithTransaction undoes the writes of fn when it returns an error or panics, the panic is passed
+// on after the rollback. Propagation is kept as in the GORM repository, a required transaction
+// inside another joins it, a nested one undoes only its own writes and a new one keeps its writes
+// when the outer rolls back. Writes are visible to other callers before the commit, there is no
+// isolation, so the isolation level and attempts of opts are ignored.
+func (r *TransactionRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+	options := repository.NewTxOptions(opts...)
+
+	if tx, ok := ctx.Value(txKey{}).(*transaction); ok {
+		switch options.Propagation {
+		case repository.PropagationRequired:
+			return fn(ctx)
+		case repository.PropagationNested:
+			return r.savepoint(ctx, tx, fn)
+		}
+	}
+
+	tx := &transaction{}
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.rollback(tx, 0)
+			panic(p)
+		}
+	}()
+
+	hooksCtx, hooks := repository.WithCommitHooks(ctx)
+	if err := fn(context.WithValue(hooksCtx, txKey{}, tx)); err != nil {
+		r.rollback(tx, 0)
+		return fmt.Errorf("error db %w", err)
+	}
+
+	hooks.Run()
+	return nil
+}
+
+// savepoint undoes the writes fn adds to tx when it fails, and drops the functions it deferred to
+// the commit.
+func (r *TransactionRepository) savepoint(ctx context.Context, tx *transaction, fn func(txCtx context.Context) error) error {
+	r.store.mu.Lock()
+	mark := len(tx.undo)
+	r.store.mu.Unlock()
+
+	hooks := repository.GetCommitHooks(ctx)
+	hooksMark := hooks.Mark()
+
+	defer func() {
+		if p := recover(); p != nil {
+			r.rollback(tx, mark)
+			hooks.Discard(hooksMark)
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx); err != nil {
+		r.rollback(tx, mark)
+		hooks.Discard(hooksMark)
+		return err
+	}
+
+	return nil
+}
+
+// rollback undoes the writes of tx after the first mark ones.
+func (r *TransactionRepository) rollback(tx *transaction, mark int) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for i := len(tx.undo) - 1; i >= mark; i-- {
+		tx.undo[i]()
+	}
+
+	tx.undo = tx.undo[:mark]
+}
+
+// lock locks the store for one repository call, it returns the unlock.
+func (r *TransactionRepository) lock() func() {
+	r.store.mu.Lock()
+	return r.store.mu.Unlock
+}
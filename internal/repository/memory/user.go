@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"strings"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	TransactionRepository
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, error) {
+	defer r.lock()()
+
+	user, ok := r.store.users.rows[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	found := *user
+	return &found, nil
+}
+
+func (r *UserRepository) GetByUsernameOrEmail(ctx context.Context, req *domain.GetByUsernameOrEmail) (*domain.User, error) {
+	defer r.lock()()
+
+	for _, id := range sortedIDs(r.store.users.rows) {
+		user := r.store.users.rows[id]
+		if strings.EqualFold(user.Username, req.Username) || strings.EqualFold(user.Email, req.Email) {
+			found := *user
+			return &found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *UserRepository) RegisterUser(ctx context.Context, req *domain.User) error {
+	defer r.lock()()
+
+	id, err := r.store.users.newID(req.ID)
+	if err != nil {
+		return err
+	}
+
+	req.ID = id
+
+	user := *req
+	put(ctx, r.store.users, user.ID, &user)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Order) error
+	GetByID(ctx context.Context, id int) (*domain.Order, error)
+	GetByIDForUpdate(ctx context.Context, id int) (*domain.Order, error)
+	List(ctx context.Context, filter *domain.OrderFilter) ([]*domain.Order, error)
+	Update(ctx context.Context, req *domain.Order, columns ...string) error
+}
+
+type OrderRepository struct {
+	TransactionRepository
+}
+
+func NewOrderRepository(db *gorm.DB) OrderRepositoryImpl {
+	return &OrderRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *OrderRepository) orders(ctx context.Context) *gorm.DB {
+	return r.tx(ctx).Model(&domain.Order{}).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
+}
+
+// Create inserts the order with its lines.
+func (r *OrderRepository) Create(ctx context.Context, req *domain.Order) error {
+	return r.tx(ctx).Model(&domain.Order{}).Create(&req).Error
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, error) {
+	return r.getBy(r.orders(ctx), id)
+}
+
+// GetByIDForUpdate locks the order until the transaction in ctx ends so transitions happen one at a time.
+func (r *OrderRepository) GetByIDForUpdate(ctx context.Context, id int) (*domain.Order, error) {
+	return r.getBy(r.orders(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *OrderRepository) getBy(db *gorm.DB, id int) (*domain.Order, error) {
+	var order domain.Order
+
+	db = db.Where("id = ?", id).First(&order)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *OrderRepository) List(ctx context.Context, filter *domain.OrderFilter) ([]*domain.Order, error) {
+	db := r.orders(ctx)
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if filter.CustomerEmail != "" {
+		db = db.Where("LOWER(customer_email) = LOWER(?)", filter.CustomerEmail)
+	}
+
+	var orders []*domain.Order
+	if err := db.Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *OrderRepository) Update(ctx context.Context, req *domain.Order, columns ...string) error {
+	db := r.tx(ctx).Omit("id", clause.Associations).Model(&domain.Order{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
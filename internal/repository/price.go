@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PriceRepositoryImpl interface {
+	CreateHistory(ctx context.Context, changes []*domain.PriceChange) error
+	GetHistory(ctx context.Context, bookID int) ([]*domain.PriceChange, error)
+	CreateSchedule(ctx context.Context, req *domain.ScheduledPrice) error
+	GetSchedule(ctx context.Context, id int) (*domain.ScheduledPrice, error)
+	GetSchedules(ctx context.Context, bookID int) ([]*domain.ScheduledPrice, error)
+	ClaimDueSchedule(ctx context.Context, now time.Time) (*domain.ScheduledPrice, error)
+	UpdateSchedule(ctx context.Context, req *domain.ScheduledPrice, columns ...string) error
+	FailSchedule(ctx context.Context, id int, reason string) error
+}
+
+type PriceRepository struct {
+	TransactionRepository
+}
+
+func NewPriceRepository(db *gorm.DB) PriceRepositoryImpl {
+	return &PriceRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *PriceRepository) CreateHistory(ctx context.Context, changes []*domain.PriceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return r.tx(ctx).Model(&domain.PriceChange{}).Create(&changes).Error
+}
+
+func (r *PriceRepository) GetHistory(ctx context.Context, bookID int) ([]*domain.PriceChange, error) {
+	var changes []*domain.PriceChange
+
+	db := r.tx(ctx).Model(&domain.PriceChange{}).Where("book_id = ?", bookID).Order("changed_at, id").Find(&changes)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (r *PriceRepository) CreateSchedule(ctx context.Context, req *domain.ScheduledPrice) error {
+	return r.tx(ctx).Model(&domain.ScheduledPrice{}).Create(&req).Error
+}
+
+func (r *PriceRepository) GetSchedule(ctx context.Context, id int) (*domain.ScheduledPrice, error) {
+	var schedule domain.ScheduledPrice
+
+	db := r.tx(ctx).Model(&domain.ScheduledPrice{}).Where("id = ?", id).First(&schedule)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *PriceRepository) GetSchedules(ctx context.Context, bookID int) ([]*domain.ScheduledPrice, error) {
+	var schedules []*domain.ScheduledPrice
+
+	db := r.tx(ctx).Model(&domain.ScheduledPrice{}).Where("book_id = ?", bookID).Order("effective_at, id").Find(&schedules)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// ClaimDueSchedule locks the oldest pending schedule that is due, rows locked by another
+// scheduler are skipped so several instances can run side by side. It must run in a transaction.
+func (r *PriceRepository) ClaimDueSchedule(ctx context.Context, now time.Time) (*domain.ScheduledPrice, error) {
+	var schedule domain.ScheduledPrice
+
+	db := r.tx(ctx).Model(&domain.ScheduledPrice{}).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND effective_at <= ?", domain.ScheduledPricePending, now).
+		Order("effective_at, id").
+		First(&schedule)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+func (r *PriceRepository) UpdateSchedule(ctx context.Context, req *domain.ScheduledPrice, columns ...string) error {
+	db := r.tx(ctx).Omit("id").Model(&domain.ScheduledPrice{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
+
+// FailSchedule marks a schedule that is still pending as failed for reason.
+func (r *PriceRepository) FailSchedule(ctx context.Context, id int, reason string) error {
+	return r.tx(ctx).Model(&domain.ScheduledPrice{}).
+		Where("id = ? AND status = ?", id, domain.ScheduledPricePending).
+		Updates(map[string]interface{}{"status": domain.ScheduledPriceFailed, "failure_reason": reason}).Error
+}
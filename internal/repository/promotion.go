@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type PromotionRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Promotion) error
+	GetByID(ctx context.Context, id int) (*domain.Promotion, error)
+	List(ctx context.Context) ([]*domain.Promotion, error)
+	ListValidAt(ctx context.Context, at time.Time) ([]*domain.Promotion, error)
+	Update(ctx context.Context, req *domain.Promotion) error
+	Delete(ctx context.Context, id int) error
+}
+
+type PromotionRepository struct {
+	TransactionRepository
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepositoryImpl {
+	return &PromotionRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *PromotionRepository) Create(ctx context.Context, req *domain.Promotion) error {
+	return r.tx(ctx).Model(&domain.Promotion{}).Create(&req).Error
+}
+
+func (r *PromotionRepository) GetByID(ctx context.Context, id int) (*domain.Promotion, error) {
+	var promotion domain.Promotion
+	db := r.tx(ctx).Model(&domain.Promotion{}).Where("id = ?", id).First(&promotion)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &promotion, nil
+}
+
+func (r *PromotionRepository) List(ctx context.Context) ([]*domain.Promotion, error) {
+	var promotions []*domain.Promotion
+	if err := r.tx(ctx).Model(&domain.Promotion{}).Order("priority DESC, id").Find(&promotions).Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+// ListValidAt returns the active promotions whose validity window contains at, in the order they apply.
+func (r *PromotionRepository) ListValidAt(ctx context.Context, at time.Time) ([]*domain.Promotion, error) {
+	var promotions []*domain.Promotion
+
+	db := r.tx(ctx).Model(&domain.Promotion{}).
+		Where("active AND starts_at <= ? AND (ends_at IS NULL OR ends_at > ?)", at, at).
+		Order("priority DESC, id").
+		Find(&promotions)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return promotions, nil
+}
+
+func (r *PromotionRepository) Update(ctx context.Context, req *domain.Promotion) error {
+	return r.tx(ctx).Model(&domain.Promotion{}).Where("id = ?", req.ID).
+		Select("name", "type", "scope", "scope_id", "percentage", "discount_amount", "discount_currency",
+			"buy_quantity", "pay_quantity", "starts_at", "ends_at", "priority", "stackable", "active", "updated_at").
+		Updates(req).Error
+}
+
+func (r *PromotionRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.Promotion{}).Error
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type PublisherRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Publisher) error
+	GetByID(ctx context.Context, id int) (*domain.Publisher, error)
+	GetByName(ctx context.Context, name string) (*domain.Publisher, error)
+	List(ctx context.Context) ([]*domain.Publisher, error)
+	GetBookIDs(ctx context.Context, id int) ([]int, error)
+	Update(ctx context.Context, req *domain.Publisher) error
+	Delete(ctx context.Context, id int) error
+}
+
+type PublisherRepository struct {
+	TransactionRepository
+}
+
+func NewPublisherRepository(db *gorm.DB) PublisherRepositoryImpl {
+	return &PublisherRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *PublisherRepository) Create(ctx context.Context, req *domain.Publisher) error {
+	return r.tx(ctx).Model(&domain.Publisher{}).Create(&req).Error
+}
+
+func (r *PublisherRepository) GetByID(ctx context.Context, id int) (*domain.Publisher, error) {
+	return r.getBy(ctx, "id = ?", id)
+}
+
+func (r *PublisherRepository) GetByName(ctx context.Context, name string) (*domain.Publisher, error) {
+	return r.getBy(ctx, "LOWER(name) = LOWER(?)", name)
+}
+
+func (r *PublisherRepository) getBy(ctx context.Context, query string, value interface{}) (*domain.Publisher, error) {
+	var publisher domain.Publisher
+	db := r.tx(ctx).Model(&domain.Publisher{}).Where(query, value).First(&publisher)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &publisher, nil
+}
+
+func (r *PublisherRepository) List(ctx context.Context) ([]*domain.Publisher, error) {
+	var publishers []*domain.Publisher
+	if err := r.tx(ctx).Model(&domain.Publisher{}).Order("name").Find(&publishers).Error; err != nil {
+		return nil, err
+	}
+
+	return publishers, nil
+}
+
+// GetBookIDs returns the ids of the books the publisher published.
+func (r *PublisherRepository) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	var ids []int
+	if err := r.tx(ctx).Model(&domain.Book{}).Where("publisher_id = ?", id).Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *PublisherRepository) Update(ctx context.Context, req *domain.Publisher) error {
+	return r.tx(ctx).Model(&domain.Publisher{}).Where("id = ?", req.ID).Select("name", "website").Updates(req).Error
+}
+
+func (r *PublisherRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.Publisher{}).Error
+}
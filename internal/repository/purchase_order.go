@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseOrderRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.PurchaseOrder) error
+	GetByID(ctx context.Context, id int) (*domain.PurchaseOrder, error)
+	GetByIDForUpdate(ctx context.Context, id int) (*domain.PurchaseOrder, error)
+	List(ctx context.Context, filter *domain.PurchaseOrderFilter) ([]*domain.PurchaseOrder, error)
+	Update(ctx context.Context, req *domain.PurchaseOrder, columns ...string) error
+	ReplaceLines(ctx context.Context, orderID int, lines []*domain.PurchaseOrderLine) error
+	UpdateLineReceived(ctx context.Context, line *domain.PurchaseOrderLine) error
+	CreateReceipt(ctx context.Context, req *domain.GoodsReceipt) error
+	ExistsForSupplier(ctx context.Context, supplierID int) (bool, error)
+	LastUnitCost(ctx context.Context, supplierID, bookID int) (*money.Money, error)
+}
+
+type PurchaseOrderRepository struct {
+	TransactionRepository
+}
+
+func NewPurchaseOrderRepository(db *gorm.DB) PurchaseOrderRepositoryImpl {
+	return &PurchaseOrderRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// orders loads the supplier, the lines and every goods receipt with each order.
+func (r *PurchaseOrderRepository) orders(ctx context.Context) *gorm.DB {
+	return r.tx(ctx).Model(&domain.PurchaseOrder{}).
+		Preload("Supplier").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Preload("Receipts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("received_at, id")
+		}).
+		Preload("Receipts.Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
+}
+
+// Create inserts the order with its lines.
+func (r *PurchaseOrderRepository) Create(ctx context.Context, req *domain.PurchaseOrder) error {
+	return r.tx(ctx).Model(&domain.PurchaseOrder{}).Omit("Supplier", "Receipts").Create(&req).Error
+}
+
+func (r *PurchaseOrderRepository) GetByID(ctx context.Context, id int) (*domain.PurchaseOrder, error) {
+	return r.getBy(r.orders(ctx), id)
+}
+
+// GetByIDForUpdate locks the order until the transaction in ctx ends so receipts are posted one at a time.
+func (r *PurchaseOrderRepository) GetByIDForUpdate(ctx context.Context, id int) (*domain.PurchaseOrder, error) {
+	return r.getBy(r.orders(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *PurchaseOrderRepository) getBy(db *gorm.DB, id int) (*domain.PurchaseOrder, error) {
+	var order domain.PurchaseOrder
+
+	db = db.Where("id = ?", id).First(&order)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+func (r *PurchaseOrderRepository) List(ctx context.Context, filter *domain.PurchaseOrderFilter) ([]*domain.PurchaseOrder, error) {
+	db := r.orders(ctx)
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if filter.SupplierID != 0 {
+		db = db.Where("supplier_id = ?", filter.SupplierID)
+	}
+
+	var orders []*domain.PurchaseOrder
+	if err := db.Order("id DESC").Find(&orders).Error; err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (r *PurchaseOrderRepository) Update(ctx context.Context, req *domain.PurchaseOrder, columns ...string) error {
+	db := r.tx(ctx).Omit("id", clause.Associations).Model(&domain.PurchaseOrder{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
+
+func (r *PurchaseOrderRepository) ReplaceLines(ctx context.Context, orderID int, lines []*domain.PurchaseOrderLine) error {
+	return r.tx(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("purchase_order_id = ?", orderID).Delete(&domain.PurchaseOrderLine{}).Error; err != nil {
+			return err
+		}
+
+		for _, line := range lines {
+			line.ID, line.PurchaseOrderID = 0, orderID
+		}
+
+		return tx.Create(&lines).Error
+	})
+}
+
+func (r *PurchaseOrderRepository) UpdateLineReceived(ctx context.Context, line *domain.PurchaseOrderLine) error {
+	return r.tx(ctx).Model(&domain.PurchaseOrderLine{}).Where("id = ?", line.ID).
+		UpdateColumn("quantity_received", line.QuantityReceived).Error
+}
+
+// CreateReceipt inserts the goods receipt with its lines.
+func (r *PurchaseOrderRepository) CreateReceipt(ctx context.Context, req *domain.GoodsReceipt) error {
+	return r.tx(ctx).Model(&domain.GoodsReceipt{}).Create(&req).Error
+}
+
+func (r *PurchaseOrderRepository) ExistsForSupplier(ctx context.Context, supplierID int) (bool, error) {
+	var count int64
+	if err := r.tx(ctx).Model(&domain.PurchaseOrder{}).Where("supplier_id = ?", supplierID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// LastUnitCost returns the unit cost of the book on the newest order that was not cancelled with the
+// supplier, nil when the book was never ordered from them.
+func (r *PurchaseOrderRepository) LastUnitCost(ctx context.Context, supplierID, bookID int) (*money.Money, error) {
+	var line domain.PurchaseOrderLine
+
+	db := r.tx(ctx).Model(&domain.PurchaseOrderLine{}).
+		Joins("JOIN purchase_orders po ON po.id = purchase_order_lines.purchase_order_id").
+		Where("po.supplier_id = ? AND purchase_order_lines.book_id = ? AND po.status <> ?", supplierID, bookID, domain.PurchaseOrderCancelled).
+		Order("po.created_at DESC, po.id DESC").
+		First(&line)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &line.UnitCost, nil
+}
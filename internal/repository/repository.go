@@ -9,6 +9,22 @@ type RepositoryImpl interface {
 	GetBookRepo() BookRepositoryImpl
 	GetAuthorRepo() AuthorRepositoryImpl
 	GetTransactionRepo() TransactionRepositoryImpl
+	GetIdempotencyRepo() IdempotencyRepositoryImpl
+	GetPublisherRepo() PublisherRepositoryImpl
+	GetCategoryRepo() CategoryRepositoryImpl
+	GetTagRepo() TagRepositoryImpl
+	GetPriceRepo() PriceRepositoryImpl
+	GetPromotionRepo() PromotionRepositoryImpl
+	GetSupplierRepo() SupplierRepositoryImpl
+	GetPurchaseOrderRepo() PurchaseOrderRepositoryImpl
+	GetStockRepo() StockRepositoryImpl
+	GetOrderRepo() OrderRepositoryImpl
+	GetRMARepo() RMARepositoryImpl
+	GetCycleCountRepo() CycleCountRepositoryImpl
+	GetStockAlertRepo() StockAlertRepositoryImpl
+	GetValuationRepo() ValuationRepositoryImpl
+	GetAnalyticsRepo() AnalyticsRepositoryImpl
+	GetForecastRepo() ForecastRepositoryImpl
 }
 
 type Repository struct {
@@ -36,3 +52,67 @@ func (r *Repository) GetAuthorRepo() AuthorRepositoryImpl {
 func (r *Repository) GetTransactionRepo() TransactionRepositoryImpl {
 	return NewTransactionRepository(r.db)
 }
+
+func (r *Repository) GetIdempotencyRepo() IdempotencyRepositoryImpl {
+	return NewIdempotencyRepository(r.db)
+}
+
+func (r *Repository) GetPublisherRepo() PublisherRepositoryImpl {
+	return NewPublisherRepository(r.db)
+}
+
+func (r *Repository) GetCategoryRepo() CategoryRepositoryImpl {
+	return NewCategoryRepository(r.db)
+}
+
+func (r *Repository) GetTagRepo() TagRepositoryImpl {
+	return NewTagRepository(r.db)
+}
+
+func (r *Repository) GetPriceRepo() PriceRepositoryImpl {
+	return NewPriceRepository(r.db)
+}
+
+func (r *Repository) GetPromotionRepo() PromotionRepositoryImpl {
+	return NewPromotionRepository(r.db)
+}
+
+func (r *Repository) GetSupplierRepo() SupplierRepositoryImpl {
+	return NewSupplierRepository(r.db)
+}
+
+func (r *Repository) GetPurchaseOrderRepo() PurchaseOrderRepositoryImpl {
+	return NewPurchaseOrderRepository(r.db)
+}
+
+func (r *Repository) GetStockRepo() StockRepositoryImpl {
+	return NewStockRepository(r.db)
+}
+
+func (r *Repository) GetOrderRepo() OrderRepositoryImpl {
+	return NewOrderRepository(r.db)
+}
+
+func (r *Repository) GetRMARepo() RMARepositoryImpl {
+	return NewRMARepository(r.db)
+}
+
+func (r *Repository) GetCycleCountRepo() CycleCountRepositoryImpl {
+	return NewCycleCountRepository(r.db)
+}
+
+func (r *Repository) GetStockAlertRepo() StockAlertRepositoryImpl {
+	return NewStockAlertRepository(r.db)
+}
+
+func (r *Repository) GetValuationRepo() ValuationRepositoryImpl {
+	return NewValuationRepository(r.db)
+}
+
+func (r *Repository) GetAnalyticsRepo() AnalyticsRepositoryImpl {
+	return NewAnalyticsRepository(r.db)
+}
+
+func (r *Repository) GetForecastRepo() ForecastRepositoryImpl {
+	return NewForecastRepository(r.db)
+}
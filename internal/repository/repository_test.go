@@ -0,0 +1,351 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	migration "github.com/imanudd/inventorySvc-clean-architecture/database"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/repositorytest"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/dialect"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/replica"
+	"github.com/mattn/go-sqlite3"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// newSQLite opens a migrated SQLite database in the test's temporary directory.
+func newSQLite(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "inventorybook.db")+"?_foreign_keys=on"), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{
+			SingularTable: true,
+		},
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rdb, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdb.SetMaxOpenConns(1)
+
+	if _, err := migration.New(&config.MainConfig{DBType: dialect.SQLite}, rdb).Migrate(migration.MIGRATION_TYPE_UP); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestContract(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repository.RepositoryImpl {
+		return repository.NewRepository(newSQLite(t))
+	})
+}
+
+func TestReplicas(t *testing.T) {
+	Convey("Test reads from replicas", t, func() {
+		db := newSQLite(t)
+
+		pool, err := newSQLite(t).DB()
+		So(err, ShouldBeNil)
+
+		resolver := replica.New(time.Hour, &replica.Replica{Name: "replica", DB: pool})
+		defer resolver.Close()
+		So(db.Use(resolver), ShouldBeNil)
+
+		repo := repository.NewRepository(db)
+		ctx := context.Background()
+
+		// the replica never catches up, it shows which reads go to it
+		So(repo.GetAuthorRepo().Create(ctx, &domain.Author{Name: "Frank Herbert", Email: "frank@example.com"}), ShouldBeNil)
+
+		author, err := repo.GetAuthorRepo().GetByName(ctx, "frank herbert")
+		So(err, ShouldBeNil)
+		So(author, ShouldBeNil)
+
+		author, err = repo.GetAuthorRepo().GetByName(repository.ReadYourWrites(ctx), "frank herbert")
+		So(err, ShouldBeNil)
+		So(author, ShouldNotBeNil)
+
+		So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			author, err := repo.GetAuthorRepo().GetByName(txCtx, "frank herbert")
+			So(err, ShouldBeNil)
+			So(author, ShouldNotBeNil)
+			return nil
+		}), ShouldBeNil)
+	})
+}
+
+func TestSQLite(t *testing.T) {
+	Convey("Test repositories on sqlite", t, func() {
+		db := newSQLite(t)
+		repo := repository.NewRepository(db)
+		ctx := context.Background()
+
+		So(repo.GetBookRepo().Create(ctx, &domain.Book{Title: "Dune", BookName: "Dune", SKU: "BK-1", Barcode: "2000000000015"}), ShouldBeNil)
+		So(repo.GetBookRepo().Create(ctx, &domain.Book{Title: "Emma", BookName: "Emma", SKU: "BK-2", Barcode: "2000000000022"}), ShouldBeNil)
+
+		Convey("searches case insensitively", func() {
+			books, total, err := repo.GetBookRepo().List(ctx, &domain.BookFilter{Query: "dUN", Page: 1, Limit: 10})
+			So(err, ShouldBeNil)
+			So(total, ShouldEqual, 1)
+			So(books[0].Title, ShouldEqual, "Dune")
+
+			So(repo.GetAuthorRepo().Create(ctx, &domain.Author{Name: "Frank Herbert", Email: "frank@example.com"}), ShouldBeNil)
+
+			author, err := repo.GetAuthorRepo().GetByName(ctx, "frank herbert")
+			So(err, ShouldBeNil)
+			So(author.Name, ShouldEqual, "Frank Herbert")
+		})
+
+		Convey("skips a second open alert of a book", func() {
+			created, err := repo.GetStockAlertRepo().CreateAlert(ctx, &domain.StockAlert{BookID: 1, Status: domain.StockAlertOpen, CreatedAt: time.Now()})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			created, err = repo.GetStockAlertRepo().CreateAlert(ctx, &domain.StockAlert{BookID: 1, Status: domain.StockAlertOpen, CreatedAt: time.Now()})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeFalse)
+		})
+
+		Convey("finds the books of a publisher, category and tag", func() {
+			publisher := &domain.Publisher{Name: "Chilton"}
+			category := &domain.Category{Name: "Science Fiction"}
+			So(repo.GetPublisherRepo().Create(ctx, publisher), ShouldBeNil)
+			So(repo.GetCategoryRepo().Create(ctx, category), ShouldBeNil)
+
+			tags, err := repo.GetTagRepo().FindOrCreate(ctx, []string{"classic"})
+			So(err, ShouldBeNil)
+
+			So(repo.GetBookRepo().Update(ctx, &domain.Book{ID: 1, PublisherID: &publisher.ID}, "publisher_id"), ShouldBeNil)
+			So(repo.GetBookRepo().ReplaceCategories(ctx, 2, []*domain.Category{category}), ShouldBeNil)
+			So(repo.GetBookRepo().ReplaceTags(ctx, 1, tags), ShouldBeNil)
+			So(repo.GetBookRepo().ReplaceTags(ctx, 2, tags), ShouldBeNil)
+
+			ids, err := repo.GetPublisherRepo().GetBookIDs(ctx, publisher.ID)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{1})
+
+			ids, err = repo.GetCategoryRepo().GetBookIDs(ctx, category.ID)
+			So(err, ShouldBeNil)
+			So(ids, ShouldResemble, []int{2})
+
+			ids, err = repo.GetTagRepo().GetBookIDs(ctx, tags[0].ID)
+			So(err, ShouldBeNil)
+			So(ids, ShouldHaveLength, 2)
+		})
+
+		Convey("fails a schedule only while it is pending", func() {
+			prices := repo.GetPriceRepo()
+
+			pending := &domain.ScheduledPrice{BookID: 1, Price: money.New(500000, "IDR"), EffectiveAt: time.Now(), Status: domain.ScheduledPricePending, CreatedAt: time.Now()}
+			cancelled := &domain.ScheduledPrice{BookID: 1, Price: money.New(500000, "IDR"), EffectiveAt: time.Now(), Status: domain.ScheduledPriceCancelled, CreatedAt: time.Now()}
+			So(prices.CreateSchedule(ctx, pending), ShouldBeNil)
+			So(prices.CreateSchedule(ctx, cancelled), ShouldBeNil)
+
+			So(prices.FailSchedule(ctx, pending.ID, "book not found"), ShouldBeNil)
+			So(prices.FailSchedule(ctx, cancelled.ID, "book not found"), ShouldBeNil)
+
+			schedule, err := prices.GetSchedule(ctx, pending.ID)
+			So(err, ShouldBeNil)
+			So(schedule.Status, ShouldEqual, domain.ScheduledPriceFailed)
+			So(schedule.FailureReason, ShouldEqual, "book not found")
+
+			schedule, err = prices.GetSchedule(ctx, cancelled.ID)
+			So(err, ShouldBeNil)
+			So(schedule.Status, ShouldEqual, domain.ScheduledPriceCancelled)
+			So(schedule.FailureReason, ShouldBeEmpty)
+		})
+
+		Convey("watches books at the reorder point of their location unless they have their own", func() {
+			alerts := repo.GetStockAlertRepo()
+
+			So(db.Exec("UPDATE books SET location = 'A-01', stock = 4").Error, ShouldBeNil)
+			So(alerts.SetLocationReorderPoint(ctx, &domain.LocationReorderPoint{Location: "A-01", ReorderPoint: 5, ReorderQuantity: 10, UpdatedAt: time.Now()}), ShouldBeNil)
+			So(alerts.SetReorderPoint(ctx, &domain.ReorderPoint{BookID: 2, ReorderPoint: 3, ReorderQuantity: 7, UpdatedAt: time.Now()}), ShouldBeNil)
+
+			lows, err := alerts.ListLowStock(ctx)
+			So(err, ShouldBeNil)
+			So(lows, ShouldHaveLength, 1)
+			So(lows[0].BookID, ShouldEqual, 1)
+			So(lows[0].Location, ShouldEqual, "A-01")
+			So(lows[0].ReorderPoint.ReorderPoint, ShouldEqual, 5)
+			So(lows[0].ReorderQuantity, ShouldEqual, 10)
+			So(lows[0].Available, ShouldEqual, 4)
+
+			created, err := alerts.CreateAlert(ctx, &domain.StockAlert{BookID: 1, Status: domain.StockAlertOpen, CreatedAt: time.Now()})
+			So(err, ShouldBeNil)
+			So(created, ShouldBeTrue)
+
+			So(alerts.DeleteLocationReorderPoint(ctx, "A-01"), ShouldBeNil)
+
+			resolved, err := alerts.ResolveRecovered(ctx, time.Now())
+			So(err, ShouldBeNil)
+			So(resolved, ShouldEqual, 1)
+		})
+
+		Convey("rolls the ledger up by day", func() {
+			today := domain.BucketStart(time.Now(), domain.BucketDay)
+			day := func(ago, hour int) time.Time { return today.AddDate(0, 0, -ago).Add(time.Duration(hour) * time.Hour) }
+
+			So(repo.GetStockRepo().Record(ctx, []*domain.StockMovement{
+				{BookID: 1, Type: domain.StockMovementPurchaseReceipt, Quantity: 20, CreatedAt: day(3, 9)},
+				{BookID: 1, Type: domain.StockMovementSaleShipment, Quantity: -2, CreatedAt: day(2, 10)},
+				{BookID: 1, Type: domain.StockMovementSaleShipment, Quantity: -3, CreatedAt: day(2, 23)},
+				{BookID: 1, Type: domain.StockMovementSaleShipment, Quantity: -4, CreatedAt: day(1, 0)},
+				{BookID: 2, Type: domain.StockMovementPurchaseReceipt, Quantity: 5, CreatedAt: day(1, 12)},
+			}), ShouldBeNil)
+
+			demand, err := repo.GetForecastRepo().ListDemand(ctx, day(7, 0), today, 0)
+			So(err, ShouldBeNil)
+			So(demand, ShouldHaveLength, 2)
+			So(domain.DayKey(demand[0].Day), ShouldEqual, domain.DayKey(day(2, 0)))
+			So(demand[0].Quantity, ShouldEqual, 5)
+			So(demand[1].Quantity, ShouldEqual, 4)
+
+			analytics := repo.GetAnalyticsRepo()
+			So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				refresh, err := analytics.LockRefresh(txCtx, domain.AnalyticsRollups)
+				So(err, ShouldBeNil)
+
+				So(analytics.RefreshSales(txCtx, refresh.RefreshedThrough), ShouldBeNil)
+				So(analytics.RefreshStock(txCtx, refresh.RefreshedThrough), ShouldBeNil)
+
+				refresh.RefreshedThrough, refresh.RefreshedAt = today, time.Now()
+				return analytics.SetRefreshed(txCtx, refresh)
+			}), ShouldBeNil)
+
+			opening, err := analytics.OpeningStock(ctx, day(1, 0), 1)
+			So(err, ShouldBeNil)
+			So(opening, ShouldEqual, 15)
+
+			changes, err := analytics.ListStockChanges(ctx, day(3, 0), today, 0)
+			So(err, ShouldBeNil)
+			So(changes, ShouldHaveLength, 3)
+			So(domain.DayKey(changes[2].Day), ShouldEqual, domain.DayKey(day(1, 0)))
+			So(changes[2].Quantity, ShouldEqual, 1)
+
+			movers, err := analytics.SlowMovers(ctx, day(7, 0), today, 10)
+			So(err, ShouldBeNil)
+			So(movers, ShouldHaveLength, 2)
+			So(movers[0].BookID, ShouldEqual, 1)
+			So(movers[0].LastSoldOn, ShouldBeNil)
+		})
+	})
+}
+
+func TestTransaction(t *testing.T) {
+	Convey("Test transactions on sqlite", t, func() {
+		db := newSQLite(t)
+		repo := repository.NewRepository(db)
+		ctx := context.Background()
+
+		authorNamed := func(name string) *domain.Author {
+			author, err := repo.GetAuthorRepo().GetByName(ctx, name)
+			So(err, ShouldBeNil)
+			return author
+		}
+
+		create := func(name string) func(txCtx context.Context) error {
+			return func(txCtx context.Context) error {
+				return repo.GetAuthorRepo().Create(txCtx, &domain.Author{Name: name, Email: name + "@example.com"})
+			}
+		}
+
+		Convey("rolls back when ctx is cancelled before the commit", func() {
+			cancelCtx, cancel := context.WithCancel(ctx)
+
+			err := repo.GetTransactionRepo().WithTransaction(cancelCtx, func(txCtx context.Context) error {
+				if err := create("frank")(txCtx); err != nil {
+					return err
+				}
+
+				cancel()
+				return nil
+			})
+			So(err, ShouldNotBeNil)
+			So(authorNamed("frank"), ShouldBeNil)
+		})
+
+		Convey("commits a new transaction on its own", func() {
+			rdb, err := db.DB()
+			So(err, ShouldBeNil)
+			rdb.SetMaxOpenConns(2)
+
+			err = repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				if err := repo.GetTransactionRepo().WithTransaction(txCtx, create("frank"), repository.WithPropagation(repository.PropagationRequiresNew)); err != nil {
+					return err
+				}
+
+				if err := create("jane")(txCtx); err != nil {
+					return err
+				}
+
+				return errors.New("failed")
+			})
+			So(err, ShouldNotBeNil)
+
+			So(authorNamed("frank"), ShouldNotBeNil)
+			So(authorNamed("jane"), ShouldBeNil)
+		})
+
+		Convey("runs a transaction again when the database is busy", func() {
+			attempts := 0
+			err := repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				attempts++
+				if err := create("frank")(txCtx); err != nil {
+					return err
+				}
+
+				if attempts == 1 {
+					return sqlite3.Error{Code: sqlite3.ErrBusy}
+				}
+
+				return nil
+			}, repository.WithIsolation(sql.LevelSerializable), repository.WithMaxAttempts(3))
+			So(err, ShouldBeNil)
+			So(attempts, ShouldEqual, 2)
+			So(authorNamed("frank"), ShouldNotBeNil)
+		})
+
+		Convey("gives up after the last attempt and does not retry other errors", func() {
+			attempts := 0
+			err := repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				attempts++
+				return sqlite3.Error{Code: sqlite3.ErrBusy}
+			}, repository.WithMaxAttempts(2))
+			So(err, ShouldNotBeNil)
+			So(attempts, ShouldEqual, 2)
+
+			attempts = 0
+			err = repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				attempts++
+				return errors.New("failed")
+			}, repository.WithMaxAttempts(2))
+			So(err, ShouldNotBeNil)
+			So(attempts, ShouldEqual, 1)
+		})
+
+		Convey("does not retry unless the caller opts in", func() {
+			attempts := 0
+			err := repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				attempts++
+				return sqlite3.Error{Code: sqlite3.ErrBusy}
+			})
+			So(err, ShouldNotBeNil)
+			So(attempts, ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,417 @@
+// Package repositorytest holds the behaviour every repository.RepositoryImpl has to show for users,
+// authors, books and transactions. The GORM and the in-memory repositories both run it.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Run runs the contract, newRepo returns an empty repository for every case.
+func Run(t *testing.T, newRepo func(t *testing.T) repository.RepositoryImpl) {
+	Convey("Test repository contract", t, func() {
+		repo := newRepo(t)
+		ctx := context.Background()
+
+		Convey("users", func() { users(ctx, repo) })
+		Convey("authors", func() { authors(ctx, repo) })
+		Convey("books", func() { books(ctx, repo) })
+		Convey("transactions", func() { transactions(ctx, repo) })
+	})
+}
+
+func users(ctx context.Context, repo repository.RepositoryImpl) {
+	user := &domain.User{Username: "Ada", Email: "ada@example.com", Password: "secret"}
+	So(repo.GetUserRepo().RegisterUser(ctx, user), ShouldBeNil)
+	So(user.ID, ShouldNotEqual, 0)
+
+	Convey("finds a user by id", func() {
+		found, err := repo.GetUserRepo().GetByID(ctx, user.ID)
+		So(err, ShouldBeNil)
+		So(found, ShouldResemble, user)
+
+		_, err = repo.GetUserRepo().GetByID(ctx, user.ID+1)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("finds a user by username or email case insensitively", func() {
+		found, err := repo.GetUserRepo().GetByUsernameOrEmail(ctx, &domain.GetByUsernameOrEmail{Username: "ADA"})
+		So(err, ShouldBeNil)
+		So(found.ID, ShouldEqual, user.ID)
+
+		found, err = repo.GetUserRepo().GetByUsernameOrEmail(ctx, &domain.GetByUsernameOrEmail{Username: "grace", Email: "Ada@Example.com"})
+		So(err, ShouldBeNil)
+		So(found.ID, ShouldEqual, user.ID)
+
+		found, err = repo.GetUserRepo().GetByUsernameOrEmail(ctx, &domain.GetByUsernameOrEmail{Username: "grace", Email: "grace@example.com"})
+		So(err, ShouldBeNil)
+		So(found, ShouldBeNil)
+	})
+}
+
+func authors(ctx context.Context, repo repository.RepositoryImpl) {
+	frank := &domain.Author{Name: "Frank Herbert", Email: "frank@example.com", PhoneNumber: "0812"}
+	So(repo.GetAuthorRepo().Create(ctx, frank), ShouldBeNil)
+
+	jane := &domain.Author{Name: "Jane Austen", Email: "jane@example.com"}
+	So(repo.GetAuthorRepo().Create(ctx, jane), ShouldBeNil)
+	So(jane.ID, ShouldBeGreaterThan, frank.ID)
+
+	Convey("finds authors by id and name", func() {
+		found, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(found, ShouldResemble, frank)
+
+		found, err = repo.GetAuthorRepo().GetByID(ctx, jane.ID+1)
+		So(err, ShouldBeNil)
+		So(found, ShouldBeNil)
+
+		found, err = repo.GetAuthorRepo().GetByName(ctx, "jane austen")
+		So(err, ShouldBeNil)
+		So(found.ID, ShouldEqual, jane.ID)
+
+		found, err = repo.GetAuthorRepo().GetByName(ctx, "jane")
+		So(err, ShouldBeNil)
+		So(found, ShouldBeNil)
+
+		list, err := repo.GetAuthorRepo().GetByIDs(ctx, []int{jane.ID, frank.ID, jane.ID + 1})
+		So(err, ShouldBeNil)
+		So(list, ShouldHaveLength, 2)
+
+		list, err = repo.GetAuthorRepo().GetByIDs(ctx, nil)
+		So(err, ShouldBeNil)
+		So(list, ShouldBeEmpty)
+	})
+
+	Convey("updates the given columns, zero values included", func() {
+		So(repo.GetAuthorRepo().Update(ctx, &domain.Author{ID: frank.ID, Name: "F. Herbert"}, "name", "phone_number"), ShouldBeNil)
+
+		found, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(found, ShouldResemble, &domain.Author{ID: frank.ID, Name: "F. Herbert", Email: "frank@example.com"})
+	})
+
+	Convey("updates the non-zero fields without columns", func() {
+		So(repo.GetAuthorRepo().Update(ctx, &domain.Author{ID: frank.ID, Email: "herbert@example.com"}), ShouldBeNil)
+
+		found, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(found, ShouldResemble, &domain.Author{ID: frank.ID, Name: "Frank Herbert", Email: "herbert@example.com", PhoneNumber: "0812"})
+	})
+}
+
+func books(ctx context.Context, repo repository.RepositoryImpl) {
+	frank := &domain.Author{Name: "Frank Herbert", Email: "frank@example.com"}
+	So(repo.GetAuthorRepo().Create(ctx, frank), ShouldBeNil)
+
+	editor := &domain.Author{Name: "Brian Herbert", Email: "brian@example.com"}
+	So(repo.GetAuthorRepo().Create(ctx, editor), ShouldBeNil)
+
+	isbn := "9780441172719"
+	dune := &domain.Book{
+		BookName: "Dune", Title: "Dune", Price: money.New(150000, "IDR"),
+		ISBN13: &isbn, SKU: "BK-1", Barcode: isbn, Location: "A-1",
+		CreatedAt: time.Now().Add(-time.Hour),
+		Contributors: []*domain.BookContributor{
+			{AuthorID: editor.ID, Role: domain.ContributorRoleEditor, Position: 2},
+			{AuthorID: frank.ID, Role: domain.ContributorRoleAuthor, Position: 1},
+		},
+	}
+	So(repo.GetBookRepo().Create(ctx, dune), ShouldBeNil)
+	So(dune.ID, ShouldNotEqual, 0)
+
+	messiah := &domain.Book{
+		BookName: "Dune Messiah", Title: "Dune Messiah", Price: money.New(120000, "IDR"),
+		SKU: "BK-2", Barcode: "2000000000022", Location: "A-2",
+		Contributors: []*domain.BookContributor{{AuthorID: frank.ID, Role: domain.ContributorRoleAuthor, Position: 1}},
+	}
+	So(repo.GetBookRepo().Create(ctx, messiah), ShouldBeNil)
+
+	Convey("loads a book with its contributors in position order", func() {
+		book, err := repo.GetBookRepo().GetByID(ctx, dune.ID)
+		So(err, ShouldBeNil)
+		So(book.Title, ShouldEqual, "Dune")
+		So(book.Price, ShouldResemble, money.New(150000, "IDR"))
+		So(book.AuthorID, ShouldEqual, frank.ID)
+		So(book.Contributors, ShouldHaveLength, 2)
+		So(book.Contributors[0].AuthorID, ShouldEqual, frank.ID)
+		So(book.Contributors[1].BookID, ShouldEqual, dune.ID)
+		So(book.Stock, ShouldEqual, 0)
+
+		book, err = repo.GetBookRepo().GetByID(ctx, messiah.ID+1)
+		So(err, ShouldBeNil)
+		So(book, ShouldBeNil)
+	})
+
+	Convey("finds a book by its identifiers", func() {
+		book, err := repo.GetBookRepo().GetByISBN(ctx, isbn)
+		So(err, ShouldBeNil)
+		So(book.ID, ShouldEqual, dune.ID)
+
+		book, err = repo.GetBookRepo().GetBySKU(ctx, "BK-2")
+		So(err, ShouldBeNil)
+		So(book.ID, ShouldEqual, messiah.ID)
+
+		book, err = repo.GetBookRepo().GetByBarcode(ctx, "2000000000022")
+		So(err, ShouldBeNil)
+		So(book.ID, ShouldEqual, messiah.ID)
+
+		book, err = repo.GetBookRepo().GetBySKU(ctx, "BK-3")
+		So(err, ShouldBeNil)
+		So(book, ShouldBeNil)
+
+		shelved, err := repo.GetBookRepo().GetByLocation(ctx, "A-2")
+		So(err, ShouldBeNil)
+		So(shelved, ShouldHaveLength, 1)
+		So(shelved[0].ID, ShouldEqual, messiah.ID)
+	})
+
+	Convey("rejects a repeated sku", func() {
+		So(repo.GetBookRepo().Create(ctx, &domain.Book{BookName: "Copy", Title: "Copy", SKU: "BK-1", Barcode: "2000000000039"}), ShouldNotBeNil)
+	})
+
+	Convey("returns the latest book", func() {
+		book, err := repo.GetBookRepo().GetLastBook(ctx)
+		So(err, ShouldBeNil)
+		So(book.ID, ShouldEqual, messiah.ID)
+	})
+
+	Convey("lists books by filter and page", func() {
+		books, total, err := repo.GetBookRepo().List(ctx, &domain.BookFilter{Query: "mESSIAH", Page: 1, Limit: 10})
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 1)
+		So(books[0].ID, ShouldEqual, messiah.ID)
+
+		books, total, err = repo.GetBookRepo().List(ctx, &domain.BookFilter{AuthorID: frank.ID, Page: 2, Limit: 1})
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 2)
+		So(books, ShouldHaveLength, 1)
+		So(books[0].ID, ShouldEqual, messiah.ID)
+
+		books, total, err = repo.GetBookRepo().List(ctx, &domain.BookFilter{AuthorID: editor.ID, Page: 1, Limit: 10})
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 1)
+		So(books[0].ID, ShouldEqual, dune.ID)
+
+		byAuthor, err := repo.GetBookRepo().GetListBookByAuthorID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(byAuthor, ShouldHaveLength, 2)
+
+		byID, err := repo.GetBookRepo().GetByIDs(ctx, []int{messiah.ID})
+		So(err, ShouldBeNil)
+		So(byID, ShouldHaveLength, 1)
+	})
+
+	Convey("updates the given columns and leaves stock alone", func() {
+		So(repo.GetBookRepo().Update(ctx, &domain.Book{ID: dune.ID, Title: "Dune (1965)", Location: "", Stock: 10}, "title", "location"), ShouldBeNil)
+
+		book, err := repo.GetBookRepo().GetByID(ctx, dune.ID)
+		So(err, ShouldBeNil)
+		So(book.Title, ShouldEqual, "Dune (1965)")
+		So(book.BookName, ShouldEqual, "Dune")
+		So(book.Location, ShouldEqual, "")
+		So(book.Stock, ShouldEqual, 0)
+	})
+
+	Convey("replaces contributors and list prices", func() {
+		So(repo.GetBookRepo().ReplaceContributors(ctx, dune.ID, []*domain.BookContributor{
+			{AuthorID: editor.ID, Role: domain.ContributorRoleAuthor, Position: 1},
+		}), ShouldBeNil)
+		So(repo.GetBookRepo().ReplacePrices(ctx, dune.ID, []*domain.BookPrice{
+			{Currency: "USD", Amount: 1000, UpdatedAt: time.Now()},
+			{Currency: "EUR", Amount: 900, UpdatedAt: time.Now()},
+		}), ShouldBeNil)
+
+		book, err := repo.GetBookRepo().GetByID(ctx, dune.ID)
+		So(err, ShouldBeNil)
+		So(book.AuthorID, ShouldEqual, editor.ID)
+		So(book.Contributors, ShouldHaveLength, 1)
+		So(book.Prices, ShouldHaveLength, 2)
+		So(book.Prices[0].Currency, ShouldEqual, "EUR")
+
+		So(repo.GetBookRepo().ReplacePrices(ctx, dune.ID, nil), ShouldBeNil)
+
+		book, err = repo.GetBookRepo().GetByID(ctx, dune.ID)
+		So(err, ShouldBeNil)
+		So(book.Prices, ShouldBeEmpty)
+	})
+
+	Convey("deletes a book only through one of its authors", func() {
+		So(repo.GetBookRepo().DeleteBookByAuthorID(ctx, editor.ID, messiah.ID), ShouldBeNil)
+
+		book, err := repo.GetBookRepo().GetByID(ctx, messiah.ID)
+		So(err, ShouldBeNil)
+		So(book, ShouldNotBeNil)
+
+		So(repo.GetBookRepo().DeleteBookByAuthorID(ctx, frank.ID, messiah.ID), ShouldBeNil)
+		So(repo.GetBookRepo().Delete(ctx, dune.ID), ShouldBeNil)
+
+		_, total, err := repo.GetBookRepo().List(ctx, &domain.BookFilter{Page: 1, Limit: 10})
+		So(err, ShouldBeNil)
+		So(total, ShouldEqual, 0)
+	})
+}
+
+func transactions(ctx context.Context, repo repository.RepositoryImpl) {
+	frank := &domain.Author{Name: "Frank Herbert", Email: "frank@example.com"}
+	So(repo.GetAuthorRepo().Create(ctx, frank), ShouldBeNil)
+
+	write := func(txCtx context.Context) error {
+		if err := repo.GetAuthorRepo().Update(txCtx, &domain.Author{ID: frank.ID, Name: "F. Herbert"}); err != nil {
+			return err
+		}
+
+		if err := repo.GetAuthorRepo().Create(txCtx, &domain.Author{Name: "Jane Austen", Email: "jane@example.com"}); err != nil {
+			return err
+		}
+
+		// the writes are visible inside the transaction
+		jane, err := repo.GetAuthorRepo().GetByName(txCtx, "jane austen")
+		So(err, ShouldBeNil)
+		So(jane, ShouldNotBeNil)
+
+		return repo.GetBookRepo().Create(txCtx, &domain.Book{
+			BookName: "Emma", Title: "Emma", SKU: "BK-1", Barcode: "2000000000015",
+			Contributors: []*domain.BookContributor{{AuthorID: jane.ID, Role: domain.ContributorRoleAuthor, Position: 1}},
+		})
+	}
+
+	Convey("commits when fn succeeds", func() {
+		So(repo.GetTransactionRepo().WithTransaction(ctx, write), ShouldBeNil)
+
+		author, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(author.Name, ShouldEqual, "F. Herbert")
+
+		book, err := repo.GetBookRepo().GetBySKU(ctx, "BK-1")
+		So(err, ShouldBeNil)
+		So(book.Title, ShouldEqual, "Emma")
+	})
+
+	Convey("rolls every write back when fn fails", func() {
+		err := repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := write(txCtx); err != nil {
+				return err
+			}
+
+			return errors.New("failed")
+		})
+		So(err, ShouldNotBeNil)
+
+		author, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(author.Name, ShouldEqual, "Frank Herbert")
+
+		jane, err := repo.GetAuthorRepo().GetByName(ctx, "jane austen")
+		So(err, ShouldBeNil)
+		So(jane, ShouldBeNil)
+
+		book, err := repo.GetBookRepo().GetBySKU(ctx, "BK-1")
+		So(err, ShouldBeNil)
+		So(book, ShouldBeNil)
+	})
+
+	Convey("rolls every write back and passes a panic on", func() {
+		So(func() {
+			repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				if err := write(txCtx); err != nil {
+					return err
+				}
+
+				panic("failed")
+			})
+		}, ShouldPanicWith, "failed")
+
+		author, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(author.Name, ShouldEqual, "Frank Herbert")
+
+		jane, err := repo.GetAuthorRepo().GetByName(ctx, "jane austen")
+		So(err, ShouldBeNil)
+		So(jane, ShouldBeNil)
+	})
+
+	Convey("joins the transaction of ctx", func() {
+		err := repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := repo.GetTransactionRepo().WithTransaction(txCtx, write); err != nil {
+				return err
+			}
+
+			return errors.New("failed")
+		})
+		So(err, ShouldNotBeNil)
+
+		author, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(author.Name, ShouldEqual, "Frank Herbert")
+	})
+
+	Convey("rolls a nested transaction back to its savepoint", func() {
+		nested := repository.WithPropagation(repository.PropagationNested)
+
+		So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := repo.GetAuthorRepo().Update(txCtx, &domain.Author{ID: frank.ID, Name: "Frank P. Herbert"}); err != nil {
+				return err
+			}
+
+			err := repo.GetTransactionRepo().WithTransaction(txCtx, func(txCtx context.Context) error {
+				if err := write(txCtx); err != nil {
+					return err
+				}
+
+				return errors.New("failed")
+			}, nested)
+			So(err, ShouldNotBeNil)
+
+			return repo.GetTransactionRepo().WithTransaction(txCtx, func(txCtx context.Context) error {
+				return repo.GetAuthorRepo().Create(txCtx, &domain.Author{Name: "Ursula K. Le Guin", Email: "ursula@example.com"})
+			}, nested)
+		}), ShouldBeNil)
+
+		author, err := repo.GetAuthorRepo().GetByID(ctx, frank.ID)
+		So(err, ShouldBeNil)
+		So(author.Name, ShouldEqual, "Frank P. Herbert")
+
+		jane, err := repo.GetAuthorRepo().GetByName(ctx, "jane austen")
+		So(err, ShouldBeNil)
+		So(jane, ShouldBeNil)
+
+		ursula, err := repo.GetAuthorRepo().GetByName(ctx, "ursula k. le guin")
+		So(err, ShouldBeNil)
+		So(ursula, ShouldNotBeNil)
+	})
+
+	Convey("runs the functions deferred to the commit once it commits", func() {
+		var ran []string
+		deferred := func(name string) func() { return func() { ran = append(ran, name) } }
+
+		repository.AfterCommit(ctx, deferred("outside"))
+		So(ran, ShouldResemble, []string{"outside"})
+
+		So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			repository.AfterCommit(txCtx, deferred("committed"))
+
+			So(repo.GetTransactionRepo().WithTransaction(txCtx, func(txCtx context.Context) error {
+				repository.AfterCommit(txCtx, deferred("savepoint"))
+				return errors.New("failed")
+			}, repository.WithPropagation(repository.PropagationNested)), ShouldNotBeNil)
+
+			So(ran, ShouldResemble, []string{"outside"})
+			return nil
+		}), ShouldBeNil)
+
+		So(repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			repository.AfterCommit(txCtx, deferred("rolled back"))
+			return errors.New("failed")
+		}), ShouldNotBeNil)
+
+		So(ran, ShouldResemble, []string{"outside", "committed"})
+	})
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RMARepositoryImpl interface {
+	Create(ctx context.Context, req *domain.RMA) error
+	GetByID(ctx context.Context, id int) (*domain.RMA, error)
+	GetByIDForUpdate(ctx context.Context, id int) (*domain.RMA, error)
+	List(ctx context.Context, filter *domain.RMAFilter) ([]*domain.RMA, error)
+	Update(ctx context.Context, req *domain.RMA, columns ...string) error
+	UpdateLine(ctx context.Context, line *domain.RMALine) error
+	ReturnedQuantities(ctx context.Context, orderID int) (map[int]int, error)
+	ExistsForOrder(ctx context.Context, orderID int) (bool, error)
+}
+
+type RMARepository struct {
+	TransactionRepository
+}
+
+func NewRMARepository(db *gorm.DB) RMARepositoryImpl {
+	return &RMARepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *RMARepository) rmas(ctx context.Context) *gorm.DB {
+	return r.tx(ctx).Model(&domain.RMA{}).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
+}
+
+// Create inserts the rma with its lines.
+func (r *RMARepository) Create(ctx context.Context, req *domain.RMA) error {
+	return r.tx(ctx).Model(&domain.RMA{}).Create(&req).Error
+}
+
+func (r *RMARepository) GetByID(ctx context.Context, id int) (*domain.RMA, error) {
+	return r.getBy(r.rmas(ctx), id)
+}
+
+// GetByIDForUpdate locks the rma until the transaction in ctx ends so a line is never inspected twice.
+func (r *RMARepository) GetByIDForUpdate(ctx context.Context, id int) (*domain.RMA, error) {
+	return r.getBy(r.rmas(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+func (r *RMARepository) getBy(db *gorm.DB, id int) (*domain.RMA, error) {
+	var rma domain.RMA
+
+	db = db.Where("id = ?", id).First(&rma)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &rma, nil
+}
+
+func (r *RMARepository) List(ctx context.Context, filter *domain.RMAFilter) ([]*domain.RMA, error) {
+	db := r.rmas(ctx)
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if filter.CustomerEmail != "" {
+		db = db.Where("LOWER(customer_email) = LOWER(?)", filter.CustomerEmail)
+	}
+
+	if filter.BookID != 0 {
+		db = db.Where("id IN (SELECT rma_id FROM rma_lines WHERE book_id = ?)", filter.BookID)
+	}
+
+	var rmas []*domain.RMA
+	if err := db.Order("id DESC").Find(&rmas).Error; err != nil {
+		return nil, err
+	}
+
+	return rmas, nil
+}
+
+func (r *RMARepository) Update(ctx context.Context, req *domain.RMA, columns ...string) error {
+	db := r.tx(ctx).Omit("id", clause.Associations).Model(&domain.RMA{}).Where("id = ?", req.ID)
+	if len(columns) > 0 {
+		db = db.Select(columns)
+	}
+
+	return db.Updates(&req).Error
+}
+
+// UpdateLine writes the inspection of a line.
+func (r *RMARepository) UpdateLine(ctx context.Context, line *domain.RMALine) error {
+	return r.tx(ctx).Model(&domain.RMALine{}).Where("id = ?", line.ID).
+		Select("outcome", "refund_amount", "refund_currency", "note", "inspected_by", "inspected_at").
+		Updates(line).Error
+}
+
+// ReturnedQuantities sums, per book, what the rmas of the order that were not cancelled take back.
+func (r *RMARepository) ReturnedQuantities(ctx context.Context, orderID int) (map[int]int, error) {
+	var rows []struct {
+		BookID   int
+		Quantity int
+	}
+
+	db := r.tx(ctx).Model(&domain.RMALine{}).
+		Select("rma_lines.book_id, SUM(rma_lines.quantity) AS quantity").
+		Joins("JOIN rmas ON rmas.id = rma_lines.rma_id").
+		Where("rmas.order_id = ? AND rmas.status <> ?", orderID, domain.RMACancelled).
+		Group("rma_lines.book_id").
+		Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	quantities := make(map[int]int, len(rows))
+	for _, row := range rows {
+		quantities[row.BookID] = row.Quantity
+	}
+
+	return quantities, nil
+}
+
+func (r *RMARepository) ExistsForOrder(ctx context.Context, orderID int) (bool, error) {
+	var count int64
+	if err := r.tx(ctx).Model(&domain.RMA{}).Where("order_id = ? AND status <> ?", orderID, domain.RMACancelled).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
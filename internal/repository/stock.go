@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type StockRepositoryImpl interface {
+	Record(ctx context.Context, movements []*domain.StockMovement) error
+	ListMovements(ctx context.Context, bookID int) ([]*domain.StockMovement, error)
+	Reserve(ctx context.Context, bookID, quantity int) (bool, error)
+	Release(ctx context.Context, bookID, quantity int) error
+}
+
+type StockRepository struct {
+	TransactionRepository
+}
+
+func NewStockRepository(db *gorm.DB) StockRepositoryImpl {
+	return &StockRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// Record appends the movements to the ledger and moves the stock of their books by the same quantities.
+// Stock can not go below zero, a movement that would do so fails the check on books.stock. The stock
+// is updated through the table, the fields of domain.Book are read only.
+func (r *StockRepository) Record(ctx context.Context, movements []*domain.StockMovement) error {
+	if len(movements) == 0 {
+		return nil
+	}
+
+	return r.tx(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.StockMovement{}).Create(&movements).Error; err != nil {
+			return err
+		}
+
+		for _, movement := range movements {
+			db := tx.Table(domain.Book{}.TableName()).Where("id = ?", movement.BookID).
+				UpdateColumn("stock", gorm.Expr("stock + ?", movement.Quantity))
+			if err := db.Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *StockRepository) ListMovements(ctx context.Context, bookID int) ([]*domain.StockMovement, error) {
+	var movements []*domain.StockMovement
+
+	db := r.tx(ctx).Model(&domain.StockMovement{}).Where("book_id = ?", bookID).Order("created_at, id").Find(&movements)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// Reserve holds quantity of the book for an order, it reports false without reserving anything when
+// less than quantity is available.
+func (r *StockRepository) Reserve(ctx context.Context, bookID, quantity int) (bool, error) {
+	db := r.tx(ctx).Table(domain.Book{}.TableName()).Where("id = ? AND stock - reserved >= ?", bookID, quantity).
+		UpdateColumn("reserved", gorm.Expr("reserved + ?", quantity))
+	if err := db.Error; err != nil {
+		return false, err
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+// Release gives back a reservation made with Reserve.
+func (r *StockRepository) Release(ctx context.Context, bookID, quantity int) error {
+	return r.tx(ctx).Table(domain.Book{}.TableName()).Where("id = ?", bookID).
+		UpdateColumn("reserved", gorm.Expr("reserved - ?", quantity)).Error
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockAlertRepositoryImpl interface {
+	SetReorderPoint(ctx context.Context, req *domain.ReorderPoint) error
+	GetReorderPoint(ctx context.Context, bookID int) (*domain.ReorderPoint, error)
+	DeleteReorderPoint(ctx context.Context, bookID int) error
+	SetLocationReorderPoint(ctx context.Context, req *domain.LocationReorderPoint) error
+	GetLocationReorderPoint(ctx context.Context, location string) (*domain.LocationReorderPoint, error)
+	DeleteLocationReorderPoint(ctx context.Context, location string) error
+	ListLowStock(ctx context.Context) ([]*domain.LowStock, error)
+	CreateAlert(ctx context.Context, req *domain.StockAlert) (bool, error)
+	SetAlertPurchaseOrder(ctx context.Context, alertIDs []int, purchaseOrderID int) error
+	ResolveRecovered(ctx context.Context, now time.Time) (int64, error)
+	ListAlerts(ctx context.Context, filter *domain.StockAlertFilter) ([]*domain.StockAlert, error)
+}
+
+type StockAlertRepository struct {
+	TransactionRepository
+}
+
+func NewStockAlertRepository(db *gorm.DB) StockAlertRepositoryImpl {
+	return &StockAlertRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// watched joins every book to its reorder point and the one of its location, the book's own wins.
+const watched = `books b
+	LEFT JOIN reorder_points rp ON rp.book_id = b.id
+	LEFT JOIN location_reorder_points lrp ON lrp.location = b.location`
+
+// lowStock matches the books that have at most their reorder point available.
+const lowStock = `SELECT b.id FROM ` + watched + `
+	WHERE b.stock - b.reserved <= COALESCE(rp.reorder_point, lrp.reorder_point)`
+
+func (r *StockAlertRepository) SetReorderPoint(ctx context.Context, req *domain.ReorderPoint) error {
+	return r.tx(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "book_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reorder_point", "reorder_quantity", "supplier_id", "updated_at"}),
+	}).Create(req).Error
+}
+
+func (r *StockAlertRepository) GetReorderPoint(ctx context.Context, bookID int) (*domain.ReorderPoint, error) {
+	var point domain.ReorderPoint
+
+	db := r.tx(ctx).Where("book_id = ?", bookID).First(&point)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &point, nil
+}
+
+func (r *StockAlertRepository) DeleteReorderPoint(ctx context.Context, bookID int) error {
+	return r.tx(ctx).Where("book_id = ?", bookID).Delete(&domain.ReorderPoint{}).Error
+}
+
+func (r *StockAlertRepository) SetLocationReorderPoint(ctx context.Context, req *domain.LocationReorderPoint) error {
+	return r.tx(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "location"}},
+		DoUpdates: clause.AssignmentColumns([]string{"reorder_point", "reorder_quantity", "supplier_id", "updated_at"}),
+	}).Create(req).Error
+}
+
+func (r *StockAlertRepository) GetLocationReorderPoint(ctx context.Context, location string) (*domain.LocationReorderPoint, error) {
+	var point domain.LocationReorderPoint
+
+	db := r.tx(ctx).Where("location = ?", location).First(&point)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &point, nil
+}
+
+func (r *StockAlertRepository) DeleteLocationReorderPoint(ctx context.Context, location string) error {
+	return r.tx(ctx).Where("location = ?", location).Delete(&domain.LocationReorderPoint{}).Error
+}
+
+// ListLowStock returns the books at or below their reorder point that have no open alert yet.
+func (r *StockAlertRepository) ListLowStock(ctx context.Context) ([]*domain.LowStock, error) {
+	var rows []*domain.LowStock
+
+	db := r.tx(ctx).Table(watched).
+		Select(`b.id AS book_id,
+			COALESCE(rp.reorder_point, lrp.reorder_point) AS reorder_point,
+			COALESCE(rp.reorder_quantity, lrp.reorder_quantity) AS reorder_quantity,
+			CASE WHEN rp.book_id IS NULL THEN lrp.supplier_id ELSE rp.supplier_id END AS supplier_id,
+			b.location, b.title, b.stock - b.reserved AS available`).
+		Where("b.stock - b.reserved <= COALESCE(rp.reorder_point, lrp.reorder_point)").
+		Where("NOT EXISTS (SELECT 1 FROM stock_alerts a WHERE a.book_id = b.id AND a.status = ?)", domain.StockAlertOpen).
+		Order("b.id").
+		Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// CreateAlert inserts an open alert, it reports false when the book already has one.
+func (r *StockAlertRepository) CreateAlert(ctx context.Context, req *domain.StockAlert) (bool, error) {
+	db := r.tx(ctx).Clauses(r.dialect().IgnoreConflicts(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "book_id"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "status = 'open'"}}},
+	})).Omit("Book").Create(req)
+	if err := db.Error; err != nil {
+		return false, err
+	}
+
+	return db.RowsAffected > 0, nil
+}
+
+func (r *StockAlertRepository) SetAlertPurchaseOrder(ctx context.Context, alertIDs []int, purchaseOrderID int) error {
+	return r.tx(ctx).Model(&domain.StockAlert{}).Where("id IN ?", alertIDs).
+		UpdateColumn("purchase_order_id", purchaseOrderID).Error
+}
+
+// ResolveRecovered closes the open alerts of books back above their reorder point or without one.
+func (r *StockAlertRepository) ResolveRecovered(ctx context.Context, now time.Time) (int64, error) {
+	db := r.tx(ctx).Model(&domain.StockAlert{}).
+		Where("status = ? AND book_id NOT IN ("+lowStock+")", domain.StockAlertOpen).
+		Updates(map[string]interface{}{"status": domain.StockAlertResolved, "resolved_at": now})
+	if err := db.Error; err != nil {
+		return 0, err
+	}
+
+	return db.RowsAffected, nil
+}
+
+func (r *StockAlertRepository) ListAlerts(ctx context.Context, filter *domain.StockAlertFilter) ([]*domain.StockAlert, error) {
+	db := r.tx(ctx).Model(&domain.StockAlert{}).Preload("Book")
+
+	if filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+
+	if filter.BookID != 0 {
+		db = db.Where("book_id = ?", filter.BookID)
+	}
+
+	var alerts []*domain.StockAlert
+	if err := db.Order("id DESC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type SupplierRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Supplier) error
+	GetByID(ctx context.Context, id int) (*domain.Supplier, error)
+	GetByName(ctx context.Context, name string) (*domain.Supplier, error)
+	List(ctx context.Context) ([]*domain.Supplier, error)
+	Update(ctx context.Context, req *domain.Supplier) error
+	Delete(ctx context.Context, id int) error
+}
+
+type SupplierRepository struct {
+	TransactionRepository
+}
+
+func NewSupplierRepository(db *gorm.DB) SupplierRepositoryImpl {
+	return &SupplierRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *SupplierRepository) Create(ctx context.Context, req *domain.Supplier) error {
+	return r.tx(ctx).Model(&domain.Supplier{}).Create(&req).Error
+}
+
+func (r *SupplierRepository) GetByID(ctx context.Context, id int) (*domain.Supplier, error) {
+	return r.getBy(ctx, "id = ?", id)
+}
+
+func (r *SupplierRepository) GetByName(ctx context.Context, name string) (*domain.Supplier, error) {
+	return r.getBy(ctx, "LOWER(name) = LOWER(?)", name)
+}
+
+func (r *SupplierRepository) getBy(ctx context.Context, query string, value interface{}) (*domain.Supplier, error) {
+	var supplier domain.Supplier
+	db := r.tx(ctx).Model(&domain.Supplier{}).Where(query, value).First(&supplier)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) List(ctx context.Context) ([]*domain.Supplier, error) {
+	var suppliers []*domain.Supplier
+	if err := r.tx(ctx).Model(&domain.Supplier{}).Order("name").Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+
+	return suppliers, nil
+}
+
+func (r *SupplierRepository) Update(ctx context.Context, req *domain.Supplier) error {
+	return r.tx(ctx).Model(&domain.Supplier{}).Where("id = ?", req.ID).Select("name", "email", "phone_number", "address", "lead_time_days").Updates(req).Error
+}
+
+func (r *SupplierRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.Supplier{}).Error
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepositoryImpl interface {
+	Create(ctx context.Context, req *domain.Tag) error
+	GetByID(ctx context.Context, id int) (*domain.Tag, error)
+	GetByName(ctx context.Context, name string) (*domain.Tag, error)
+	FindOrCreate(ctx context.Context, names []string) ([]*domain.Tag, error)
+	List(ctx context.Context) ([]*domain.Tag, error)
+	GetBookIDs(ctx context.Context, id int) ([]int, error)
+	Update(ctx context.Context, req *domain.Tag) error
+	Delete(ctx context.Context, id int) error
+}
+
+type TagRepository struct {
+	TransactionRepository
+}
+
+func NewTagRepository(db *gorm.DB) TagRepositoryImpl {
+	return &TagRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+func (r *TagRepository) Create(ctx context.Context, req *domain.Tag) error {
+	return r.tx(ctx).Model(&domain.Tag{}).Create(&req).Error
+}
+
+func (r *TagRepository) GetByID(ctx context.Context, id int) (*domain.Tag, error) {
+	return r.getBy(ctx, "id", id)
+}
+
+func (r *TagRepository) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	return r.getBy(ctx, "name", name)
+}
+
+func (r *TagRepository) getBy(ctx context.Context, column string, value interface{}) (*domain.Tag, error) {
+	var tag domain.Tag
+	db := r.tx(ctx).Model(&domain.Tag{}).Where(column+" = ?", value).First(&tag)
+	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// FindOrCreate inserts the names that are not tags yet and returns every tag in names order.
+// names must already be normalized.
+func (r *TagRepository) FindOrCreate(ctx context.Context, names []string) ([]*domain.Tag, error) {
+	if len(names) == 0 {
+		return []*domain.Tag{}, nil
+	}
+
+	tags := make([]*domain.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &domain.Tag{Name: name})
+	}
+
+	db := r.tx(ctx)
+	if err := db.Clauses(r.dialect().IgnoreConflicts(clause.OnConflict{})).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	var existing []*domain.Tag
+	if err := db.Model(&domain.Tag{}).Where("name IN ?", names).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*domain.Tag, len(existing))
+	for _, tag := range existing {
+		byName[tag.Name] = tag
+	}
+
+	tags = tags[:0]
+	for _, name := range names {
+		if tag, ok := byName[name]; ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) List(ctx context.Context) ([]*domain.Tag, error) {
+	var tags []*domain.Tag
+	if err := r.tx(ctx).Model(&domain.Tag{}).Order("name").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// GetBookIDs returns the ids of the books tagged with the tag.
+func (r *TagRepository) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	var ids []int
+	if err := r.tx(ctx).Table("book_tags").Where("tag_id = ?", id).Pluck("book_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (r *TagRepository) Update(ctx context.Context, req *domain.Tag) error {
+	return r.tx(ctx).Model(&domain.Tag{}).Where("id = ?", req.ID).Update("name", req.Name).Error
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id int) error {
+	return r.tx(ctx).Where("id = ?", id).Delete(&domain.Tag{}).Error
+}
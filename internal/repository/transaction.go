@@ -2,14 +2,83 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"log/slog"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/imanudd/inventorySvc-clean-architecture/pkg/auth"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/dialect"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/replica"
 	"gorm.io/gorm"
 )
 
 type TransactionRepositoryImpl interface {
-	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
+	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...TxOption) error
+}
+
+// Propagation decides how WithTransaction runs when ctx already carries a transaction.
+type Propagation int
+
+const (
+	// PropagationRequired joins the transaction of ctx, or begins one when there is none
+	PropagationRequired Propagation = iota
+	// PropagationRequiresNew always begins a transaction of its own on another connection, it commits
+	// or rolls back independently of the transaction of ctx
+	PropagationRequiresNew
+	// PropagationNested runs in a savepoint of the transaction of ctx, a failure rolls back to the
+	// savepoint and leaves the outer transaction going. Without a transaction it begins one
+	PropagationNested
+)
+
+// TxOptions are how WithTransaction runs fn. Isolation, ReadOnly and MaxAttempts apply to a
+// transaction WithTransaction begins, a joined transaction or savepoint keeps the ones of the outer.
+type TxOptions struct {
+	Propagation Propagation
+	Isolation   sql.IsolationLevel
+	ReadOnly    bool
+	// MaxAttempts is how many times a transaction runs when it fails on an error the dialect reports
+	// as Retryable, fn runs again from the start in a new transaction. It is 1 unless a caller whose
+	// fn is safe to run again opts in with WithMaxAttempts
+	MaxAttempts int
+}
+
+type TxOption func(*TxOptions)
+
+func WithPropagation(propagation Propagation) TxOption {
+	return func(o *TxOptions) { o.Propagation = propagation }
+}
+
+func WithIsolation(isolation sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) { o.Isolation = isolation }
+}
+
+func WithReadOnly() TxOption {
+	return func(o *TxOptions) { o.ReadOnly = true }
+}
+
+// WithMaxAttempts sets TxOptions.MaxAttempts. Only fns that build everything they write inside, and
+// keep nothing of a rolled back attempt, can be run more than once.
+func WithMaxAttempts(attempts int) TxOption {
+	return func(o *TxOptions) { o.MaxAttempts = max(attempts, 1) }
+}
+
+// txBackoff is the wait before the second attempt of a transaction, it doubles with every attempt
+// after and gets up to as much again of random jitter.
+var txBackoff = 20 * time.Millisecond
+
+// NewTxOptions returns the defaults, a required transaction tried once, with opts applied.
+func NewTxOptions(opts ...TxOption) TxOptions {
+	options := TxOptions{Propagation: PropagationRequired, MaxAttempts: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
 }
 
 type TransactionRepository struct {
@@ -22,32 +91,222 @@ func NewTransactionRepository(db *gorm.DB) TransactionRepositoryImpl {
 	}
 }
 
-func (r *TransactionRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
-	txCtx, cancel := context.WithCancel(ctx)
-	defer cancel()
+// WithTransaction runs fn in a transaction as opts propagate it. The transaction rolls back when fn
+// returns an error or panics, the panic is passed on after the rollback, and when ctx is cancelled
+// before the commit. A transaction WithTransaction begins that fails on a serialization failure or
+// deadlock runs again with backoff when opts allow more than one attempt, see WithMaxAttempts.
+func (r *TransactionRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error, opts ...TxOption) error {
+	options := NewTxOptions(opts...)
 
-	tx := r.db.WithContext(txCtx).Begin()
+	if tx := auth.GetTxContext(ctx); tx != nil {
+		switch options.Propagation {
+		case PropagationRequired:
+			return fn(ctx)
+		case PropagationNested:
+			return r.savepoint(ctx, tx, fn)
+		}
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = r.begin(ctx, fn, options)
+		if err == nil || attempt >= options.MaxAttempts || !r.dialect().Retryable(err) {
+			break
+		}
 
+		backoff := txBackoff << (attempt - 1)
+		backoff += time.Duration(rand.Int63n(int64(backoff)))
+
+		slog.With("component", "repository").WarnContext(ctx, "retrying transaction", "attempt", attempt, "backoff", backoff, "error", err)
+		metrics.TransactionRetried()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("error db %w", err)
+	}
+
+	return nil
+}
+
+// begin runs fn in a new transaction. The transaction is bound to ctx, the driver rolls it back
+// when ctx is cancelled and the commit fails.
+func (r *TransactionRepository) begin(ctx context.Context, fn func(txCtx context.Context) error, options TxOptions) error {
+	var txOptions *sql.TxOptions
+	if options.Isolation != sql.LevelDefault || options.ReadOnly {
+		txOptions = &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly}
+	}
+
+	tx := r.db.WithContext(ctx).Begin(txOptions)
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	committed := false
 	defer func() {
-		if recover() != nil || ctx.Done() != nil {
+		if !committed {
 			tx.Rollback()
+			metrics.TransactionFinished(metrics.Rollback)
 		}
 	}()
 
-	err := fn(auth.SetTrx(ctx, tx))
-	if err != nil {
-		tx.Rollback()
-		return fmt.Errorf("error db %v", err)
+	hooksCtx, hooks := WithCommitHooks(ctx)
+	if err := fn(auth.SetTrx(hooksCtx, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+
+	committed = true
+	metrics.TransactionFinished(metrics.Commit)
+	hooks.Run()
+
+	return nil
+}
+
+type savepointKey struct{}
+
+// savepoint runs fn in a savepoint of tx, named after how deep it is nested so that siblings reuse
+// the name of a released one.
+func (r *TransactionRepository) savepoint(ctx context.Context, tx *gorm.DB, fn func(txCtx context.Context) error) error {
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	depth++
+	name := "sp_" + strconv.Itoa(depth)
+
+	if err := tx.SavePoint(name).Error; err != nil {
+		return err
+	}
+
+	hooks := GetCommitHooks(ctx)
+	mark := hooks.Mark()
+
+	released := false
+	defer func() {
+		if !released {
+			tx.RollbackTo(name)
+			hooks.Discard(mark)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, savepointKey{}, depth)); err != nil {
+		return err
 	}
 
-	return tx.Commit().Error
+	released = true
+	return tx.Exec("RELEASE SAVEPOINT " + name).Error
+}
+
+type commitHooksKey struct{}
+
+// CommitHooks are the functions AfterCommit defers in a transaction. A TransactionRepositoryImpl
+// keeps them for every transaction it begins and runs them once it commits.
+type CommitHooks struct {
+	mu  sync.Mutex
+	fns []func()
+}
+
+// WithCommitHooks returns ctx deferring the functions given to AfterCommit into the hooks returned.
+func WithCommitHooks(ctx context.Context) (context.Context, *CommitHooks) {
+	hooks := &CommitHooks{}
+	return context.WithValue(ctx, commitHooksKey{}, hooks), hooks
+}
+
+// GetCommitHooks returns the hooks of ctx, nil outside a transaction.
+func GetCommitHooks(ctx context.Context) *CommitHooks {
+	hooks, _ := ctx.Value(commitHooksKey{}).(*CommitHooks)
+	return hooks
+}
+
+// Mark returns how many functions are deferred, for Discard. It is 0 on nil hooks.
+func (h *CommitHooks) Mark() int {
+	if h == nil {
+		return 0
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.fns)
 }
 
+// Discard drops the functions deferred after mark, as a savepoint rolls back.
+func (h *CommitHooks) Discard(mark int) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.fns = h.fns[:min(mark, len(h.fns))]
+}
+
+// Run runs the deferred functions in the order they were deferred.
+func (h *CommitHooks) Run() {
+	h.mu.Lock()
+	fns := h.fns
+	h.fns = nil
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// AfterCommit runs fn once the transaction of ctx commits, right away outside a transaction. fn is
+// dropped when the transaction, or the savepoint it was deferred in, rolls back.
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks := GetCommitHooks(ctx)
+	if hooks == nil {
+		fn()
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+
+	hooks.fns = append(hooks.fns, fn)
+}
+
+type readYourWritesKey struct{}
+
+// ReadYourWrites keeps the reads made with ctx on the primary, for reads outside a transaction that
+// have to see a write a replica may not have yet.
+func ReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// ReadsYourWrites reports whether ctx is marked with ReadYourWrites.
+func ReadsYourWrites(ctx context.Context) bool {
+	readYourWrites, _ := ctx.Value(readYourWritesKey{}).(bool)
+	return readYourWrites
+}
+
+// tx returns the transaction in ctx. Outside a transaction reads go to a replica when there are any,
+// unless ctx is marked with ReadYourWrites, and writes go to the primary.
 func (r *TransactionRepository) tx(ctx context.Context) *gorm.DB {
 	conn := auth.GetTxContext(ctx)
 	if conn == nil {
 		conn = r.db.WithContext(ctx)
+		if ReadsYourWrites(ctx) {
+			conn = replica.Primary(conn)
+		}
 	}
 
 	return conn.WithContext(ctx)
 }
+
+// dialect returns the SQL dialect of the database the repository runs on.
+func (r *TransactionRepository) dialect() dialect.Dialect {
+	return dialect.Of(r.db)
+}
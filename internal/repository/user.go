@@ -38,7 +38,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, err
 
 func (r *UserRepository) GetByUsernameOrEmail(ctx context.Context, req *domain.GetByUsernameOrEmail) (*domain.User, error) {
 	var user *domain.User
-	db := r.tx(ctx).Model(&domain.User{}).Where("username ilike ? or email ilike ?", req.Username, req.Email).First(&user)
+	db := r.tx(ctx).Model(&domain.User{}).Where(r.dialect().ILike("username")+" OR "+r.dialect().ILike("email"), req.Username, req.Email).First(&user)
 
 	if errors.Is(db.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
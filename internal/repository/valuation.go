@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ValuationRepositoryImpl interface {
+	ListMovements(ctx context.Context, until time.Time) ([]*domain.StockMovement, error)
+	ListRevenue(ctx context.Context, from, to time.Time) ([]*domain.SaleRevenue, error)
+}
+
+type ValuationRepository struct {
+	TransactionRepository
+}
+
+func NewValuationRepository(db *gorm.DB) ValuationRepositoryImpl {
+	return &ValuationRepository{
+		TransactionRepository: TransactionRepository{
+			db: db,
+		},
+	}
+}
+
+// saleRevenue prices shipped and returned order lines at what the customer paid, and rma returns at
+// what was refunded. Shipments have a negative quantity, so their revenue comes out positive.
+const saleRevenue = `SELECT book_id, SUM(amount) AS revenue_amount, currency AS revenue_currency FROM (
+	SELECT m.book_id, -m.quantity * ol.unit_price_amount AS amount, ol.unit_price_currency AS currency
+		FROM stock_movements m JOIN order_lines ol ON ol.order_id = m.reference_id AND ol.book_id = m.book_id
+		WHERE m.reference_type = @order AND m.type IN @types AND m.created_at >= @from AND m.created_at < @to
+	UNION ALL
+	SELECT m.book_id, -rl.refund_amount, rl.refund_currency
+		FROM stock_movements m JOIN rma_lines rl ON rl.rma_id = m.reference_id AND rl.book_id = m.book_id
+		WHERE m.reference_type = @rma AND m.type = @return AND rl.refund_amount > 0
+			AND m.created_at >= @from AND m.created_at < @to
+) sales GROUP BY book_id, currency ORDER BY book_id, currency`
+
+// ListMovements returns the whole ledger before until in the order it was written.
+func (r *ValuationRepository) ListMovements(ctx context.Context, until time.Time) ([]*domain.StockMovement, error) {
+	var movements []*domain.StockMovement
+
+	db := r.tx(ctx).Model(&domain.StockMovement{}).Where("created_at < ?", until).Order("created_at, id").Find(&movements)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+// ListRevenue returns the net sales per book and currency from from up to, not including, to.
+func (r *ValuationRepository) ListRevenue(ctx context.Context, from, to time.Time) ([]*domain.SaleRevenue, error) {
+	var rows []*domain.SaleRevenue
+
+	db := r.tx(ctx).Raw(saleRevenue, map[string]interface{}{
+		"order":  domain.StockReferenceOrder,
+		"rma":    domain.StockReferenceRMA,
+		"types":  []string{domain.StockMovementSaleShipment, domain.StockMovementSaleReturn},
+		"return": domain.StockMovementSaleReturn,
+		"from":   from,
+		"to":     to,
+	}).Scan(&rows)
+	if err := db.Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
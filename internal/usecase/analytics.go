@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+const defaultAnalyticsLimit = 20
+
+type AnalyticsUseCaseImpl interface {
+	RefreshAnalytics(ctx context.Context, now time.Time) error
+	TopSellingBooks(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.TopSellingBook, error)
+	SlowMovers(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.SlowMover, error)
+	DaysOnHand(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.DaysOnHand, error)
+	StockTurnover(ctx context.Context, req *domain.AnalyticsRequest) (*domain.StockTurnoverReport, error)
+	RevenueByAuthor(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.AuthorRevenue, error)
+}
+
+type analyticsUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
+}
+
+func NewAnalyticsUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) AnalyticsUseCaseImpl {
+	return &analyticsUseCase{
+		config: config,
+		repo:   repo,
+		rates:  rates,
+	}
+}
+
+// RefreshAnalytics rebuilds the rollups from the last refreshed day on, that day may have been
+// rolled up before it was over. A refresh reads everything it writes inside the transaction, it is
+// run again when it fails on a serialization failure or deadlock.
+func (u *analyticsUseCase) RefreshAnalytics(ctx context.Context, now time.Time) error {
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		refresh, err := u.repo.GetAnalyticsRepo().LockRefresh(txCtx, domain.AnalyticsRollups)
+		if err != nil {
+			return err
+		}
+
+		// the watermark is a date, read it as midnight where the ledger timestamps are written
+		y, m, d := refresh.RefreshedThrough.Date()
+		from := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+
+		if err := u.repo.GetAnalyticsRepo().RefreshSales(txCtx, from); err != nil {
+			return err
+		}
+
+		if err := u.repo.GetAnalyticsRepo().RefreshStock(txCtx, from); err != nil {
+			return err
+		}
+
+		refresh.RefreshedThrough = domain.BucketStart(now, domain.BucketDay)
+		refresh.RefreshedAt = now
+
+		return u.repo.GetAnalyticsRepo().SetRefreshed(txCtx, refresh)
+	}, repository.WithMaxAttempts(3))
+}
+
+func (u *analyticsUseCase) TopSellingBooks(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.TopSellingBook, error) {
+	if err := u.validate(req); err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.GetAnalyticsRepo().TopSelling(ctx, req.From, req.End(), req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := u.currency(req.Currency)
+	convert := newConverter(ctx, u.rates, currency)
+
+	books := []*domain.TopSellingBook{}
+	for _, row := range rows {
+		revenue, err := convert(row.Revenue)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(books) == 0 || books[len(books)-1].BookID != row.BookID {
+			books = append(books, &domain.TopSellingBook{
+				BookID:   row.BookID,
+				Title:    row.Title,
+				Quantity: row.Quantity,
+				Revenue:  money.New(0, currency),
+			})
+		}
+
+		books[len(books)-1].Revenue.Amount += revenue.Amount
+	}
+
+	return books, nil
+}
+
+func (u *analyticsUseCase) SlowMovers(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.SlowMover, error) {
+	if err := u.validate(req); err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.GetAnalyticsRepo().SlowMovers(ctx, req.From, req.End(), req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	movers := make([]*domain.SlowMover, 0, len(rows))
+	for _, row := range rows {
+		mover := &domain.SlowMover{BookStockSales: *row}
+		if row.LastSoldOn != nil {
+			days := domain.DaysBetween(*row.LastSoldOn, req.To)
+			mover.DaysSinceLastSale = &days
+		}
+
+		movers = append(movers, mover)
+	}
+
+	return movers, nil
+}
+
+func (u *analyticsUseCase) DaysOnHand(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.DaysOnHand, error) {
+	if err := u.validate(req); err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.GetAnalyticsRepo().DaysOnHand(ctx, req.From, req.End(), req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*domain.DaysOnHand, 0, len(rows))
+	for _, row := range rows {
+		book := &domain.DaysOnHand{
+			BookID:            row.BookID,
+			Title:             row.Title,
+			Stock:             row.Stock,
+			Quantity:          row.Quantity,
+			AverageDailySales: round2(float64(row.Quantity) / float64(req.Days())),
+		}
+
+		if row.Quantity > 0 {
+			days := round2(float64(row.Stock) * float64(req.Days()) / float64(row.Quantity))
+			book.DaysOnHand = &days
+		}
+
+		books = append(books, book)
+	}
+
+	return books, nil
+}
+
+// StockTurnover walks the stock from its opening level through the daily rollups, of one book or of
+// all books, and relates the copies sold in every bucket to the stock held.
+func (u *analyticsUseCase) StockTurnover(ctx context.Context, req *domain.AnalyticsRequest) (*domain.StockTurnoverReport, error) {
+	buckets, err := u.buckets(req)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := u.repo.GetAnalyticsRepo().OpeningStock(ctx, req.From, req.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := u.repo.GetAnalyticsRepo().ListStockChanges(ctx, req.From, req.End(), req.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := u.repo.GetAnalyticsRepo().ListSales(ctx, req.From, req.End(), req.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, sold := byDay(changes), byDay(sales)
+
+	report := &domain.StockTurnoverReport{
+		BookID:  req.BookID,
+		Bucket:  req.Bucket,
+		Total:   &domain.TurnoverBucket{Bucket: domain.Bucket{Start: req.From, End: req.End()}, OpeningStock: stock},
+		Buckets: make([]*domain.TurnoverBucket, 0, len(buckets)),
+	}
+
+	for _, bucket := range buckets {
+		turnover := &domain.TurnoverBucket{Bucket: *bucket, OpeningStock: stock}
+		for day := bucket.Start; day.Before(bucket.End); day = day.AddDate(0, 0, 1) {
+			stock += changed[domain.DayKey(day)]
+			turnover.Quantity += sold[domain.DayKey(day)]
+		}
+
+		turnover.ClosingStock = stock
+		setTurnover(turnover)
+
+		report.Buckets = append(report.Buckets, turnover)
+		report.Total.Quantity += turnover.Quantity
+	}
+
+	report.Total.ClosingStock = stock
+	setTurnover(report.Total)
+
+	return report, nil
+}
+
+// RevenueByAuthor returns the authors with the highest revenue first, their sales split into buckets.
+func (u *analyticsUseCase) RevenueByAuthor(ctx context.Context, req *domain.AnalyticsRequest) ([]*domain.AuthorRevenue, error) {
+	buckets, err := u.buckets(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.GetAnalyticsRepo().ListAuthorRevenue(ctx, req.From, req.End(), req.Bucket, req.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := u.currency(req.Currency)
+	convert := newConverter(ctx, u.rates, currency)
+
+	index := make(map[string]int, len(buckets))
+	for i, bucket := range buckets {
+		index[domain.DayKey(domain.BucketStart(bucket.Start, req.Bucket))] = i
+	}
+
+	authors := make(map[int]*domain.AuthorRevenue)
+	for _, row := range rows {
+		revenue, err := convert(row.Revenue)
+		if err != nil {
+			return nil, err
+		}
+
+		author, ok := authors[row.AuthorID]
+		if !ok {
+			author = &domain.AuthorRevenue{
+				AuthorID: row.AuthorID,
+				Name:     row.Name,
+				Revenue:  money.New(0, currency),
+				Buckets:  make([]*domain.RevenueBucket, 0, len(buckets)),
+			}
+			for _, bucket := range buckets {
+				author.Buckets = append(author.Buckets, &domain.RevenueBucket{Bucket: *bucket, Revenue: money.New(0, currency)})
+			}
+			authors[row.AuthorID] = author
+		}
+
+		i, ok := index[domain.DayKey(row.Bucket)]
+		if !ok {
+			return nil, fmt.Errorf("sales of %s are outside the requested buckets", domain.DayKey(row.Bucket))
+		}
+
+		author.Buckets[i].Quantity += row.Quantity
+		author.Buckets[i].Revenue.Amount += revenue.Amount
+		author.Quantity += row.Quantity
+		author.Revenue.Amount += revenue.Amount
+	}
+
+	list := make([]*domain.AuthorRevenue, 0, len(authors))
+	for _, author := range authors {
+		list = append(list, author)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Revenue.Amount != list[j].Revenue.Amount {
+			return list[i].Revenue.Amount > list[j].Revenue.Amount
+		}
+		return list[i].AuthorID < list[j].AuthorID
+	})
+
+	if len(list) > req.Limit {
+		list = list[:req.Limit]
+	}
+
+	return list, nil
+}
+
+func (u *analyticsUseCase) validate(req *domain.AnalyticsRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if req.Limit == 0 {
+		req.Limit = defaultAnalyticsLimit
+	}
+
+	if req.Bucket == "" {
+		req.Bucket = domain.BucketDay
+	}
+
+	return nil
+}
+
+func (u *analyticsUseCase) buckets(req *domain.AnalyticsRequest) ([]*domain.Bucket, error) {
+	if err := u.validate(req); err != nil {
+		return nil, err
+	}
+
+	buckets := req.Buckets()
+	if len(buckets) > domain.MaxAnalyticsBuckets {
+		return nil, validator.NewValidationError(fmt.Sprintf("period has more than %d buckets, use a wider bucket", domain.MaxAnalyticsBuckets))
+	}
+
+	return buckets, nil
+}
+
+func (u *analyticsUseCase) currency(currency string) string {
+	if currency != "" {
+		return currency
+	}
+
+	return u.config.DefaultCurrency
+}
+
+func byDay(rows []*domain.DailyQuantity) map[string]int64 {
+	days := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		days[domain.DayKey(row.Day)] += row.Quantity
+	}
+
+	return days
+}
+
+// setTurnover fills in the average stock, the turnover when stock was held and the days on hand when
+// anything sold.
+func setTurnover(b *domain.TurnoverBucket) {
+	b.AverageStock = round2(float64(b.OpeningStock+b.ClosingStock) / 2)
+	b.Turnover, b.DaysOnHand = nil, nil
+
+	if b.AverageStock > 0 {
+		turnover := round2(float64(b.Quantity) / b.AverageStock)
+		b.Turnover = &turnover
+	}
+
+	if b.Quantity > 0 {
+		days := round2(b.AverageStock * float64(b.Days()) / float64(b.Quantity))
+		b.DaysOnHand = &days
+	}
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	pkgMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/pkg"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRefreshAnalytics(t *testing.T) {
+	Convey("Test refresh analytics", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		analyticsRepo := repositoryMock.NewMockAnalyticsRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAnalyticsRepo().Return(analyticsRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			// a refresh is safe to run again, it opts in to retries
+			So(repository.NewTxOptions(opts...).MaxAttempts, ShouldBeGreaterThan, 1)
+			return fn(ctx)
+		}).AnyTimes()
+
+		analyticsUseCase := NewAnalyticsUseCase(config, repoMock, nil)
+
+		var (
+			ctx = context.Background()
+			now = time.Date(2026, 3, 10, 14, 30, 0, 0, time.Local)
+		)
+
+		Convey("resp success rebuilds from the last refreshed day", func() {
+			from := time.Date(2026, 3, 8, 0, 0, 0, 0, time.Local)
+
+			analyticsRepo.EXPECT().LockRefresh(gomock.Any(), domain.AnalyticsRollups).Return(&domain.AnalyticsRefresh{
+				Name:             domain.AnalyticsRollups,
+				RefreshedThrough: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			}, nil)
+			analyticsRepo.EXPECT().RefreshSales(gomock.Any(), from).Return(nil)
+			analyticsRepo.EXPECT().RefreshStock(gomock.Any(), from).Return(nil)
+			analyticsRepo.EXPECT().SetRefreshed(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, refresh *domain.AnalyticsRefresh) error {
+				So(refresh.RefreshedThrough, ShouldEqual, time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local))
+				So(refresh.RefreshedAt, ShouldEqual, now)
+				return nil
+			})
+
+			err := analyticsUseCase.RefreshAnalytics(ctx, now)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestAnalyticsReports(t *testing.T) {
+	Convey("Test analytics reports", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		analyticsRepo := repositoryMock.NewMockAnalyticsRepositoryImpl(ctrl)
+		rates := pkgMock.NewMockProvider(ctrl)
+
+		repoMock.EXPECT().GetAnalyticsRepo().Return(analyticsRepo).AnyTimes()
+
+		analyticsUseCase := NewAnalyticsUseCase(config, repoMock, rates)
+
+		var (
+			ctx  = context.Background()
+			date = func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 0, 0, 0, 0, time.Local) }
+			utc  = func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC) }
+		)
+
+		rates.EXPECT().Rate(gomock.Any(), "USD", "IDR").Return(big.NewRat(16000, 1), nil).AnyTimes()
+
+		Convey("resp err too many buckets", func() {
+			_, err := analyticsUseCase.StockTurnover(ctx, &domain.AnalyticsRequest{From: date(1, 1), To: date(12, 31).AddDate(1, 0, 0)})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("stock turnover walks the stock through weekly buckets", func() {
+			req := &domain.AnalyticsRequest{From: date(3, 4), To: date(3, 15), Bucket: domain.BucketWeek, BookID: 10}
+
+			analyticsRepo.EXPECT().OpeningStock(gomock.Any(), date(3, 4), 10).Return(int64(100), nil)
+			analyticsRepo.EXPECT().ListStockChanges(gomock.Any(), date(3, 4), date(3, 16), 10).Return([]*domain.DailyQuantity{
+				{Day: utc(3, 5), Quantity: -10},
+				{Day: utc(3, 10), Quantity: 50},
+				{Day: utc(3, 12), Quantity: -20},
+			}, nil)
+			analyticsRepo.EXPECT().ListSales(gomock.Any(), date(3, 4), date(3, 16), 10).Return([]*domain.DailyQuantity{
+				{Day: utc(3, 5), Quantity: 10},
+				{Day: utc(3, 12), Quantity: 20},
+			}, nil)
+
+			report, err := analyticsUseCase.StockTurnover(ctx, req)
+			So(err, ShouldBeNil)
+			So(report.Buckets, ShouldHaveLength, 2)
+
+			first := report.Buckets[0]
+			So(first.End, ShouldEqual, date(3, 9))
+			So(first.OpeningStock, ShouldEqual, 100)
+			So(first.ClosingStock, ShouldEqual, 90)
+			So(first.AverageStock, ShouldEqual, 95)
+			So(*first.Turnover, ShouldEqual, 0.11)
+			So(*first.DaysOnHand, ShouldEqual, 47.5)
+
+			second := report.Buckets[1]
+			So(second.OpeningStock, ShouldEqual, 90)
+			So(second.ClosingStock, ShouldEqual, 120)
+			So(*second.DaysOnHand, ShouldEqual, 36.75)
+
+			So(report.Total.Quantity, ShouldEqual, 30)
+			So(*report.Total.Turnover, ShouldEqual, 0.27)
+			So(*report.Total.DaysOnHand, ShouldEqual, 44)
+		})
+
+		Convey("revenue by author fills monthly buckets in one currency", func() {
+			req := &domain.AnalyticsRequest{From: date(1, 15), To: date(3, 10), Bucket: domain.BucketMonth}
+
+			analyticsRepo.EXPECT().ListAuthorRevenue(gomock.Any(), date(1, 15), date(3, 11), domain.BucketMonth, 0).Return([]*domain.AuthorRevenueRow{
+				{AuthorID: 1, Name: "Frank Herbert", Bucket: utc(1, 1), Quantity: 2, Revenue: money.New(200000, "IDR")},
+				{AuthorID: 1, Name: "Frank Herbert", Bucket: utc(3, 1), Quantity: 1, Revenue: money.New(100, "USD")},
+				{AuthorID: 2, Name: "Jane Austen", Bucket: utc(2, 1), Quantity: 5, Revenue: money.New(5000000, "IDR")},
+			}, nil)
+
+			authors, err := analyticsUseCase.RevenueByAuthor(ctx, req)
+			So(err, ShouldBeNil)
+			So(authors, ShouldHaveLength, 2)
+
+			So(authors[0].AuthorID, ShouldEqual, 2)
+			So(authors[1].Revenue, ShouldResemble, money.New(1800000, "IDR"))
+			So(authors[1].Quantity, ShouldEqual, 3)
+			So(authors[1].Buckets, ShouldHaveLength, 3)
+			So(authors[1].Buckets[0].Start, ShouldEqual, date(1, 15))
+			So(authors[1].Buckets[1].Revenue, ShouldResemble, money.New(0, "IDR"))
+			So(authors[1].Buckets[2].Revenue, ShouldResemble, money.New(1600000, "IDR"))
+		})
+
+		Convey("slow movers count the days since their last sale", func() {
+			lastSold := utc(3, 2)
+			analyticsRepo.EXPECT().SlowMovers(gomock.Any(), date(3, 1), date(4, 1), 20).Return([]*domain.BookStockSales{
+				{BookID: 10, Title: "Dune", Stock: 40},
+				{BookID: 11, Title: "Emma", Stock: 12, Quantity: 1, LastSoldOn: &lastSold},
+			}, nil)
+
+			movers, err := analyticsUseCase.SlowMovers(ctx, &domain.AnalyticsRequest{From: date(3, 1), To: date(3, 31)})
+			So(err, ShouldBeNil)
+			So(movers[0].DaysSinceLastSale, ShouldBeNil)
+			So(*movers[1].DaysSinceLastSale, ShouldEqual, 29)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
 	"github.com/imanudd/inventorySvc-clean-architecture/pkg/auth"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
 	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -31,6 +32,7 @@ func NewAuthUseCase(cfg *config.MainConfig, repo repository.RepositoryImpl) Auth
 
 func (a *authUseCase) Login(ctx context.Context, req *domain.LoginRequest) (*domain.LoginResponse, error) {
 	if err := validator.ValidateStruct(req); err != nil {
+		metrics.LoginFailed(metrics.LoginInvalidRequest)
 		return nil, err
 	}
 
@@ -42,11 +44,13 @@ func (a *authUseCase) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 	}
 
 	if user == nil {
+		metrics.LoginFailed(metrics.LoginUnknownUser)
 		return nil, errors.New("user is not exist")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
+		metrics.LoginFailed(metrics.LoginWrongPassword)
 		return nil, err
 	}
 
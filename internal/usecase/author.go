@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -10,26 +11,31 @@ import (
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
 	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
 )
 
 type AuthorUseCaseImpl interface {
 	CreateAuthorAndBook(ctx context.Context, req *domain.CreateAuthorAndBookRequest) error
 	DeleteBookByAuthor(ctx context.Context, id, bookId int) error
-	GetListBookByAuthor(ctx context.Context, id int) ([]*domain.Book, error)
+	GetListBookByAuthor(ctx context.Context, id int, currency string) ([]*domain.Book, error)
 	AddAuthorBook(ctx context.Context, req *domain.AddAuthorBookRequest) error
 	CreateAuthor(ctx context.Context, req *domain.CreateAuthorRequest) error
+	PatchAuthor(ctx context.Context, id int, patch mergepatch.Patch) error
 }
 
 type authorUseCase struct {
 	config *config.MainConfig
 	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
 }
 
-func NewAuthorUseCase(config *config.MainConfig, repo repository.RepositoryImpl) AuthorUseCaseImpl {
+func NewAuthorUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) AuthorUseCaseImpl {
 	return &authorUseCase{
 		config: config,
 		repo:   repo,
+		rates:  rates,
 	}
 }
 
@@ -38,29 +44,46 @@ func (u *authorUseCase) CreateAuthorAndBook(ctx context.Context, req *domain.Cre
 		return err
 	}
 
+	price, err := resolvePrice(u.config, req.Book.Price)
+	if err != nil {
+		return err
+	}
+
 	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
-		err := u.repo.GetAuthorRepo().Create(txCtx, &domain.Author{
+		author := &domain.Author{
 			ID:          req.Book.AuthorID,
 			Name:        req.Author.Name,
 			Email:       req.Author.Email,
 			PhoneNumber: req.Author.PhoneNumber,
-		})
+		}
+
+		err := u.repo.GetAuthorRepo().Create(txCtx, author)
 		if err != nil {
 			return err
 		}
 
-		err = u.repo.GetBookRepo().Create(txCtx, &domain.Book{
-			AuthorID:  req.Book.AuthorID,
+		book := &domain.Book{
 			BookName:  req.Book.BookName,
 			Title:     req.Book.Title,
-			Price:     req.Book.Price,
+			Price:     price,
+			Location:  req.Book.Location,
 			CreatedAt: time.Now(),
-		})
-		if err != nil {
+		}
+		book.SetContributors(author.ID, req.Book.Contributors)
+
+		if err := checkAuthorsExist(txCtx, u.repo, otherAuthorIDs(book.Contributors, author.ID)); err != nil {
 			return err
 		}
 
-		return nil
+		if err := setBookTaxonomy(txCtx, u.repo, book, &req.Book.BookTaxonomyRequest, &bookChanges{}); err != nil {
+			return err
+		}
+
+		if err := newBookIdentifiers(txCtx, u.config, u.repo, book, req.Book.ISBN); err != nil {
+			return err
+		}
+
+		return createBook(txCtx, u.repo, book)
 	})
 }
 
@@ -78,12 +101,33 @@ func (u *authorUseCase) AddAuthorBook(ctx context.Context, req *domain.AddAuthor
 		return errors.New("author not found")
 	}
 
-	return u.repo.GetBookRepo().Create(ctx, &domain.Book{
-		AuthorID: author.ID,
-		BookName: req.BookName,
-		Title:    req.Title,
-		Price:    req.Price,
-	})
+	price, err := resolvePrice(u.config, req.Price)
+	if err != nil {
+		return err
+	}
+
+	book := &domain.Book{
+		BookName:  req.BookName,
+		Title:     req.Title,
+		Price:     price,
+		Location:  req.Location,
+		CreatedAt: time.Now(),
+	}
+	book.SetContributors(author.ID, req.Contributors)
+
+	if err := checkAuthorsExist(ctx, u.repo, otherAuthorIDs(book.Contributors, author.ID)); err != nil {
+		return err
+	}
+
+	if err := setBookTaxonomy(ctx, u.repo, book, &req.BookTaxonomyRequest, &bookChanges{}); err != nil {
+		return err
+	}
+
+	if err := newBookIdentifiers(ctx, u.config, u.repo, book, req.ISBN); err != nil {
+		return err
+	}
+
+	return createBook(ctx, u.repo, book)
 }
 
 func (u *authorUseCase) CreateAuthor(ctx context.Context, req *domain.CreateAuthorRequest) error {
@@ -107,6 +151,62 @@ func (u *authorUseCase) CreateAuthor(ctx context.Context, req *domain.CreateAuth
 	})
 }
 
+func (u *authorUseCase) PatchAuthor(ctx context.Context, id int, patch mergepatch.Patch) error {
+	if fields := patch.NullFields(); len(fields) > 0 {
+		return validator.NewValidationError(fmt.Sprintf("%s can not be null", fields[0]))
+	}
+
+	var req domain.PatchAuthorRequest
+	if err := patch.Decode(&req); err != nil {
+		return validator.NewValidationError(err.Error())
+	}
+
+	if err := validator.ValidateStruct(&req); err != nil {
+		return err
+	}
+
+	author, err := u.repo.GetAuthorRepo().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if author == nil {
+		return errors.New("author not found")
+	}
+
+	var columns []string
+
+	if req.Name != nil {
+		existing, err := u.repo.GetAuthorRepo().GetByName(ctx, *req.Name)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil && existing.ID != author.ID {
+			return errors.New("author already exist")
+		}
+
+		author.Name = *req.Name
+		columns = append(columns, "name")
+	}
+
+	if req.Email != nil {
+		author.Email = *req.Email
+		columns = append(columns, "email")
+	}
+
+	if req.PhoneNumber != nil {
+		author.PhoneNumber = *req.PhoneNumber
+		columns = append(columns, "phone_number")
+	}
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	return u.repo.GetAuthorRepo().Update(ctx, author, columns...)
+}
+
 func (u *authorUseCase) DeleteBookByAuthor(ctx context.Context, id int, bookId int) error {
 	g, gCtx := errgroup.WithContext(ctx)
 
@@ -150,7 +250,11 @@ func (u *authorUseCase) DeleteBookByAuthor(ctx context.Context, id int, bookId i
 	return u.repo.GetBookRepo().DeleteBookByAuthorID(ctx, author.ID, book.ID)
 }
 
-func (u *authorUseCase) GetListBookByAuthor(ctx context.Context, id int) ([]*domain.Book, error) {
+func (u *authorUseCase) GetListBookByAuthor(ctx context.Context, id int, currency string) ([]*domain.Book, error) {
+	if err := checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
 	author, err := u.repo.GetAuthorRepo().GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -165,5 +269,21 @@ func (u *authorUseCase) GetListBookByAuthor(ctx context.Context, id int) ([]*dom
 		return nil, err
 	}
 
+	if err := convertPrices(ctx, u.rates, currency, books...); err != nil {
+		return nil, err
+	}
+
 	return books, nil
 }
+
+// otherAuthorIDs lists the contributing authors besides the one that is already known to exist.
+func otherAuthorIDs(contributors []*domain.BookContributor, authorID int) []int {
+	var ids []int
+	for _, id := range domain.ContributorAuthorIDs(contributors) {
+		if id != authorID {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
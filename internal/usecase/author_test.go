@@ -3,11 +3,17 @@ package usecase
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository/memory"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	pkgMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/pkg"
 	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
 	. "github.com/smartystreets/goconvey/convey"
 	"go.uber.org/mock/gomock"
@@ -24,7 +30,7 @@ func TestCreateAuthorAndBook(t *testing.T) {
 		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
 		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
 
-		authorUseCase := NewAuthorUseCase(config, repoMock)
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
 
 		var (
 			ctx     = context.Background()
@@ -39,7 +45,7 @@ func TestCreateAuthorAndBook(t *testing.T) {
 					AuthorID:  1,
 					BookName:  "book test",
 					Title:     "testing",
-					Price:     10000,
+					Price:     money.New(1000000, "IDR"),
 					CreatedAt: time.Now(),
 				},
 			}
@@ -56,7 +62,7 @@ func TestCreateAuthorAndBook(t *testing.T) {
 				repoMock.EXPECT().GetTransactionRepo().Return(trx)
 				repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
 
-				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error) {
+				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) {
 					authorRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errResp)
 					err := fn(ctx)
 					So(err, ShouldNotBeNil)
@@ -70,7 +76,7 @@ func TestCreateAuthorAndBook(t *testing.T) {
 				repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
 				repoMock.EXPECT().GetBookRepo().Return(bookRepo)
 
-				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error) {
+				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) {
 					authorRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 					bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errResp)
 					err := fn(ctx)
@@ -85,7 +91,7 @@ func TestCreateAuthorAndBook(t *testing.T) {
 				repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
 				repoMock.EXPECT().GetBookRepo().Return(bookRepo)
 
-				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error) {
+				trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) {
 					authorRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 					bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
 					err := fn(ctx)
@@ -98,6 +104,51 @@ func TestCreateAuthorAndBook(t *testing.T) {
 		})
 	})
 }
+func TestCreateAuthorAndBookInMemory(t *testing.T) {
+	Convey("Test create author and book in memory", t, func() {
+		repo := memory.NewRepository()
+		authorUseCase := NewAuthorUseCase(&config.MainConfig{SKUPrefix: "BK"}, repo, nil)
+
+		ctx := context.Background()
+		req := &domain.CreateAuthorAndBookRequest{
+			Author: domain.CreateAuthorRequest{
+				Name:        "jamil",
+				Email:       "jamil@mail.com",
+				PhoneNumber: "082833",
+			},
+			Book: domain.CreateBookRequest{
+				AuthorID: 1,
+				BookName: "book test",
+				Title:    "testing",
+				Price:    money.New(1000000, "IDR"),
+			},
+		}
+
+		Convey("creates the author with the book", func() {
+			So(authorUseCase.CreateAuthorAndBook(ctx, req), ShouldBeNil)
+
+			author, err := repo.GetAuthorRepo().GetByName(ctx, "jamil")
+			So(err, ShouldBeNil)
+
+			books, err := authorUseCase.GetListBookByAuthor(ctx, author.ID, "")
+			So(err, ShouldBeNil)
+			So(books, ShouldHaveLength, 1)
+			So(books[0].Title, ShouldEqual, "testing")
+			So(books[0].SKU, ShouldStartWith, "BK")
+		})
+
+		Convey("rolls the author back when a contributor does not exist", func() {
+			req.Book.Contributors = []*domain.ContributorRequest{{AuthorID: 99, Role: domain.ContributorRoleEditor}}
+
+			So(authorUseCase.CreateAuthorAndBook(ctx, req), ShouldNotBeNil)
+
+			author, err := repo.GetAuthorRepo().GetByName(ctx, "jamil")
+			So(err, ShouldBeNil)
+			So(author, ShouldBeNil)
+		})
+	})
+}
+
 func TestAddAuthorBook(t *testing.T) {
 	Convey("Test add author book", t, func() {
 		ctrl := gomock.NewController(t)
@@ -114,7 +165,7 @@ func TestAddAuthorBook(t *testing.T) {
 				AuthorID: 1,
 				BookName: "petualangan sherina",
 				Title:    "adventure",
-				Price:    10000,
+				Price:    money.New(1000000, "IDR"),
 			}
 
 			author = &domain.Author{
@@ -127,7 +178,7 @@ func TestAddAuthorBook(t *testing.T) {
 			errResp = errors.New("error")
 		)
 
-		authorUseCase := NewAuthorUseCase(config, repoMock)
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
 
 		Convey("resp err validator", func() {
 			req.BookName = ""
@@ -169,6 +220,33 @@ func TestAddAuthorBook(t *testing.T) {
 			So(err, ShouldBeNil)
 		})
 
+		Convey("resp err when co-author doesnt exist", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).Times(2)
+
+			req.Contributors = []*domain.ContributorRequest{{AuthorID: 2, Role: domain.ContributorRoleAuthor}}
+			authorRepo.EXPECT().GetByID(gomock.Any(), 1).Return(author, nil)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{2}).Return(nil, nil)
+			err := authorUseCase.AddAuthorBook(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success credits the path author first", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).Times(2)
+			repoMock.EXPECT().GetBookRepo().Return(bookRepo)
+
+			req.Contributors = []*domain.ContributorRequest{{AuthorID: 2, Role: domain.ContributorRoleIllustrator}}
+			authorRepo.EXPECT().GetByID(gomock.Any(), 1).Return(author, nil)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{2}).Return([]*domain.Author{{ID: 2, Name: "budi"}}, nil)
+			bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, book *domain.Book) error {
+				So(book.AuthorID, ShouldEqual, 1)
+				So(len(book.Contributors), ShouldEqual, 2)
+				So(book.Contributors[1].Role, ShouldEqual, domain.ContributorRoleIllustrator)
+				return nil
+			})
+			err := authorUseCase.AddAuthorBook(ctx, req)
+			So(err, ShouldBeNil)
+		})
+
 	})
 }
 
@@ -181,7 +259,7 @@ func TestCreateAuthor(t *testing.T) {
 		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
 		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
 
-		authorUseCase := NewAuthorUseCase(config, repoMock)
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
 
 		var (
 			ctx = context.Background()
@@ -252,7 +330,7 @@ func TestDeleteBookByAuthor(t *testing.T) {
 		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
 		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
 
-		authorUseCase := NewAuthorUseCase(config, repoMock)
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
 
 		var (
 			ctx      = context.Background()
@@ -265,7 +343,7 @@ func TestDeleteBookByAuthor(t *testing.T) {
 				AuthorID:  authorID,
 				BookName:  "buku tulis",
 				Title:     "anak anak",
-				Price:     10000,
+				Price:     money.New(1000000, "IDR"),
 				CreatedAt: time.Now(),
 			}
 
@@ -351,7 +429,7 @@ func TestGetListBookByAuthor(t *testing.T) {
 		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
 		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
 
-		authorUseCase := NewAuthorUseCase(config, repoMock)
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
 
 		var (
 			ctx      = context.Background()
@@ -371,14 +449,14 @@ func TestGetListBookByAuthor(t *testing.T) {
 					AuthorID: authorID,
 					BookName: "buku tulis",
 					Title:    "anak anak",
-					Price:    7000,
+					Price:    money.New(700000, "IDR"),
 				},
 				{
 					ID:       2,
 					AuthorID: authorID,
 					BookName: "buku tulis gambar",
 					Title:    "anak anak",
-					Price:    7000,
+					Price:    money.New(700000, "IDR"),
 				},
 			}
 		)
@@ -387,7 +465,7 @@ func TestGetListBookByAuthor(t *testing.T) {
 			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
 
 			authorRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, errResp)
-			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID)
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "")
 			So(err, ShouldNotBeNil)
 			So(resp, ShouldBeNil)
 		})
@@ -396,7 +474,7 @@ func TestGetListBookByAuthor(t *testing.T) {
 			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
 
 			authorRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, nil)
-			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID)
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "")
 			So(err, ShouldNotBeNil)
 			So(resp, ShouldBeNil)
 		})
@@ -407,7 +485,7 @@ func TestGetListBookByAuthor(t *testing.T) {
 
 			authorRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(author, nil)
 			bookRepo.EXPECT().GetListBookByAuthorID(gomock.Any(), gomock.Any()).Return(nil, errResp)
-			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID)
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "")
 			So(err, ShouldNotBeNil)
 			So(resp, ShouldBeNil)
 		})
@@ -418,9 +496,121 @@ func TestGetListBookByAuthor(t *testing.T) {
 
 			authorRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(author, nil)
 			bookRepo.EXPECT().GetListBookByAuthorID(gomock.Any(), gomock.Any()).Return(books, nil)
-			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID)
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "")
 			So(err, ShouldBeNil)
 			So(resp, ShouldNotBeNil)
 		})
+
+		Convey("resp success in another currency", func() {
+			rates := pkgMock.NewMockProvider(ctrl)
+			authorUseCase := NewAuthorUseCase(config, repoMock, rates)
+
+			books[1].Prices = []*domain.BookPrice{{BookID: 2, Currency: "SGD", Amount: 99}}
+
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
+			repoMock.EXPECT().GetBookRepo().Return(bookRepo)
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(author, nil)
+			bookRepo.EXPECT().GetListBookByAuthorID(gomock.Any(), gomock.Any()).Return(books, nil)
+			rates.EXPECT().Rate(gomock.Any(), "IDR", "SGD").Return(big.NewRat(1, 12000), nil)
+
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "SGD")
+			So(err, ShouldBeNil)
+			So(resp[0].Price, ShouldResemble, money.New(58, "SGD"))
+			So(resp[0].PriceConverted, ShouldBeTrue)
+			So(resp[1].Price, ShouldResemble, money.New(99, "SGD"))
+			So(resp[1].PriceConverted, ShouldBeFalse)
+		})
+
+		Convey("resp err unsupported currency", func() {
+			resp, err := authorUseCase.GetListBookByAuthor(ctx, authorID, "XXX")
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+	})
+}
+
+func TestPatchAuthor(t *testing.T) {
+	Convey("Test patch author", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+
+		authorUseCase := NewAuthorUseCase(config, repoMock, nil)
+
+		var (
+			ctx      = context.Background()
+			errResp  = errors.New("error")
+			authorID = 1
+
+			author = &domain.Author{
+				ID:          authorID,
+				Name:        "jamil",
+				Email:       "jamil@mail.com",
+				PhoneNumber: "092173820",
+			}
+		)
+
+		newPatch := func(body string) mergepatch.Patch {
+			patch, err := mergepatch.Parse([]byte(body))
+			So(err, ShouldBeNil)
+			return patch
+		}
+
+		Convey("resp err when field is null", func() {
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"email":null}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when field is unknown", func() {
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"id":2}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err validator", func() {
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"email":"jamil"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when get author by id", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), authorID).Return(nil, errResp)
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"email":"new@mail.com"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when author doesnt exist", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo)
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), authorID).Return(nil, nil)
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"email":"new@mail.com"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when name belongs to another author", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), authorID).Return(author, nil)
+			authorRepo.EXPECT().GetByName(gomock.Any(), "budi").Return(&domain.Author{ID: 2, Name: "budi"}, nil)
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"name":"budi"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success only updates present fields", func() {
+			repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), authorID).Return(author, nil)
+			authorRepo.EXPECT().Update(gomock.Any(), gomock.Any(), "email").DoAndReturn(func(_ context.Context, req *domain.Author, _ ...string) error {
+				So(req.Email, ShouldEqual, "new@mail.com")
+				So(req.Name, ShouldEqual, "jamil")
+				return nil
+			})
+			err := authorUseCase.PatchAuthor(ctx, authorID, newPatch(`{"email":"new@mail.com"}`))
+			So(err, ShouldBeNil)
+		})
 	})
 }
@@ -3,31 +3,44 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/identifier"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
 	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
-	"golang.org/x/sync/errgroup"
 )
 
 type BookUseCaseImpl interface {
-	GetDetailBook(ctx context.Context, id int) (*domain.DetailBook, error)
+	GetDetailBook(ctx context.Context, id int, currency string) (*domain.DetailBook, error)
 	DeleteBook(ctx context.Context, id int) error
 	UpdateBook(ctx context.Context, req *domain.UpdateBookRequest) error
+	PatchBook(ctx context.Context, id int, patch mergepatch.Patch) error
+	BatchBooks(ctx context.Context, req *domain.BookBatchRequest) (*domain.BookBatchResponse, error)
+	LookupBook(ctx context.Context, req *domain.BookLookupRequest) (*domain.DetailBook, error)
+	ListBooks(ctx context.Context, filter *domain.BookFilter) (*domain.BookListResponse, error)
 	AddBook(ctx context.Context, req *domain.CreateBookRequest) error
+	SetBookPrices(ctx context.Context, req *domain.SetBookPricesRequest) error
 }
 
+const defaultBookListLimit = 20
+
 type bookUseCase struct {
 	config *config.MainConfig
 	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
 }
 
-func NewBookUseCase(config *config.MainConfig, repo repository.RepositoryImpl) BookUseCaseImpl {
+func NewBookUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) BookUseCaseImpl {
 	return &bookUseCase{
 		config: config,
 		repo:   repo,
+		rates:  rates,
 	}
 }
 
@@ -45,7 +58,11 @@ func (s *bookUseCase) DeleteBook(ctx context.Context, id int) error {
 	return s.repo.GetBookRepo().Delete(ctx, id)
 }
 
-func (s *bookUseCase) GetDetailBook(ctx context.Context, id int) (*domain.DetailBook, error) {
+func (s *bookUseCase) GetDetailBook(ctx context.Context, id int, currency string) (*domain.DetailBook, error) {
+	if err := checkCurrency(currency); err != nil {
+		return nil, err
+	}
+
 	book, err := s.repo.GetBookRepo().GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -55,19 +72,140 @@ func (s *bookUseCase) GetDetailBook(ctx context.Context, id int) (*domain.Detail
 		return nil, errors.New("book not found")
 	}
 
-	author, err := s.repo.GetAuthorRepo().GetByID(ctx, book.AuthorID)
+	if err := convertPrices(ctx, s.rates, currency, book); err != nil {
+		return nil, err
+	}
+
+	return s.toDetailBook(ctx, book)
+}
+
+func (s *bookUseCase) LookupBook(ctx context.Context, req *domain.BookLookupRequest) (*domain.DetailBook, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var (
+		book *domain.Book
+		err  error
+	)
+
+	switch {
+	case req.ISBN != "" && req.SKU == "" && req.Barcode == "":
+		isbn13, _ := identifier.ToISBN13(req.ISBN)
+		book, err = s.repo.GetBookRepo().GetByISBN(ctx, isbn13)
+	case req.SKU != "" && req.ISBN == "" && req.Barcode == "":
+		book, err = s.repo.GetBookRepo().GetBySKU(ctx, req.SKU)
+	case req.Barcode != "" && req.ISBN == "" && req.SKU == "":
+		book, err = s.repo.GetBookRepo().GetByBarcode(ctx, req.Barcode)
+	default:
+		return nil, validator.NewValidationError("exactly one of isbn, sku or barcode is required")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if book == nil {
+		return nil, errors.New("book not found")
+	}
+
+	if err := convertPrices(ctx, s.rates, req.Currency, book); err != nil {
+		return nil, err
+	}
+
+	return s.toDetailBook(ctx, book)
+}
+
+// ListBooks searches the catalog, a category filter also matches books in any of its subcategories.
+func (s *bookUseCase) ListBooks(ctx context.Context, filter *domain.BookFilter) (*domain.BookListResponse, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	if filter.Page == 0 {
+		filter.Page = 1
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultBookListLimit
+	}
+
+	filter.Tags = domain.NormalizeTags(filter.Tags)
+
+	if filter.CategoryID != 0 {
+		ids, err := s.repo.GetCategoryRepo().GetDescendantIDs(ctx, filter.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ids) == 0 {
+			return nil, errors.New("category not found")
+		}
+
+		filter.CategoryIDs = ids
+	}
+
+	books, total, err := s.repo.GetBookRepo().List(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := convertPrices(ctx, s.rates, filter.Currency, books...); err != nil {
+		return nil, err
+	}
+
+	return &domain.BookListResponse{
+		Books: books,
+		Total: total,
+		Page:  filter.Page,
+		Limit: filter.Limit,
+	}, nil
+}
+
+func (s *bookUseCase) toDetailBook(ctx context.Context, book *domain.Book) (*domain.DetailBook, error) {
+	authors, err := s.repo.GetAuthorRepo().GetByIDs(ctx, domain.ContributorAuthorIDs(book.Contributors))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string, len(authors))
+	for _, author := range authors {
+		names[author.ID] = author.Name
+	}
+
 	resp := &domain.DetailBook{
-		ID:         book.ID,
-		AuthorID:   book.AuthorID,
-		AuthorName: author.Name,
-		BookName:   book.BookName,
-		Title:      book.Title,
-		Price:      book.Price,
-		CreatedAt:  book.CreatedAt,
+		ID:        book.ID,
+		AuthorID:  book.AuthorID,
+		BookName:  book.BookName,
+		Title:     book.Title,
+		Price:     book.Price,
+		ISBN13:    book.ISBN13,
+		ISBN10:    book.ISBN10,
+		SKU:       book.SKU,
+		Barcode:   book.Barcode,
+		Location:  book.Location,
+		CreatedAt: book.CreatedAt,
+		Stock:     book.Stock,
+		Reserved:  book.Reserved,
+
+		AuthorName:   names[book.AuthorID],
+		Contributors: make([]*domain.DetailContributor, 0, len(book.Contributors)),
+		PublisherID:  book.PublisherID,
+		Publisher:    book.Publisher,
+		Categories:   book.Categories,
+		Tags:         book.Tags,
+
+		PriceConverted: book.PriceConverted,
+		Prices:         book.Prices,
+	}
+
+	for _, contributor := range book.Contributors {
+		resp.Contributors = append(resp.Contributors, &domain.DetailContributor{
+			AuthorID:   contributor.AuthorID,
+			AuthorName: names[contributor.AuthorID],
+			Role:       contributor.Role,
+			Position:   contributor.Position,
+		})
 	}
 
 	return resp, nil
@@ -78,64 +216,290 @@ func (s *bookUseCase) UpdateBook(ctx context.Context, req *domain.UpdateBookRequ
 		return err
 	}
 
-	g, gCtx := errgroup.WithContext(ctx)
-
-	var (
-		book   *domain.Book
-		author *domain.Author
-		err    error
-	)
-
-	g.Go(func() error {
-		book, err = s.repo.GetBookRepo().GetByID(gCtx, req.ID)
+	return s.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		book, changes, err := s.applyBookUpdate(txCtx, req)
 		if err != nil {
 			return err
 		}
 
-		if book == nil {
-			return errors.New("book not found")
+		return writeBookChanges(txCtx, s.repo, book, changes)
+	})
+}
+
+// applyBookUpdate loads the book of req and overwrites it, the tags it creates go in the transaction of ctx.
+func (s *bookUseCase) applyBookUpdate(ctx context.Context, req *domain.UpdateBookRequest) (*domain.Book, *bookChanges, error) {
+	book, err := s.repo.GetBookRepo().GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if book == nil {
+		return nil, nil, errors.New("book not found")
+	}
+
+	if err := checkContributorsExist(ctx, s.repo, domain.NewContributors(req.AuthorID, req.Contributors)); err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := s.overwriteBook(ctx, book, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return book, changes, nil
+}
+
+// overwriteBook overwrites the book with req and returns what has to be written, it writes nothing so
+// that the caller picks the transaction the changes go in.
+func (s *bookUseCase) overwriteBook(ctx context.Context, book *domain.Book, req *domain.UpdateBookRequest) (*bookChanges, error) {
+	price, err := resolvePrice(s.config, req.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	book.SetContributors(req.AuthorID, req.Contributors)
+	book.BookName = req.BookName
+	book.Title = req.Title
+
+	changes := &bookChanges{
+		columns:      []string{"book_name", "title"},
+		contributors: true,
+		reason:       req.PriceReason,
+	}
+	setBasePrice(book, price, changes)
+
+	if req.Location != "" {
+		book.Location = req.Location
+		changes.columns = append(changes.columns, "location")
+	}
+
+	if req.ISBN != "" {
+		if err := book.SetISBN(req.ISBN); err != nil {
+			return nil, err
 		}
+
+		if err := checkISBNAvailable(ctx, s.repo, book); err != nil {
+			return nil, err
+		}
+
+		changes.columns = append(changes.columns, "isbn13", "isbn10", "barcode")
+	}
+
+	if err := setBookTaxonomy(ctx, s.repo, book, &req.BookTaxonomyRequest, changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// bookChanges lists what has to be written for an existing book.
+type bookChanges struct {
+	columns      []string
+	contributors bool
+	categories   bool
+	tags         bool
+	prices       bool
+
+	// history holds the price changes, recorded with reason by changedBy, the signed in user when empty
+	history          []*domain.PriceChange
+	reason           string
+	changedBy        string
+	scheduledPriceID *int
+}
+
+func (c *bookChanges) isEmpty() bool {
+	return len(c.columns) == 0 && !c.contributors && !c.categories && !c.tags && !c.prices && len(c.history) == 0
+}
+
+// saveChanges writes changes in a transaction of their own.
+func (s *bookUseCase) saveChanges(ctx context.Context, book *domain.Book, changes *bookChanges) error {
+	if changes.isEmpty() {
 		return nil
+	}
+
+	return s.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		return writeBookChanges(txCtx, s.repo, book, changes)
 	})
+}
 
-	g.Go(func() error {
-		author, err = s.repo.GetAuthorRepo().GetByID(gCtx, req.AuthorID)
-		if err != nil {
+// writeBookChanges writes changes with the transaction already in ctx.
+func writeBookChanges(ctx context.Context, repo repository.RepositoryImpl, book *domain.Book, changes *bookChanges) error {
+	if len(changes.columns) > 0 {
+		if err := repo.GetBookRepo().Update(ctx, book, changes.columns...); err != nil {
 			return err
 		}
+	}
 
-		if author == nil {
-			return errors.New("author not found")
+	if changes.contributors {
+		if err := repo.GetBookRepo().ReplaceContributors(ctx, book.ID, book.Contributors); err != nil {
+			return err
 		}
+	}
 
+	if changes.categories {
+		if err := repo.GetBookRepo().ReplaceCategories(ctx, book.ID, book.Categories); err != nil {
+			return err
+		}
+	}
+
+	if changes.tags {
+		if err := repo.GetBookRepo().ReplaceTags(ctx, book.ID, book.Tags); err != nil {
+			return err
+		}
+	}
+
+	if changes.prices {
+		if err := repo.GetBookRepo().ReplacePrices(ctx, book.ID, book.Prices); err != nil {
+			return err
+		}
+	}
+
+	if len(changes.history) == 0 {
 		return nil
-	})
+	}
 
-	if err = g.Wait(); err != nil {
-		return err
+	by := changes.changedBy
+	if by == "" {
+		by = changedBy(ctx)
 	}
 
-	return s.repo.GetBookRepo().Update(ctx, &domain.Book{
-		ID:       req.ID,
-		AuthorID: req.AuthorID,
-		BookName: req.BookName,
-		Title:    req.Title,
-		Price:    req.Price,
-	})
+	now := time.Now()
+	for _, change := range changes.history {
+		change.BookID = book.ID
+		change.Reason = changes.reason
+		change.ChangedBy = by
+		change.ScheduledPriceID = changes.scheduledPriceID
+		change.ChangedAt = now
+	}
+
+	return repo.GetPriceRepo().CreateHistory(ctx, changes.history)
 }
 
-func (s *bookUseCase) AddBook(ctx context.Context, req *domain.CreateBookRequest) error {
-	if err := validator.ValidateStruct(req); err != nil {
+func (s *bookUseCase) PatchBook(ctx context.Context, id int, patch mergepatch.Patch) error {
+	for _, field := range patch.NullFields() {
+		// only optional members can be removed with a null
+		switch field {
+		case "isbn", "location", "publisher_id", "category_ids", "tags":
+		default:
+			return validator.NewValidationError(fmt.Sprintf("%s can not be null", field))
+		}
+	}
+
+	var req domain.PatchBookRequest
+	if err := patch.Decode(&req); err != nil {
+		return validator.NewValidationError(err.Error())
+	}
+
+	if err := validator.ValidateStruct(&req); err != nil {
 		return err
 	}
 
-	author, err := s.repo.GetAuthorRepo().GetByID(ctx, req.AuthorID)
+	if patch.Has("contributors") && len(req.Contributors) == 0 {
+		return validator.NewValidationError("contributors can not be empty")
+	}
+
+	book, err := s.repo.GetBookRepo().GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if author == nil {
-		return errors.New("author not found")
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	changes := &bookChanges{}
+	if req.PriceReason != nil {
+		changes.reason = *req.PriceReason
+	}
+
+	// contributors replace the whole list, a lone author_id only swaps the primary author
+	if req.Contributors != nil || req.AuthorID != nil {
+		if req.Contributors != nil {
+			authorID := 0
+			if req.AuthorID != nil {
+				authorID = *req.AuthorID
+			}
+
+			book.SetContributors(authorID, req.Contributors)
+		} else {
+			book.SetPrimaryAuthor(*req.AuthorID)
+		}
+
+		if err := checkContributorsExist(ctx, s.repo, book.Contributors); err != nil {
+			return err
+		}
+
+		changes.contributors = true
+	}
+
+	if req.BookName != nil {
+		book.BookName = *req.BookName
+		changes.columns = append(changes.columns, "book_name")
+	}
+
+	if req.Title != nil {
+		book.Title = *req.Title
+		changes.columns = append(changes.columns, "title")
+	}
+
+	if req.Price != nil {
+		price, err := resolvePrice(s.config, *req.Price)
+		if err != nil {
+			return err
+		}
+
+		setBasePrice(book, price, changes)
+	}
+
+	if req.Location != nil || patch.IsNull("location") {
+		book.Location = ""
+		if req.Location != nil {
+			book.Location = *req.Location
+		}
+
+		changes.columns = append(changes.columns, "location")
+	}
+
+	if req.ISBN != nil || patch.IsNull("isbn") {
+		isbn := ""
+		if req.ISBN != nil {
+			isbn = *req.ISBN
+		}
+
+		if err := book.SetISBN(isbn); err != nil {
+			return err
+		}
+
+		if err := checkISBNAvailable(ctx, s.repo, book); err != nil {
+			return err
+		}
+
+		changes.columns = append(changes.columns, "isbn13", "isbn10", "barcode")
+	}
+
+	if patch.IsNull("publisher_id") {
+		book.PublisherID, book.Publisher = nil, nil
+		changes.columns = append(changes.columns, "publisher_id")
+	}
+
+	if patch.IsNull("category_ids") {
+		req.CategoryIDs = []int{}
+	}
+
+	if patch.IsNull("tags") {
+		req.Tags = []string{}
+	}
+
+	if err := setBookTaxonomy(ctx, s.repo, book, &req.BookTaxonomyRequest, changes); err != nil {
+		return err
+	}
+
+	return s.saveChanges(ctx, book, changes)
+}
+
+func (s *bookUseCase) AddBook(ctx context.Context, req *domain.CreateBookRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
 	}
 
 	// books, err := s.repo.BookRepository.GetLastBook(ctx)
@@ -151,17 +515,365 @@ func (s *bookUseCase) AddBook(ctx context.Context, req *domain.CreateBookRequest
 	// 	Author:   *author,
 	// })
 
+	return s.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		book, err := s.buildNewBook(txCtx, req)
+		if err != nil {
+			return err
+		}
+
+		return createBook(txCtx, s.repo, book)
+	})
+}
+
+// buildNewBook builds the book of req, the tags it creates go in the transaction of ctx so that they
+// are rolled back with a book that fails to be created.
+func (s *bookUseCase) buildNewBook(ctx context.Context, req *domain.CreateBookRequest) (*domain.Book, error) {
+	price, err := resolvePrice(s.config, req.Price)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	book := &domain.Book{
-		AuthorID:  req.AuthorID,
 		BookName:  req.BookName,
 		Title:     req.Title,
-		Price:     req.Price,
+		Price:     price,
+		Location:  req.Location,
 		CreatedAt: time.Now(),
 	}
+	book.SetContributors(req.AuthorID, req.Contributors)
+
+	if err := checkContributorsExist(ctx, s.repo, book.Contributors); err != nil {
+		return nil, err
+	}
+
+	if err := setBookTaxonomy(ctx, s.repo, book, &req.BookTaxonomyRequest, &bookChanges{}); err != nil {
+		return nil, err
+	}
+
+	if err := newBookIdentifiers(ctx, s.config, s.repo, book, req.ISBN); err != nil {
+		return nil, err
+	}
+
+	return book, nil
+}
+
+// BatchBooks applies every operation in order. In atomic mode the operations share one transaction, the
+// first failure rolls back the operations before it and the ones after it are not attempted, otherwise each
+// operation runs in a transaction of its own and failures are reported per item.
+func (s *bookUseCase) BatchBooks(ctx context.Context, req *domain.BookBatchRequest) (*domain.BookBatchResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	resp := &domain.BookBatchResponse{
+		Atomic:  req.Atomic,
+		Results: make([]*domain.BookBatchResult, len(req.Operations)),
+	}
+
+	for i, op := range req.Operations {
+		resp.Results[i] = &domain.BookBatchResult{
+			Index:     i,
+			Operation: op.Operation,
+			ID:        op.ID,
+		}
+	}
+
+	if !req.Atomic {
+		for i, op := range req.Operations {
+			var id int
+			err := s.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+				var err error
+				id, err = s.applyBatchOperation(txCtx, op)
+				return err
+			})
+			resp.Results[i].SetOutcome(id, err)
+		}
+
+		return resp.Summarize(), nil
+	}
+
+	failed := -1
+	err := s.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		for i, op := range req.Operations {
+			id, err := s.applyBatchOperation(txCtx, op)
+			resp.Results[i].SetOutcome(id, err)
+			if err != nil {
+				failed = i
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		for i, result := range resp.Results {
+			switch {
+			case i == failed:
+			case failed >= 0 && i > failed:
+				result.SetOutcome(result.ID, errors.New("not attempted"))
+			default:
+				if result.Operation == domain.BatchOperationCreate {
+					result.ID = 0
+				}
+
+				result.SetOutcome(result.ID, errors.New("batch rolled back"))
+			}
+		}
+	}
+
+	return resp.Summarize(), nil
+}
+
+// applyBatchOperation applies op with the transaction of ctx, BatchBooks decides what it spans.
+func (s *bookUseCase) applyBatchOperation(ctx context.Context, op *domain.BookBatchOperation) (int, error) {
+	if err := validator.ValidateStruct(op); err != nil {
+		return 0, err
+	}
+
+	switch op.Operation {
+	case domain.BatchOperationCreate:
+		req := &domain.CreateBookRequest{
+			AuthorID:     op.AuthorID,
+			Contributors: op.Contributors,
+			BookName:     op.BookName,
+			Title:        op.Title,
+			Price:        op.Price,
+			ISBN:         op.ISBN,
+			Location:     op.Location,
+
+			BookTaxonomyRequest: op.BookTaxonomyRequest,
+		}
+
+		if err := validator.ValidateStruct(req); err != nil {
+			return 0, err
+		}
+
+		book, err := s.buildNewBook(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+
+		if err := createBook(ctx, s.repo, book); err != nil {
+			return 0, err
+		}
+
+		return book.ID, nil
+	case domain.BatchOperationUpdate:
+		req := &domain.UpdateBookRequest{
+			ID:           op.ID,
+			AuthorID:     op.AuthorID,
+			Contributors: op.Contributors,
+			BookName:     op.BookName,
+			Title:        op.Title,
+			Price:        op.Price,
+			PriceReason:  op.PriceReason,
+			ISBN:         op.ISBN,
+			Location:     op.Location,
+
+			BookTaxonomyRequest: op.BookTaxonomyRequest,
+		}
+
+		if err := validator.ValidateStruct(req); err != nil {
+			return 0, err
+		}
+
+		book, changes, err := s.applyBookUpdate(ctx, req)
+		if err != nil {
+			return 0, err
+		}
+
+		return req.ID, writeBookChanges(ctx, s.repo, book, changes)
+	default:
+		if err := s.checkBookExists(ctx, op.ID); err != nil {
+			return 0, err
+		}
+
+		return op.ID, s.repo.GetBookRepo().Delete(ctx, op.ID)
+	}
+}
+
+func (s *bookUseCase) checkBookExists(ctx context.Context, id int) error {
+	book, err := s.repo.GetBookRepo().GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	return nil
+}
+
+// checkContributorsExist makes sure a book is credited to at least one author and that every credited author exists.
+func checkContributorsExist(ctx context.Context, repo repository.RepositoryImpl, contributors []*domain.BookContributor) error {
+	if len(contributors) == 0 {
+		return validator.NewValidationError("author_id or contributors is required")
+	}
+
+	return checkAuthorsExist(ctx, repo, domain.ContributorAuthorIDs(contributors))
+}
+
+func checkAuthorsExist(ctx context.Context, repo repository.RepositoryImpl, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	authors, err := repo.GetAuthorRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(authors) != len(ids) {
+		return errors.New("author not found")
+	}
+
+	return nil
+}
+
+// createBook creates book and counts it once the transaction of ctx commits.
+func createBook(ctx context.Context, repo repository.RepositoryImpl, book *domain.Book) error {
+	if err := repo.GetBookRepo().Create(ctx, book); err != nil {
+		return err
+	}
+
+	repository.AfterCommit(ctx, metrics.BookCreated)
+	return nil
+}
+
+// newBookIdentifiers generates the SKU of a new book and resolves its ISBN and barcode.
+func newBookIdentifiers(ctx context.Context, cfg *config.MainConfig, repo repository.RepositoryImpl, book *domain.Book, isbn string) error {
+	sku, err := identifier.GenerateSKU(cfg.SKUPrefix)
+	if err != nil {
+		return err
+	}
+
+	book.SKU = sku
+
+	if err := book.SetISBN(isbn); err != nil {
+		return err
+	}
+
+	return checkISBNAvailable(ctx, repo, book)
+}
+
+func checkISBNAvailable(ctx context.Context, repo repository.RepositoryImpl, book *domain.Book) error {
+	if book.ISBN13 == nil {
+		return nil
+	}
+
+	existing, err := repo.GetBookRepo().GetByISBN(ctx, *book.ISBN13)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != book.ID {
+		return errors.New("book with this isbn already exist")
+	}
+
+	return nil
+}
+
+// SetBookPrices replaces the list prices of a book in currencies other than its base price currency.
+func (s *bookUseCase) SetBookPrices(ctx context.Context, req *domain.SetBookPricesRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	book, err := s.repo.GetBookRepo().GetByID(ctx, req.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	seen := make(map[string]bool, len(req.Prices))
+	for _, price := range req.Prices {
+		switch {
+		case price.Currency == "":
+			return validator.NewValidationError("currency is required")
+		case price.Currency == book.Price.Currency:
+			return validator.NewValidationError(fmt.Sprintf("%s is the base price currency, update the book price instead", price.Currency))
+		case seen[price.Currency]:
+			return validator.NewValidationError(fmt.Sprintf("%s is listed more than once", price.Currency))
+		}
+
+		seen[price.Currency] = true
+	}
+
+	changes := &bookChanges{reason: req.Reason}
+	for _, listPrice := range book.Prices {
+		if !seen[listPrice.Currency] {
+			removeListPrice(book, listPrice.Currency, changes)
+		}
+	}
+
+	for _, price := range req.Prices {
+		setListPrice(book, price, changes)
+	}
+
+	return s.saveChanges(ctx, book, changes)
+}
+
+// setBookTaxonomy resolves the publisher, categories and tags of req onto book. Absent members leave the
+// book untouched, present ones are recorded in changes.
+func setBookTaxonomy(ctx context.Context, repo repository.RepositoryImpl, book *domain.Book, req *domain.BookTaxonomyRequest, changes *bookChanges) error {
+	if req.PublisherID != nil {
+		publisher, err := repo.GetPublisherRepo().GetByID(ctx, *req.PublisherID)
+		if err != nil {
+			return err
+		}
+
+		if publisher == nil {
+			return errors.New("publisher not found")
+		}
+
+		book.PublisherID, book.Publisher = &publisher.ID, publisher
+		changes.columns = append(changes.columns, "publisher_id")
+	}
+
+	if req.CategoryIDs != nil {
+		ids := uniqueIDs(req.CategoryIDs)
+
+		categories, err := repo.GetCategoryRepo().GetByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		if len(categories) != len(ids) {
+			return errors.New("category not found")
+		}
+
+		book.Categories = categories
+		changes.categories = true
+	}
+
+	if req.Tags != nil {
+		tags, err := repo.GetTagRepo().FindOrCreate(ctx, domain.NormalizeTags(req.Tags))
+		if err != nil {
+			return err
+		}
+
+		book.Tags = tags
+		changes.tags = true
+	}
+
+	return nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
 
-	return s.repo.GetBookRepo().Create(ctx, book)
+	return unique
 }
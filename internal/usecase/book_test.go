@@ -0,0 +1,582 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/mergepatch"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatchBooks(t *testing.T) {
+	Convey("Test batch books", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		var (
+			ctx     = context.Background()
+			errResp = errors.New("error")
+			author  = &domain.Author{ID: 1, Name: "jamil"}
+			book    = &domain.Book{ID: 10, AuthorID: 1, BookName: "buku tulis", Title: "anak anak", Price: money.New(700000, "IDR")}
+
+			newRequest = func(atomic bool) *domain.BookBatchRequest {
+				return &domain.BookBatchRequest{
+					Atomic: atomic,
+					Operations: []*domain.BookBatchOperation{
+						{Operation: domain.BatchOperationCreate, AuthorID: 1, BookName: "buku baru", Title: "anak anak", Price: money.New(700000, "IDR")},
+						{Operation: domain.BatchOperationDelete, ID: 10},
+					},
+				}
+			}
+		)
+
+		type txKey struct{}
+
+		// inTransaction runs the operations in times transactions, their contexts are marked with txKey
+		inTransaction := func(times int) {
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				return fn(context.WithValue(ctx, txKey{}, true))
+			}).Times(times)
+		}
+
+		Convey("resp err validator", func() {
+			resp, err := bookUseCase.BatchBooks(ctx, &domain.BookBatchRequest{})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("best effort reports invalid operation per item", func() {
+			req := newRequest(false)
+			req.Operations[0].BookName = ""
+
+			inTransaction(2)
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			bookRepo.EXPECT().Delete(gomock.Any(), 10).Return(nil)
+
+			resp, err := bookUseCase.BatchBooks(ctx, req)
+			So(err, ShouldBeNil)
+			So(resp.Succeeded, ShouldEqual, 1)
+			So(resp.Failed, ShouldEqual, 1)
+			So(resp.Results[0].Success, ShouldBeFalse)
+			So(resp.Results[1].Success, ShouldBeTrue)
+		})
+
+		Convey("best effort applies every operation", func() {
+			inTransaction(2)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+			bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Book) error {
+				req.ID = 11
+				return nil
+			})
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			bookRepo.EXPECT().Delete(gomock.Any(), 10).Return(nil)
+
+			resp, err := bookUseCase.BatchBooks(ctx, newRequest(false))
+			So(err, ShouldBeNil)
+			So(resp.Failed, ShouldEqual, 0)
+			So(resp.Results[0].ID, ShouldEqual, 11)
+		})
+
+		Convey("atomic rolls back every operation on failure", func() {
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+				bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Book) error {
+					req.ID = 11
+					return nil
+				})
+				bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(nil, errResp)
+				return fn(ctx)
+			})
+
+			resp, err := bookUseCase.BatchBooks(ctx, newRequest(true))
+			So(err, ShouldBeNil)
+			So(resp.Succeeded, ShouldEqual, 0)
+			So(resp.Failed, ShouldEqual, 2)
+			So(resp.Results[0].ID, ShouldEqual, 0)
+			So(resp.Results[0].Error, ShouldEqual, "batch rolled back")
+			So(resp.Results[1].Error, ShouldEqual, errResp.Error())
+		})
+
+		Convey("atomic does not attempt the operations after the failure", func() {
+			req := newRequest(true)
+			req.Operations = append(req.Operations, &domain.BookBatchOperation{Operation: domain.BatchOperationDelete, ID: 12})
+
+			inTransaction(1)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+			bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(nil, nil)
+
+			resp, err := bookUseCase.BatchBooks(ctx, req)
+			So(err, ShouldBeNil)
+			So(resp.Failed, ShouldEqual, 3)
+			So(resp.Results[0].Error, ShouldEqual, "batch rolled back")
+			So(resp.Results[1].Error, ShouldEqual, "book not found")
+			So(resp.Results[2].Error, ShouldEqual, "not attempted")
+		})
+
+		Convey("atomic commit", func() {
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+				bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+				bookRepo.EXPECT().Delete(gomock.Any(), 10).Return(nil)
+				return fn(ctx)
+			})
+
+			resp, err := bookUseCase.BatchBooks(ctx, newRequest(true))
+			So(err, ShouldBeNil)
+			So(resp.Succeeded, ShouldEqual, 2)
+		})
+
+		Convey("atomic updates write with the transaction of the batch", func() {
+			req := &domain.BookBatchRequest{
+				Atomic: true,
+				Operations: []*domain.BookBatchOperation{
+					{Operation: domain.BatchOperationUpdate, ID: 10, AuthorID: 1, BookName: "buku tulis", Title: "anak anak baru", Price: money.New(700000, "IDR")},
+				},
+			}
+
+			inTransaction(1)
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+			bookRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *domain.Book, _ ...string) error {
+				So(ctx.Value(txKey{}), ShouldEqual, true)
+				So(req.Title, ShouldEqual, "anak anak baru")
+				return nil
+			})
+			bookRepo.EXPECT().ReplaceContributors(gomock.Any(), 10, gomock.Any()).DoAndReturn(func(ctx context.Context, _ int, _ []*domain.BookContributor) error {
+				So(ctx.Value(txKey{}), ShouldEqual, true)
+				return nil
+			})
+
+			resp, err := bookUseCase.BatchBooks(ctx, req)
+			So(err, ShouldBeNil)
+			So(resp.Succeeded, ShouldEqual, 1)
+		})
+
+		Convey("best effort reports unknown contributor per item", func() {
+			req := newRequest(false)
+			req.Operations[0].Contributors = []*domain.ContributorRequest{{AuthorID: 2, Role: domain.ContributorRoleTranslator}}
+
+			inTransaction(2)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1, 2}).Return([]*domain.Author{author}, nil)
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			bookRepo.EXPECT().Delete(gomock.Any(), 10).Return(nil)
+
+			resp, err := bookUseCase.BatchBooks(ctx, req)
+			So(err, ShouldBeNil)
+			So(resp.Failed, ShouldEqual, 1)
+			So(resp.Results[0].Error, ShouldEqual, "author not found")
+		})
+	})
+}
+
+func TestAddBook(t *testing.T) {
+	Convey("Test add book", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{SKUPrefix: "BK"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		tagRepo := repositoryMock.NewMockTagRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetTagRepo().Return(tagRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		type txKey struct{}
+
+		var (
+			ctx     = context.Background()
+			errResp = errors.New("error")
+			author  = &domain.Author{ID: 1, Name: "jamil"}
+			req     = &domain.CreateBookRequest{
+				AuthorID: 1,
+				BookName: "buku baru",
+				Title:    "anak anak",
+				Price:    money.New(700000, "IDR"),
+
+				BookTaxonomyRequest: domain.BookTaxonomyRequest{Tags: []string{"Fiksi"}},
+			}
+		)
+
+		inTransaction := func() {
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				return fn(context.WithValue(ctx, txKey{}, true))
+			})
+		}
+
+		Convey("resp err validator", func() {
+			err := bookUseCase.AddBook(ctx, &domain.CreateBookRequest{})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when the book fails to be created", func() {
+			inTransaction()
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+			tagRepo.EXPECT().FindOrCreate(gomock.Any(), []string{"fiksi"}).DoAndReturn(func(ctx context.Context, names []string) ([]*domain.Tag, error) {
+				So(ctx.Value(txKey{}), ShouldEqual, true)
+				return []*domain.Tag{{ID: 3, Name: "fiksi"}}, nil
+			})
+			bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errResp)
+
+			err := bookUseCase.AddBook(ctx, req)
+			So(err, ShouldEqual, errResp)
+		})
+
+		Convey("resp success creates the book with its tags in one transaction", func() {
+			inTransaction()
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{author}, nil)
+			tagRepo.EXPECT().FindOrCreate(gomock.Any(), []string{"fiksi"}).Return([]*domain.Tag{{ID: 3, Name: "fiksi"}}, nil)
+			bookRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, book *domain.Book) error {
+				So(ctx.Value(txKey{}), ShouldEqual, true)
+				So(book.Tags, ShouldHaveLength, 1)
+				So(book.SKU, ShouldStartWith, "BK-")
+				return nil
+			})
+
+			err := bookUseCase.AddBook(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestLookupBook(t *testing.T) {
+	Convey("Test lookup book", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		var (
+			ctx    = context.Background()
+			isbn13 = "9780306406157"
+			author = &domain.Author{ID: 1, Name: "jamil"}
+			book   = &domain.Book{ID: 10, AuthorID: 1, BookName: "buku tulis", ISBN13: &isbn13, SKU: "BK-7KQ2M9XA", Barcode: isbn13,
+				Contributors: []*domain.BookContributor{
+					{BookID: 10, AuthorID: 1, Role: domain.ContributorRoleAuthor, Position: 1},
+					{BookID: 10, AuthorID: 2, Role: domain.ContributorRoleTranslator, Position: 2},
+				},
+			}
+		)
+
+		Convey("resp err when no identifier is given", func() {
+			resp, err := bookUseCase.LookupBook(ctx, &domain.BookLookupRequest{})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when several identifiers are given", func() {
+			resp, err := bookUseCase.LookupBook(ctx, &domain.BookLookupRequest{SKU: "BK-7KQ2M9XA", Barcode: isbn13})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when isbn checksum is invalid", func() {
+			resp, err := bookUseCase.LookupBook(ctx, &domain.BookLookupRequest{ISBN: "0-306-40615-3"})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetBySKU(gomock.Any(), "BK-UNKNOWN").Return(nil, nil)
+			resp, err := bookUseCase.LookupBook(ctx, &domain.BookLookupRequest{SKU: "BK-UNKNOWN"})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp success looks up isbn-10 by its isbn-13 form", func() {
+			bookRepo.EXPECT().GetByISBN(gomock.Any(), isbn13).Return(book, nil)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1, 2}).Return([]*domain.Author{author, {ID: 2, Name: "budi"}}, nil)
+			resp, err := bookUseCase.LookupBook(ctx, &domain.BookLookupRequest{ISBN: "0-306-40615-2"})
+			So(err, ShouldBeNil)
+			So(resp.AuthorName, ShouldEqual, "jamil")
+			So(resp.SKU, ShouldEqual, "BK-7KQ2M9XA")
+			So(len(resp.Contributors), ShouldEqual, 2)
+			So(resp.Contributors[1].AuthorName, ShouldEqual, "budi")
+			So(resp.Contributors[1].Role, ShouldEqual, domain.ContributorRoleTranslator)
+		})
+	})
+}
+
+func TestListBooks(t *testing.T) {
+	Convey("Test list books", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		var (
+			ctx   = context.Background()
+			books = []*domain.Book{{ID: 10, BookName: "buku tulis"}}
+		)
+
+		Convey("resp err validator", func() {
+			resp, err := bookUseCase.ListBooks(ctx, &domain.BookFilter{Limit: 1000})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when category doesnt exist", func() {
+			categoryRepo.EXPECT().GetDescendantIDs(gomock.Any(), 5).Return(nil, nil)
+			resp, err := bookUseCase.ListBooks(ctx, &domain.BookFilter{CategoryID: 5})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp success filters by category and its descendants", func() {
+			categoryRepo.EXPECT().GetDescendantIDs(gomock.Any(), 5).Return([]int{5, 6, 7}, nil)
+			bookRepo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, filter *domain.BookFilter) ([]*domain.Book, int64, error) {
+				So(filter.CategoryIDs, ShouldResemble, []int{5, 6, 7})
+				So(filter.Tags, ShouldResemble, []string{"sci fi"})
+				So(filter.Offset(), ShouldEqual, 20)
+				return books, 21, nil
+			})
+
+			resp, err := bookUseCase.ListBooks(ctx, &domain.BookFilter{CategoryID: 5, Tags: []string{"Sci  Fi", "sci fi"}, Page: 2})
+			So(err, ShouldBeNil)
+			So(resp.Total, ShouldEqual, 21)
+			So(resp.Limit, ShouldEqual, 20)
+			So(len(resp.Books), ShouldEqual, 1)
+		})
+	})
+}
+
+func TestSetBookPrices(t *testing.T) {
+	Convey("Test set book prices", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		priceRepo := repositoryMock.NewMockPriceRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetPriceRepo().Return(priceRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		var (
+			ctx  = context.Background()
+			book = &domain.Book{ID: 10, Price: money.New(700000, "IDR"), Prices: []*domain.BookPrice{
+				{BookID: 10, Currency: "SGD", Amount: 80},
+				{BookID: 10, Currency: "EUR", Amount: 50},
+			}}
+		)
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(nil, nil)
+
+			err := bookUseCase.SetBookPrices(ctx, &domain.SetBookPricesRequest{BookID: 10})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err price in base currency", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+
+			err := bookUseCase.SetBookPrices(ctx, &domain.SetBookPricesRequest{BookID: 10, Prices: []money.Money{money.New(800000, "IDR")}})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err duplicate currency", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+
+			err := bookUseCase.SetBookPrices(ctx, &domain.SetBookPricesRequest{BookID: 10, Prices: []money.Money{money.New(99, "SGD"), money.New(100, "SGD")}})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success records every change", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				return fn(ctx)
+			})
+			bookRepo.EXPECT().ReplacePrices(gomock.Any(), 10, gomock.Any()).DoAndReturn(func(ctx context.Context, bookID int, prices []*domain.BookPrice) error {
+				So(prices, ShouldHaveLength, 2)
+				So(prices[0].Money(), ShouldResemble, money.New(99, "SGD"))
+				So(prices[1].Money(), ShouldResemble, money.New(45, "USD"))
+				return nil
+			})
+			priceRepo.EXPECT().CreateHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, changes []*domain.PriceChange) error {
+				So(changes, ShouldHaveLength, 3)
+				So(*changes[0].OldCurrency, ShouldEqual, "EUR")
+				So(changes[0].NewAmount, ShouldBeNil)
+				So(*changes[1].OldAmount, ShouldEqual, 80)
+				So(*changes[1].NewAmount, ShouldEqual, 99)
+				So(changes[2].OldAmount, ShouldBeNil)
+				So(*changes[2].NewCurrency, ShouldEqual, "USD")
+				for _, change := range changes {
+					So(change.BookID, ShouldEqual, 10)
+					So(change.Kind, ShouldEqual, domain.PriceKindList)
+					So(change.Reason, ShouldEqual, "summer sale")
+					So(change.ChangedBy, ShouldEqual, "system")
+				}
+				return nil
+			})
+
+			err := bookUseCase.SetBookPrices(ctx, &domain.SetBookPricesRequest{BookID: 10, Prices: []money.Money{money.New(99, "SGD"), money.New(45, "USD")}, Reason: "summer sale"})
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestPatchBook(t *testing.T) {
+	Convey("Test patch book", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+		tagRepo := repositoryMock.NewMockTagRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+		repoMock.EXPECT().GetTagRepo().Return(tagRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+
+		bookUseCase := NewBookUseCase(config, repoMock, nil)
+
+		var (
+			ctx         = context.Background()
+			isbn13      = "9780306406157"
+			publisherID = 3
+			book        = &domain.Book{
+				ID:           10,
+				BookName:     "dune",
+				Title:        "Dune",
+				Price:        money.New(700000, "IDR"),
+				Location:     "A-01",
+				ISBN13:       &isbn13,
+				Barcode:      isbn13,
+				PublisherID:  &publisherID,
+				Publisher:    &domain.Publisher{ID: publisherID},
+				Contributors: domain.NewContributors(1, nil),
+				Categories:   []*domain.Category{{ID: 4}},
+				Tags:         []*domain.Tag{{ID: 5, Name: "scifi"}},
+			}
+		)
+
+		newPatch := func(body string) mergepatch.Patch {
+			patch, err := mergepatch.Parse([]byte(body))
+			So(err, ShouldBeNil)
+			return patch
+		}
+
+		inTransaction := func() {
+			trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+				return fn(ctx)
+			})
+		}
+
+		Convey("resp err when a required field is null", func() {
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"title":null}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when field is unknown", func() {
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"sku":"BK-1"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when contributors are empty", func() {
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"contributors":[]}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(nil, nil)
+
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"title":"Dune Messiah"}`))
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success without writing an empty patch", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{}`))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("resp success only updates present fields", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			inTransaction()
+			bookRepo.EXPECT().Update(gomock.Any(), gomock.Any(), "title").DoAndReturn(func(_ context.Context, req *domain.Book, _ ...string) error {
+				So(req.Title, ShouldEqual, "Dune Messiah")
+				So(req.BookName, ShouldEqual, "dune")
+				So(req.Location, ShouldEqual, "A-01")
+				return nil
+			})
+
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"title":"Dune Messiah"}`))
+			So(err, ShouldBeNil)
+		})
+
+		Convey("resp success null removes optional fields", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			categoryRepo.EXPECT().GetByIDs(gomock.Any(), []int{}).Return(nil, nil)
+			tagRepo.EXPECT().FindOrCreate(gomock.Any(), gomock.Len(0)).Return(nil, nil)
+			inTransaction()
+			bookRepo.EXPECT().Update(gomock.Any(), gomock.Any(), "location", "isbn13", "isbn10", "barcode", "publisher_id").DoAndReturn(func(_ context.Context, req *domain.Book, _ ...string) error {
+				So(req.Location, ShouldBeEmpty)
+				So(req.ISBN13, ShouldBeNil)
+				So(req.ISBN10, ShouldBeNil)
+				So(req.Barcode, ShouldNotEqual, isbn13)
+				So(req.Barcode, ShouldHaveLength, 13)
+				So(req.PublisherID, ShouldBeNil)
+				So(req.Title, ShouldEqual, "Dune")
+				return nil
+			})
+			bookRepo.EXPECT().ReplaceCategories(gomock.Any(), 10, gomock.Len(0)).Return(nil)
+			bookRepo.EXPECT().ReplaceTags(gomock.Any(), 10, gomock.Len(0)).Return(nil)
+
+			err := bookUseCase.PatchBook(ctx, 10, newPatch(`{"location":null,"isbn":null,"publisher_id":null,"category_ids":null,"tags":null}`))
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type CategoryUseCaseImpl interface {
+	CreateCategory(ctx context.Context, req *domain.CreateCategoryRequest) error
+	GetCategory(ctx context.Context, id int) (*domain.Category, error)
+	GetCategoryTree(ctx context.Context) ([]*domain.CategoryNode, error)
+	UpdateCategory(ctx context.Context, req *domain.UpdateCategoryRequest) error
+	DeleteCategory(ctx context.Context, id int) error
+}
+
+type categoryUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewCategoryUseCase(config *config.MainConfig, repo repository.RepositoryImpl) CategoryUseCaseImpl {
+	return &categoryUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *categoryUseCase) CreateCategory(ctx context.Context, req *domain.CreateCategoryRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := u.checkParent(ctx, req.ParentID); err != nil {
+		return err
+	}
+
+	if err := u.checkNameAvailable(ctx, req.ParentID, req.Name, 0); err != nil {
+		return err
+	}
+
+	return u.repo.GetCategoryRepo().Create(ctx, &domain.Category{
+		ParentID:  req.ParentID,
+		Name:      req.Name,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (u *categoryUseCase) GetCategory(ctx context.Context, id int) (*domain.Category, error) {
+	category, err := u.repo.GetCategoryRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if category == nil {
+		return nil, errors.New("category not found")
+	}
+
+	return category, nil
+}
+
+func (u *categoryUseCase) GetCategoryTree(ctx context.Context) ([]*domain.CategoryNode, error) {
+	categories, err := u.repo.GetCategoryRepo().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildCategoryTree(categories), nil
+}
+
+func (u *categoryUseCase) UpdateCategory(ctx context.Context, req *domain.UpdateCategoryRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	category, err := u.GetCategory(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if req.ParentID != nil {
+		if err := u.checkParent(ctx, req.ParentID); err != nil {
+			return err
+		}
+
+		// a category can not become its own ancestor
+		descendants, err := u.repo.GetCategoryRepo().GetDescendantIDs(ctx, category.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range descendants {
+			if id == *req.ParentID {
+				return validator.NewValidationError("category can not be moved under itself or its subcategories")
+			}
+		}
+	}
+
+	if err := u.checkNameAvailable(ctx, req.ParentID, req.Name, category.ID); err != nil {
+		return err
+	}
+
+	category.ParentID = req.ParentID
+	category.Name = req.Name
+
+	return u.repo.GetCategoryRepo().Update(ctx, category)
+}
+
+// DeleteCategory removes a leaf category and unlinks it from its books.
+func (u *categoryUseCase) DeleteCategory(ctx context.Context, id int) error {
+	if _, err := u.GetCategory(ctx, id); err != nil {
+		return err
+	}
+
+	hasChildren, err := u.repo.GetCategoryRepo().HasChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if hasChildren {
+		return errors.New("category still has subcategories")
+	}
+
+	return u.repo.GetCategoryRepo().Delete(ctx, id)
+}
+
+func (u *categoryUseCase) checkParent(ctx context.Context, parentID *int) error {
+	if parentID == nil {
+		return nil
+	}
+
+	parent, err := u.repo.GetCategoryRepo().GetByID(ctx, *parentID)
+	if err != nil {
+		return err
+	}
+
+	if parent == nil {
+		return errors.New("parent category not found")
+	}
+
+	return nil
+}
+
+func (u *categoryUseCase) checkNameAvailable(ctx context.Context, parentID *int, name string, id int) error {
+	existing, err := u.repo.GetCategoryRepo().GetByName(ctx, parentID, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return errors.New("category already exist")
+	}
+
+	return nil
+}
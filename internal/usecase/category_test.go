@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCategory(t *testing.T) {
+	Convey("Test create category", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+
+		categoryUseCase := NewCategoryUseCase(config, repoMock)
+
+		var (
+			ctx      = context.Background()
+			parentID = 1
+			req      = &domain.CreateCategoryRequest{ParentID: &parentID, Name: "fantasy"}
+		)
+
+		Convey("resp err validator", func() {
+			err := categoryUseCase.CreateCategory(ctx, &domain.CreateCategoryRequest{Name: " "})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when parent doesnt exist", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(nil, nil)
+			err := categoryUseCase.CreateCategory(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when sibling has the same name", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(&domain.Category{ID: 1, Name: "fiction"}, nil)
+			categoryRepo.EXPECT().GetByName(gomock.Any(), &parentID, "fantasy").Return(&domain.Category{ID: 2, Name: "Fantasy"}, nil)
+			err := categoryUseCase.CreateCategory(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(&domain.Category{ID: 1, Name: "fiction"}, nil)
+			categoryRepo.EXPECT().GetByName(gomock.Any(), &parentID, "fantasy").Return(nil, nil)
+			categoryRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			err := categoryUseCase.CreateCategory(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestUpdateCategory(t *testing.T) {
+	Convey("Test update category", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+
+		categoryUseCase := NewCategoryUseCase(config, repoMock)
+
+		var (
+			ctx      = context.Background()
+			parentID = 3
+			req      = &domain.UpdateCategoryRequest{ID: 1, ParentID: &parentID, Name: "fiction"}
+			category = &domain.Category{ID: 1, Name: "fiction"}
+		)
+
+		Convey("resp err when category doesnt exist", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := categoryUseCase.UpdateCategory(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when moved under its own subcategory", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(category, nil)
+			categoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(&domain.Category{ID: 3, ParentID: &category.ID, Name: "fantasy"}, nil)
+			categoryRepo.EXPECT().GetDescendantIDs(gomock.Any(), 1).Return([]int{1, 2, 3}, nil)
+			err := categoryUseCase.UpdateCategory(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success moves category", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(category, nil)
+			categoryRepo.EXPECT().GetByID(gomock.Any(), parentID).Return(&domain.Category{ID: 3, Name: "books"}, nil)
+			categoryRepo.EXPECT().GetDescendantIDs(gomock.Any(), 1).Return([]int{1, 2}, nil)
+			categoryRepo.EXPECT().GetByName(gomock.Any(), &parentID, "fiction").Return(nil, nil)
+			categoryRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Category) error {
+				So(*req.ParentID, ShouldEqual, 3)
+				return nil
+			})
+			err := categoryUseCase.UpdateCategory(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	Convey("Test delete category", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+
+		categoryUseCase := NewCategoryUseCase(config, repoMock)
+
+		var (
+			ctx      = context.Background()
+			errResp  = errors.New("error")
+			category = &domain.Category{ID: 1, Name: "fiction"}
+		)
+
+		Convey("resp err when get category by id", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errResp)
+			err := categoryUseCase.DeleteCategory(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when category has subcategories", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(category, nil)
+			categoryRepo.EXPECT().HasChildren(gomock.Any(), 1).Return(true, nil)
+			err := categoryUseCase.DeleteCategory(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			categoryRepo.EXPECT().GetByID(gomock.Any(), 1).Return(category, nil)
+			categoryRepo.EXPECT().HasChildren(gomock.Any(), 1).Return(false, nil)
+			categoryRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			err := categoryUseCase.DeleteCategory(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type CycleCountUseCaseImpl interface {
+	CreateCycleCount(ctx context.Context, req *domain.CreateCycleCountRequest) (*domain.CycleCount, error)
+	GetCycleCount(ctx context.Context, id int) (*domain.CycleCount, error)
+	ListCycleCounts(ctx context.Context, filter *domain.CycleCountFilter) ([]*domain.CycleCount, error)
+	SubmitCounts(ctx context.Context, req *domain.SubmitCountsRequest) (*domain.CycleCount, error)
+	GetVariance(ctx context.Context, id int) (*domain.CycleCountVariance, error)
+	ApproveCycleCount(ctx context.Context, id int) error
+	CancelCycleCount(ctx context.Context, id int) error
+}
+
+type cycleCountUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewCycleCountUseCase(config *config.MainConfig, repo repository.RepositoryImpl) CycleCountUseCaseImpl {
+	return &cycleCountUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// CreateCycleCount snapshots the stock of the books to count, a book can only be in one open count.
+func (u *cycleCountUseCase) CreateCycleCount(ctx context.Context, req *domain.CreateCycleCountRequest) (*domain.CycleCount, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	if req.Location != "" && len(req.BookIDs) > 0 {
+		return nil, validator.NewValidationError("count either a location or a set of books")
+	}
+
+	var count *domain.CycleCount
+	err := u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		books, err := u.books(txCtx, req)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]int, 0, len(books))
+		for _, book := range books {
+			ids = append(ids, book.ID)
+		}
+
+		open, err := u.repo.GetCycleCountRepo().OpenBookIDs(txCtx, ids)
+		if err != nil {
+			return err
+		}
+
+		if len(open) > 0 {
+			return validator.NewValidationError(fmt.Sprintf("book %d is already in an open count", open[0]))
+		}
+
+		now := time.Now()
+		count = &domain.CycleCount{
+			Location:  req.Location,
+			Status:    domain.CycleCountOpen,
+			Note:      req.Note,
+			Version:   1,
+			CreatedBy: changedBy(txCtx),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		for _, book := range books {
+			count.Lines = append(count.Lines, &domain.CycleCountLine{
+				BookID:   book.ID,
+				Title:    book.Title,
+				Expected: book.Stock,
+			})
+		}
+
+		return u.repo.GetCycleCountRepo().Create(txCtx, count)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
+
+func (u *cycleCountUseCase) books(ctx context.Context, req *domain.CreateCycleCountRequest) ([]*domain.Book, error) {
+	if req.Location != "" {
+		books, err := u.repo.GetBookRepo().GetByLocation(ctx, req.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(books) == 0 {
+			return nil, validator.NewValidationError(fmt.Sprintf("there are no books at %s", req.Location))
+		}
+
+		return books, nil
+	}
+
+	books, err := u.repo.GetBookRepo().GetByIDs(ctx, req.BookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(books) != len(req.BookIDs) {
+		return nil, errors.New("book not found")
+	}
+
+	return books, nil
+}
+
+func (u *cycleCountUseCase) GetCycleCount(ctx context.Context, id int) (*domain.CycleCount, error) {
+	count, err := u.repo.GetCycleCountRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == nil {
+		return nil, errors.New("cycle count not found")
+	}
+
+	return count, nil
+}
+
+func (u *cycleCountUseCase) ListCycleCounts(ctx context.Context, filter *domain.CycleCountFilter) ([]*domain.CycleCount, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetCycleCountRepo().List(ctx, filter)
+}
+
+// SubmitCounts records counted quantities, counting a book again overwrites its earlier count.
+func (u *cycleCountUseCase) SubmitCounts(ctx context.Context, req *domain.SubmitCountsRequest) (*domain.CycleCount, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var count *domain.CycleCount
+	err := u.edit(ctx, req.ID, req.Version, func(txCtx context.Context, locked *domain.CycleCount) error {
+		count = locked
+
+		now := time.Now()
+		by := changedBy(txCtx)
+		for _, item := range req.Counts {
+			line := count.Line(item.BookID)
+			if line == nil {
+				return validator.NewValidationError(fmt.Sprintf("book %d is not in the count", item.BookID))
+			}
+
+			line.Counted, line.CountedBy, line.CountedAt = item.Counted, by, &now
+			if err := u.repo.GetCycleCountRepo().UpdateLineCount(txCtx, line); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return count, nil
+}
+
+func (u *cycleCountUseCase) GetVariance(ctx context.Context, id int) (*domain.CycleCountVariance, error) {
+	count, err := u.GetCycleCount(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return count.Variance(), nil
+}
+
+// ApproveCycleCount posts one adjustment per book whose count differs from the snapshot, the adjustment
+// is applied on top of whatever moved since the count started.
+func (u *cycleCountUseCase) ApproveCycleCount(ctx context.Context, id int) error {
+	return u.edit(ctx, id, 0, func(txCtx context.Context, count *domain.CycleCount) error {
+		variance := count.Variance()
+		if variance.Uncounted > 0 {
+			return validator.NewValidationError(fmt.Sprintf("%d books are not counted yet", variance.Uncounted))
+		}
+
+		now := time.Now()
+		by := changedBy(txCtx)
+
+		var movements []*domain.StockMovement
+		for _, line := range variance.Lines {
+			if line.Variance == 0 {
+				continue
+			}
+
+			movements = append(movements, &domain.StockMovement{
+				BookID:        line.BookID,
+				Type:          domain.StockMovementCountAdjustment,
+				Quantity:      line.Variance,
+				ReferenceType: domain.StockReferenceCycleCount,
+				ReferenceID:   &count.ID,
+				CreatedBy:     by,
+				CreatedAt:     now,
+			})
+		}
+
+		if err := recordMovements(txCtx, u.repo, movements); err != nil {
+			return err
+		}
+
+		count.Status, count.ApprovedBy, count.ClosedAt = domain.CycleCountApproved, by, &now
+
+		return u.repo.GetCycleCountRepo().CloseLines(txCtx, count.ID)
+	}, "status", "approved_by", "closed_at")
+}
+
+func (u *cycleCountUseCase) CancelCycleCount(ctx context.Context, id int) error {
+	return u.edit(ctx, id, 0, func(txCtx context.Context, count *domain.CycleCount) error {
+		now := time.Now()
+		count.Status, count.ClosedAt = domain.CycleCountCancelled, &now
+
+		return u.repo.GetCycleCountRepo().CloseLines(txCtx, count.ID)
+	}, "status", "closed_at")
+}
+
+// edit locks an open count, runs change on it and bumps its version. A non zero version must match the
+// current one so an edit made from a stale copy is refused.
+func (u *cycleCountUseCase) edit(ctx context.Context, id, version int, change func(txCtx context.Context, count *domain.CycleCount) error, columns ...string) error {
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		count, err := u.repo.GetCycleCountRepo().GetByIDForUpdate(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if count == nil {
+			return errors.New("cycle count not found")
+		}
+
+		if count.Status != domain.CycleCountOpen {
+			return validator.NewValidationError(fmt.Sprintf("cycle count is %s", count.Status))
+		}
+
+		if version != 0 && version != count.Version {
+			return validator.NewValidationError(fmt.Sprintf("cycle count changed since version %d, reload it and count again", version))
+		}
+
+		if err := change(txCtx, count); err != nil {
+			return err
+		}
+
+		count.Version++
+		count.UpdatedAt = time.Now()
+
+		return u.repo.GetCycleCountRepo().Update(txCtx, count, append([]string{"version", "updated_at"}, columns...)...)
+	})
+}
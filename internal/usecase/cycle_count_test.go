@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateCycleCount(t *testing.T) {
+	Convey("Test create cycle count", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		countRepo := repositoryMock.NewMockCycleCountRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetCycleCountRepo().Return(countRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		countUseCase := NewCycleCountUseCase(config, repoMock)
+
+		var (
+			ctx   = context.Background()
+			books = []*domain.Book{{ID: 10, Title: "Dune", Stock: 7}, {ID: 11, Title: "Emma", Stock: 0}}
+		)
+
+		Convey("resp err neither location nor books", func() {
+			_, err := countUseCase.CreateCycleCount(ctx, &domain.CreateCycleCountRequest{})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err both location and books", func() {
+			_, err := countUseCase.CreateCycleCount(ctx, &domain.CreateCycleCountRequest{Location: "A1", BookIDs: []int{10}})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err book in another open count", func() {
+			bookRepo.EXPECT().GetByLocation(gomock.Any(), "A1").Return(books, nil)
+			countRepo.EXPECT().OpenBookIDs(gomock.Any(), []int{10, 11}).Return([]int{11}, nil)
+
+			_, err := countUseCase.CreateCycleCount(ctx, &domain.CreateCycleCountRequest{Location: "A1"})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success snapshots the stock", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return(books, nil)
+			countRepo.EXPECT().OpenBookIDs(gomock.Any(), []int{10, 11}).Return(nil, nil)
+			countRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			count, err := countUseCase.CreateCycleCount(ctx, &domain.CreateCycleCountRequest{BookIDs: []int{10, 11}})
+			So(err, ShouldBeNil)
+			So(count.Status, ShouldEqual, domain.CycleCountOpen)
+			So(count.Version, ShouldEqual, 1)
+			So(count.Lines[0].Expected, ShouldEqual, 7)
+			So(count.Lines[1].Title, ShouldEqual, "Emma")
+		})
+	})
+}
+
+func TestCycleCountSubmitAndApprove(t *testing.T) {
+	Convey("Test cycle count submit and approve", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		countRepo := repositoryMock.NewMockCycleCountRepositoryImpl(ctrl)
+		stockRepo := repositoryMock.NewMockStockRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetCycleCountRepo().Return(countRepo).AnyTimes()
+		repoMock.EXPECT().GetStockRepo().Return(stockRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		countUseCase := NewCycleCountUseCase(config, repoMock)
+
+		var (
+			ctx     = context.Background()
+			five    = 5
+			counted = 4
+			count   = &domain.CycleCount{
+				ID:      2,
+				Status:  domain.CycleCountOpen,
+				Version: 3,
+				Lines: []*domain.CycleCountLine{
+					{ID: 20, BookID: 10, Expected: 7},
+					{ID: 21, BookID: 11, Expected: 5, Counted: &five},
+				},
+			}
+		)
+
+		Convey("resp err stale version", func() {
+			countRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 2).Return(count, nil)
+
+			_, err := countUseCase.SubmitCounts(ctx, &domain.SubmitCountsRequest{
+				ID:      2,
+				Version: 2,
+				Counts:  []*domain.CountedQuantity{{BookID: 10, Counted: &counted}},
+			})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success submit bumps the version", func() {
+			countRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 2).Return(count, nil)
+			countRepo.EXPECT().UpdateLineCount(gomock.Any(), count.Lines[0]).Return(nil)
+			countRepo.EXPECT().Update(gomock.Any(), count, "version", "updated_at").Return(nil)
+
+			resp, err := countUseCase.SubmitCounts(ctx, &domain.SubmitCountsRequest{
+				ID:      2,
+				Version: 3,
+				Counts:  []*domain.CountedQuantity{{BookID: 10, Counted: &counted}},
+			})
+			So(err, ShouldBeNil)
+			So(resp.Version, ShouldEqual, 4)
+
+			variance := resp.Variance()
+			So(variance.Uncounted, ShouldEqual, 0)
+			So(variance.NetVariance, ShouldEqual, -3)
+		})
+
+		Convey("resp err approve with uncounted books", func() {
+			countRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 2).Return(count, nil)
+
+			err := countUseCase.ApproveCycleCount(ctx, 2)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success approve posts the variances", func() {
+			count.Lines[0].Counted = &counted
+			countRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 2).Return(count, nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements, ShouldHaveLength, 1)
+				So(movements[0].BookID, ShouldEqual, 10)
+				So(movements[0].Type, ShouldEqual, domain.StockMovementCountAdjustment)
+				So(movements[0].Quantity, ShouldEqual, -3)
+				return nil
+			})
+			countRepo.EXPECT().CloseLines(gomock.Any(), 2).Return(nil)
+			countRepo.EXPECT().Update(gomock.Any(), count, "version", "updated_at", "status", "approved_by", "closed_at").Return(nil)
+
+			err := countUseCase.ApproveCycleCount(ctx, 2)
+			So(err, ShouldBeNil)
+			So(count.Status, ShouldEqual, domain.CycleCountApproved)
+		})
+
+		Convey("resp err approve an approved count", func() {
+			count.Status = domain.CycleCountApproved
+			countRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 2).Return(count, nil)
+
+			err := countUseCase.ApproveCycleCount(ctx, 2)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/forecast"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+const defaultForecastLimit = 100
+
+type ForecastUseCaseImpl interface {
+	ForecastDemand(ctx context.Context, req *domain.ForecastRequest) (*domain.ForecastResponse, error)
+}
+
+type forecastUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewForecastUseCase(config *config.MainConfig, repo repository.RepositoryImpl) ForecastUseCaseImpl {
+	return &forecastUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// ForecastDemand forecasts from the shipments of the last History days up to yesterday. The history of
+// a book starts at its first shipment in that window, so titles new to the catalog are not averaged
+// down by the days before they sold.
+func (u *forecastUseCase) ForecastDemand(ctx context.Context, req *domain.ForecastRequest) (*domain.ForecastResponse, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	opts, history := u.options(req)
+
+	if req.BookID != 0 {
+		book, err := u.repo.GetBookRepo().GetByID(ctx, req.BookID)
+		if err != nil {
+			return nil, err
+		}
+
+		if book == nil {
+			return nil, errors.New("book not found")
+		}
+	}
+
+	today := domain.BucketStart(time.Now(), domain.BucketDay)
+
+	demand, err := u.repo.GetForecastRepo().ListDemand(ctx, today.AddDate(0, 0, -history), today, req.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make(map[int][]float64)
+	if req.BookID != 0 {
+		series[req.BookID] = nil
+	}
+
+	for i := 0; i < len(demand); {
+		bookID, first := demand[i].BookID, demand[i].Day
+
+		days := make([]float64, domain.DaysBetween(first, today))
+		for ; i < len(demand) && demand[i].BookID == bookID; i++ {
+			days[domain.DaysBetween(first, demand[i].Day)] = float64(demand[i].Quantity)
+		}
+
+		series[bookID] = days
+	}
+
+	resp := &domain.ForecastResponse{
+		Books: []*domain.BookForecast{},
+		Page:  max(req.Page, 1),
+		Limit: req.Limit,
+	}
+
+	if resp.Limit == 0 {
+		resp.Limit = defaultForecastLimit
+	}
+
+	if len(series) == 0 {
+		return resp, nil
+	}
+
+	bookIDs := sortedKeys(series)
+
+	books, err := u.repo.GetBookRepo().GetByIDs(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	suppliers, err := u.repo.GetForecastRepo().ListSuppliers(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	onOrder, err := u.repo.GetForecastRepo().ListOnOrder(ctx, bookIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	supplierOf := make(map[int]*domain.BookSupplier, len(suppliers))
+	for _, supplier := range suppliers {
+		supplierOf[supplier.BookID] = supplier
+	}
+
+	ordered := make(map[int]int64, len(onOrder))
+	for _, row := range onOrder {
+		ordered[row.BookID] = row.Quantity
+	}
+
+	var forecasts []*domain.BookForecast
+	for _, book := range books {
+		f, err := u.forecast(book, series[book.ID], opts, supplierOf[book.ID], ordered[book.ID])
+		if err != nil {
+			return nil, err
+		}
+
+		if req.ReorderOnly && f.SuggestedQuantity == 0 {
+			continue
+		}
+
+		forecasts = append(forecasts, f)
+	}
+
+	sort.Slice(forecasts, func(i, j int) bool { return forecasts[i].BookID < forecasts[j].BookID })
+
+	resp.Total = len(forecasts)
+	if offset := (resp.Page - 1) * resp.Limit; offset < len(forecasts) {
+		resp.Books = forecasts[offset:min(offset+resp.Limit, len(forecasts))]
+	}
+
+	return resp, nil
+}
+
+func (u *forecastUseCase) forecast(book *domain.Book, history []float64, opts forecast.Options, supplier *domain.BookSupplier, onOrder int64) (*domain.BookForecast, error) {
+	f := &domain.BookForecast{
+		BookID:       book.ID,
+		Title:        book.Title,
+		Method:       opts.Method,
+		HistoryDays:  len(history),
+		Forecast:     make([]float64, opts.Horizon),
+		Available:    book.Stock - book.Reserved,
+		OnOrder:      onOrder,
+		LeadTimeDays: u.config.ForecastLeadTimeDays,
+	}
+
+	if supplier != nil {
+		f.SupplierID = &supplier.SupplierID
+		if supplier.LeadTimeDays > 0 {
+			f.LeadTimeDays = supplier.LeadTimeDays
+		}
+	}
+
+	if len(history) == 0 {
+		return f, nil
+	}
+
+	// forecast far enough to cover the lead time and the review period after it
+	horizon := opts.Horizon
+	opts.Horizon = max(horizon, f.LeadTimeDays+u.config.ForecastReviewDays)
+
+	result, err := forecast.Forecast(history, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	f.Level = round2(result.Level)
+	f.SeasonalIndices = result.Indices
+	for i := range f.Forecast {
+		f.Forecast[i] = round2(result.Forecast[i])
+		f.ForecastTotal += result.Forecast[i]
+	}
+	f.ForecastTotal = round2(f.ForecastTotal)
+
+	var leadDemand, cycleDemand float64
+	for i, demand := range result.Forecast[:f.LeadTimeDays+u.config.ForecastReviewDays] {
+		if i < f.LeadTimeDays {
+			leadDemand += demand
+		}
+		cycleDemand += demand
+	}
+
+	safety := u.config.ForecastServiceLevelZ * result.StdDev * math.Sqrt(float64(f.LeadTimeDays))
+	f.SafetyStock = round2(safety)
+	f.SuggestedReorderPoint = int64(math.Ceil(leadDemand + safety))
+
+	position := int64(f.Available) + onOrder
+	if position <= f.SuggestedReorderPoint {
+		f.SuggestedQuantity = max(int64(math.Ceil(cycleDemand+safety))-position, 0)
+	}
+
+	return f, nil
+}
+
+// options fills the request in from the configuration and returns the forecast options and history days.
+func (u *forecastUseCase) options(req *domain.ForecastRequest) (forecast.Options, int) {
+	opts := forecast.Options{
+		Method:  req.Method,
+		Window:  req.Window,
+		Alpha:   req.Alpha,
+		Season:  u.config.ForecastSeason,
+		Horizon: req.Horizon,
+	}
+
+	if opts.Method == "" {
+		opts.Method = u.config.ForecastMethod
+	}
+
+	if opts.Window == 0 {
+		opts.Window = u.config.ForecastWindow
+	}
+
+	if opts.Alpha == 0 {
+		opts.Alpha = u.config.ForecastAlpha
+	}
+
+	if req.Season != nil {
+		opts.Season = *req.Season
+	}
+
+	if opts.Horizon == 0 {
+		opts.Horizon = u.config.ForecastHorizonDays
+	}
+
+	history := req.History
+	if history == 0 {
+		history = u.config.ForecastHistoryDays
+	}
+
+	return opts, history
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/forecast"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestForecastDemand(t *testing.T) {
+	Convey("Test forecast demand", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{
+			ForecastMethod:        forecast.MovingAverage,
+			ForecastHistoryDays:   30,
+			ForecastHorizonDays:   7,
+			ForecastLeadTimeDays:  14,
+			ForecastReviewDays:    7,
+			ForecastServiceLevelZ: 1.65,
+		}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		forecastRepo := repositoryMock.NewMockForecastRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetForecastRepo().Return(forecastRepo).AnyTimes()
+
+		forecastUseCase := NewForecastUseCase(config, repoMock)
+
+		var (
+			ctx   = context.Background()
+			today = domain.BucketStart(time.Now(), domain.BucketDay)
+			day   = func(ago int) time.Time { return today.AddDate(0, 0, -ago) }
+		)
+
+		Convey("resp err book not found", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 99).Return(nil, nil)
+
+			_, err := forecastUseCase.ForecastDemand(ctx, &domain.ForecastRequest{BookID: 99})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success suggests reorders below the reorder point", func() {
+			var demand []*domain.BookDemand
+			for ago := 10; ago > 0; ago-- {
+				demand = append(demand, &domain.BookDemand{BookID: 10, Day: day(ago), Quantity: 4})
+			}
+			demand = append(demand,
+				&domain.BookDemand{BookID: 11, Day: day(4), Quantity: 2},
+				&domain.BookDemand{BookID: 11, Day: day(2), Quantity: 2},
+			)
+
+			forecastRepo.EXPECT().ListDemand(gomock.Any(), day(30), today, 0).Return(demand, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return([]*domain.Book{
+				{ID: 11, Title: "Emma", Stock: 5},
+				{ID: 10, Title: "Dune", Stock: 50, Reserved: 10},
+			}, nil)
+			forecastRepo.EXPECT().ListSuppliers(gomock.Any(), []int{10, 11}).Return([]*domain.BookSupplier{
+				{BookID: 10, SupplierID: 3, LeadTimeDays: 5},
+			}, nil)
+			forecastRepo.EXPECT().ListOnOrder(gomock.Any(), []int{10, 11}).Return([]*domain.BookOnOrder{
+				{BookID: 11, Quantity: 3},
+			}, nil)
+
+			resp, err := forecastUseCase.ForecastDemand(ctx, &domain.ForecastRequest{})
+			So(err, ShouldBeNil)
+			So(resp.Total, ShouldEqual, 2)
+
+			dune := resp.Books[0]
+			So(dune.Level, ShouldEqual, 4)
+			So(dune.Forecast, ShouldHaveLength, 7)
+			So(dune.ForecastTotal, ShouldEqual, 28)
+			So(*dune.SupplierID, ShouldEqual, 3)
+			So(dune.LeadTimeDays, ShouldEqual, 5)
+			So(dune.Available, ShouldEqual, 40)
+			So(dune.SuggestedReorderPoint, ShouldEqual, 20)
+			So(dune.SuggestedQuantity, ShouldEqual, 0)
+
+			emma := resp.Books[1]
+			So(emma.HistoryDays, ShouldEqual, 4)
+			So(emma.Level, ShouldEqual, 1)
+			So(emma.LeadTimeDays, ShouldEqual, 14)
+			So(emma.SafetyStock, ShouldAlmostEqual, 8.04, 0.01)
+			So(emma.SuggestedReorderPoint, ShouldEqual, 23)
+			So(emma.SuggestedQuantity, ShouldEqual, 22)
+
+			Convey("and leaves the others out when asked to", func() {
+				forecastRepo.EXPECT().ListDemand(gomock.Any(), day(30), today, 0).Return(demand, nil)
+				bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return([]*domain.Book{
+					{ID: 10, Title: "Dune", Stock: 50, Reserved: 10},
+					{ID: 11, Title: "Emma", Stock: 5},
+				}, nil)
+				forecastRepo.EXPECT().ListSuppliers(gomock.Any(), []int{10, 11}).Return([]*domain.BookSupplier{
+					{BookID: 10, SupplierID: 3, LeadTimeDays: 5},
+				}, nil)
+				forecastRepo.EXPECT().ListOnOrder(gomock.Any(), []int{10, 11}).Return(nil, nil)
+
+				resp, err := forecastUseCase.ForecastDemand(ctx, &domain.ForecastRequest{ReorderOnly: true})
+				So(err, ShouldBeNil)
+				So(resp.Total, ShouldEqual, 1)
+				So(resp.Books[0].BookID, ShouldEqual, 11)
+			})
+		})
+
+		Convey("resp success forecasts nothing for a book without sales", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 12).Return(&domain.Book{ID: 12}, nil)
+			forecastRepo.EXPECT().ListDemand(gomock.Any(), day(30), today, 12).Return(nil, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{12}).Return([]*domain.Book{{ID: 12, Title: "Ulysses", Stock: 3}}, nil)
+			forecastRepo.EXPECT().ListSuppliers(gomock.Any(), []int{12}).Return(nil, nil)
+			forecastRepo.EXPECT().ListOnOrder(gomock.Any(), []int{12}).Return(nil, nil)
+
+			resp, err := forecastUseCase.ForecastDemand(ctx, &domain.ForecastRequest{BookID: 12})
+			So(err, ShouldBeNil)
+			So(resp.Books[0].HistoryDays, ShouldEqual, 0)
+			So(resp.Books[0].SuggestedQuantity, ShouldEqual, 0)
+		})
+	})
+}
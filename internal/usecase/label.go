@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/barcode"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/label"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type LabelUseCaseImpl interface {
+	RenderBarcode(ctx context.Context, req *domain.BarcodeRequest) (*domain.BarcodeImage, error)
+	RenderLabelSheet(ctx context.Context, req *domain.LabelSheetRequest) ([]byte, error)
+}
+
+type labelUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewLabelUseCase(config *config.MainConfig, repo repository.RepositoryImpl) LabelUseCaseImpl {
+	return &labelUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *labelUseCase) RenderBarcode(ctx context.Context, req *domain.BarcodeRequest) (*domain.BarcodeImage, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	if req.Symbology == "" {
+		req.Symbology = barcode.SymbologyCode128
+	}
+
+	if req.Format == "" {
+		req.Format = barcode.FormatPNG
+	}
+
+	if req.Width == 0 {
+		req.Width = 400
+	}
+
+	if req.Height == 0 {
+		req.Height = 120
+	}
+
+	book, err := u.repo.GetBookRepo().GetByID(ctx, req.BookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if book == nil {
+		return nil, errors.New("book not found")
+	}
+
+	// ean13 carries the retail barcode, code128 and qr carry the internal sku
+	content := book.SKU
+	if req.Symbology == barcode.SymbologyEAN13 {
+		content = book.Barcode
+	}
+
+	if content == "" {
+		return nil, errors.New("book has no identifier for this symbology")
+	}
+
+	code, err := barcode.Encode(req.Symbology, content, req.Width, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Format == barcode.FormatSVG {
+		return &domain.BarcodeImage{
+			ContentType: "image/svg+xml",
+			Data:        barcode.SVG(code),
+		}, nil
+	}
+
+	data, err := barcode.PNG(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BarcodeImage{
+		ContentType: "image/png",
+		Data:        data,
+	}, nil
+}
+
+func (u *labelUseCase) RenderLabelSheet(ctx context.Context, req *domain.LabelSheetRequest) ([]byte, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	if req.Copies == 0 {
+		req.Copies = 1
+	}
+
+	authors := make(map[int]*domain.Author)
+	labels := make([]label.Label, 0, len(req.BookIDs)*req.Copies)
+
+	for _, id := range req.BookIDs {
+		book, err := u.repo.GetBookRepo().GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if book == nil {
+			return nil, fmt.Errorf("book %d not found", id)
+		}
+
+		author, ok := authors[book.AuthorID]
+		if !ok {
+			author, err = u.repo.GetAuthorRepo().GetByID(ctx, book.AuthorID)
+			if err != nil {
+				return nil, err
+			}
+			authors[book.AuthorID] = author
+		}
+
+		l := label.Label{
+			Title:    book.Title,
+			Price:    book.Price.Format(),
+			Location: book.Location,
+			SKU:      book.SKU,
+			Barcode:  book.Barcode,
+		}
+
+		if author != nil {
+			l.AuthorName = author.Name
+		}
+
+		for i := 0; i < req.Copies; i++ {
+			labels = append(labels, l)
+		}
+	}
+
+	return label.Render(label.A4, labels)
+}
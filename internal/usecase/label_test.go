@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRenderBarcode(t *testing.T) {
+	Convey("Test render barcode", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+
+		labelUseCase := NewLabelUseCase(config, repoMock)
+
+		var (
+			ctx     = context.Background()
+			errResp = errors.New("error")
+			book    = &domain.Book{ID: 1, Title: "anak anak", SKU: "BK-7KQ2M9XA", Barcode: "9780306406157"}
+		)
+
+		Convey("resp err validator", func() {
+			resp, err := labelUseCase.RenderBarcode(ctx, &domain.BarcodeRequest{BookID: 1, Symbology: "upc"})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when get book by id", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errResp)
+			resp, err := labelUseCase.RenderBarcode(ctx, &domain.BarcodeRequest{BookID: 1})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp success png", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(book, nil)
+			resp, err := labelUseCase.RenderBarcode(ctx, &domain.BarcodeRequest{BookID: 1, Symbology: "ean13"})
+			So(err, ShouldBeNil)
+			So(resp.ContentType, ShouldEqual, "image/png")
+			So(bytes.HasPrefix(resp.Data, []byte("\x89PNG")), ShouldBeTrue)
+		})
+
+		Convey("resp success svg", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(book, nil)
+			resp, err := labelUseCase.RenderBarcode(ctx, &domain.BarcodeRequest{BookID: 1, Symbology: "qr", Format: "svg"})
+			So(err, ShouldBeNil)
+			So(resp.ContentType, ShouldEqual, "image/svg+xml")
+			So(string(resp.Data), ShouldStartWith, "<svg")
+		})
+	})
+}
+
+func TestRenderLabelSheet(t *testing.T) {
+	Convey("Test render label sheet", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+
+		labelUseCase := NewLabelUseCase(config, repoMock)
+
+		var (
+			ctx    = context.Background()
+			author = &domain.Author{ID: 1, Name: "jamil"}
+		)
+
+		Convey("resp err validator", func() {
+			resp, err := labelUseCase.RenderLabelSheet(ctx, &domain.LabelSheetRequest{})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 2).Return(nil, nil)
+			resp, err := labelUseCase.RenderLabelSheet(ctx, &domain.LabelSheetRequest{BookIDs: []int{2}})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp success looks up each author once", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Book{ID: 1, AuthorID: 1, Title: "petualangan sherina", Price: money.New(12500000, "IDR"), SKU: "BK-7KQ2M9XA", Barcode: "9780306406157", Location: "A-03-2"}, nil)
+			bookRepo.EXPECT().GetByID(gomock.Any(), 2).Return(&domain.Book{ID: 2, AuthorID: 1, Title: "anak anak", Price: money.New(700000, "IDR"), SKU: "BK-2M9XA7KQ", Barcode: "2100000000005"}, nil)
+			authorRepo.EXPECT().GetByID(gomock.Any(), 1).Return(author, nil).Times(1)
+
+			resp, err := labelUseCase.RenderLabelSheet(ctx, &domain.LabelSheetRequest{BookIDs: []int{1, 2}, Copies: 15})
+			So(err, ShouldBeNil)
+			So(bytes.HasPrefix(resp, []byte("%PDF")), ShouldBeTrue)
+		})
+	})
+}
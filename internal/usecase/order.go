@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type OrderUseCaseImpl interface {
+	CreateOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.Order, error)
+	GetOrder(ctx context.Context, id int) (*domain.Order, error)
+	ListOrders(ctx context.Context, filter *domain.OrderFilter) ([]*domain.Order, error)
+	PayOrder(ctx context.Context, id int) error
+	PickOrder(ctx context.Context, id int) error
+	ShipOrder(ctx context.Context, id int) error
+	DeliverOrder(ctx context.Context, id int) error
+	CancelOrder(ctx context.Context, id int) error
+	ReturnOrder(ctx context.Context, id int) error
+}
+
+type orderUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
+}
+
+func NewOrderUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) OrderUseCaseImpl {
+	return &orderUseCase{
+		config: config,
+		repo:   repo,
+		rates:  rates,
+	}
+}
+
+// CreateOrder prices every line from its book in the order currency and reserves the stock, the order
+// is not placed when any book has too little available.
+func (u *orderUseCase) CreateOrder(ctx context.Context, req *domain.CreateOrderRequest) (*domain.Order, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = u.config.DefaultCurrency
+	}
+
+	ids := make([]int, 0, len(req.Lines))
+	quantities := make(map[int]int, len(req.Lines))
+	for _, item := range req.Lines {
+		if _, ok := quantities[item.BookID]; ok {
+			return nil, validator.NewValidationError(fmt.Sprintf("book %d is listed more than once", item.BookID))
+		}
+
+		ids = append(ids, item.BookID)
+		quantities[item.BookID] = item.Quantity
+	}
+
+	books, err := u.repo.GetBookRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(books) != len(ids) {
+		return nil, errors.New("book not found")
+	}
+
+	if err := convertPrices(ctx, u.rates, currency, books...); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*domain.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	now := time.Now()
+	order := &domain.Order{
+		CustomerName:    req.CustomerName,
+		CustomerEmail:   req.CustomerEmail,
+		ShippingAddress: req.ShippingAddress,
+		Status:          domain.OrderPending,
+		Currency:        currency,
+		CreatedBy:       changedBy(ctx),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	for _, id := range ids {
+		book := byID[id]
+		order.Lines = append(order.Lines, &domain.OrderLine{
+			BookID:    book.ID,
+			Title:     book.Title,
+			Quantity:  quantities[book.ID],
+			UnitPrice: book.Price,
+		})
+	}
+
+	err = u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		// books come ordered by id, reserving in that order keeps concurrent orders from deadlocking
+		for _, book := range books {
+			ok, err := u.repo.GetStockRepo().Reserve(txCtx, book.ID, quantities[book.ID])
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				return validator.NewValidationError(fmt.Sprintf("book %d does not have enough stock", book.ID))
+			}
+		}
+
+		return u.repo.GetOrderRepo().Create(txCtx, order)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (u *orderUseCase) GetOrder(ctx context.Context, id int) (*domain.Order, error) {
+	order, err := u.repo.GetOrderRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, errors.New("order not found")
+	}
+
+	return order, nil
+}
+
+func (u *orderUseCase) ListOrders(ctx context.Context, filter *domain.OrderFilter) ([]*domain.Order, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetOrderRepo().List(ctx, filter)
+}
+
+func (u *orderUseCase) PayOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderPaid, nil)
+}
+
+func (u *orderUseCase) PickOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderPicked, nil)
+}
+
+// ShipOrder turns the reservations of the order into stock leaving the warehouse.
+func (u *orderUseCase) ShipOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderShipped, func(txCtx context.Context, order *domain.Order) error {
+		if err := u.release(txCtx, order); err != nil {
+			return err
+		}
+
+		return u.recordStock(txCtx, order, domain.StockMovementSaleShipment, -1)
+	})
+}
+
+func (u *orderUseCase) DeliverOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderDelivered, nil)
+}
+
+// CancelOrder gives the reserved stock back, orders can only be cancelled before they are shipped.
+func (u *orderUseCase) CancelOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderCancelled, u.release)
+}
+
+// ReturnOrder puts the goods of a whole shipped order back in stock, orders with rmas are returned
+// through the inspection of those instead.
+func (u *orderUseCase) ReturnOrder(ctx context.Context, id int) error {
+	return u.transition(ctx, id, domain.OrderReturned, func(txCtx context.Context, order *domain.Order) error {
+		exists, err := u.repo.GetRMARepo().ExistsForOrder(txCtx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return validator.NewValidationError("order has rmas, inspect those to take the books back")
+		}
+
+		return u.recordStock(txCtx, order, domain.StockMovementSaleReturn, 1)
+	})
+}
+
+// transition locks the order, moves it through the state machine and runs effect in the same transaction.
+func (u *orderUseCase) transition(ctx context.Context, id int, to string, effect func(txCtx context.Context, order *domain.Order) error) error {
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		order, err := u.repo.GetOrderRepo().GetByIDForUpdate(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if order == nil {
+			return errors.New("order not found")
+		}
+
+		columns, err := order.Transition(to, time.Now())
+		if err != nil {
+			return validator.NewValidationError(err.Error())
+		}
+
+		if effect != nil {
+			if err := effect(txCtx, order); err != nil {
+				return err
+			}
+		}
+
+		return u.repo.GetOrderRepo().Update(txCtx, order, columns...)
+	})
+}
+
+func (u *orderUseCase) release(ctx context.Context, order *domain.Order) error {
+	for _, line := range order.Lines {
+		if err := u.repo.GetStockRepo().Release(ctx, line.BookID, line.Quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recordStock writes one movement per line, sign is -1 for goods leaving and 1 for goods coming back.
+func (u *orderUseCase) recordStock(ctx context.Context, order *domain.Order, kind string, sign int) error {
+	by := changedBy(ctx)
+
+	movements := make([]*domain.StockMovement, 0, len(order.Lines))
+	for _, line := range order.Lines {
+		movements = append(movements, &domain.StockMovement{
+			BookID:        line.BookID,
+			Type:          kind,
+			Quantity:      sign * line.Quantity,
+			ReferenceType: domain.StockReferenceOrder,
+			ReferenceID:   &order.ID,
+			CreatedBy:     by,
+			CreatedAt:     order.UpdatedAt,
+		})
+	}
+
+	return recordMovements(ctx, u.repo, movements)
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateOrder(t *testing.T) {
+	Convey("Test create order", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		stockRepo := repositoryMock.NewMockStockRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockOrderRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetStockRepo().Return(stockRepo).AnyTimes()
+		repoMock.EXPECT().GetOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		orderUseCase := NewOrderUseCase(config, repoMock, nil)
+
+		var (
+			ctx   = context.Background()
+			books = []*domain.Book{
+				{ID: 10, Title: "Dune", Price: money.New(120000, "IDR")},
+				{ID: 11, Title: "Emma", Price: money.New(80000, "IDR")},
+			}
+			req = &domain.CreateOrderRequest{
+				CustomerName:    "Budi",
+				CustomerEmail:   "budi@mail.com",
+				ShippingAddress: "Jl. Merdeka 1, Jakarta",
+				Lines: []*domain.OrderLineRequest{
+					{BookID: 11, Quantity: 1},
+					{BookID: 10, Quantity: 2},
+				},
+			}
+		)
+
+		Convey("resp err book listed twice", func() {
+			req.Lines[1].BookID = 11
+
+			_, err := orderUseCase.CreateOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{11, 10}).Return(books[:1], nil)
+
+			_, err := orderUseCase.CreateOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err not enough stock", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{11, 10}).Return(books, nil)
+			stockRepo.EXPECT().Reserve(gomock.Any(), 10, 2).Return(true, nil)
+			stockRepo.EXPECT().Reserve(gomock.Any(), 11, 1).Return(false, nil)
+
+			_, err := orderUseCase.CreateOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{11, 10}).Return(books, nil)
+			stockRepo.EXPECT().Reserve(gomock.Any(), 10, 2).Return(true, nil)
+			stockRepo.EXPECT().Reserve(gomock.Any(), 11, 1).Return(true, nil)
+			orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			order, err := orderUseCase.CreateOrder(ctx, req)
+			So(err, ShouldBeNil)
+			So(order.Status, ShouldEqual, domain.OrderPending)
+			So(order.Lines[0].Title, ShouldEqual, "Emma")
+			So(order.Lines[1].UnitPrice, ShouldResemble, money.New(120000, "IDR"))
+			So(order.Total(), ShouldResemble, money.New(320000, "IDR"))
+		})
+	})
+}
+
+func TestOrderTransitions(t *testing.T) {
+	Convey("Test order transitions", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		stockRepo := repositoryMock.NewMockStockRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockOrderRepositoryImpl(ctrl)
+		rmaRepo := repositoryMock.NewMockRMARepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetStockRepo().Return(stockRepo).AnyTimes()
+		repoMock.EXPECT().GetOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetRMARepo().Return(rmaRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		orderUseCase := NewOrderUseCase(config, repoMock, nil)
+
+		var (
+			ctx   = context.Background()
+			order = &domain.Order{
+				ID:    1,
+				Lines: []*domain.OrderLine{{BookID: 10, Quantity: 2}},
+			}
+		)
+
+		Convey("resp err when order doesnt exist", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(nil, nil)
+
+			err := orderUseCase.PayOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err ship an unpicked order", func() {
+			order.Status = domain.OrderPaid
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			err := orderUseCase.ShipOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err cancel a shipped order", func() {
+			order.Status = domain.OrderShipped
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			err := orderUseCase.CancelOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success pay", func() {
+			order.Status = domain.OrderPending
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at", "paid_at").Return(nil)
+
+			err := orderUseCase.PayOrder(ctx, 1)
+			So(err, ShouldBeNil)
+			So(order.Status, ShouldEqual, domain.OrderPaid)
+			So(order.PaidAt, ShouldNotBeNil)
+		})
+
+		Convey("resp success ship releases the reservation and takes the stock", func() {
+			order.Status = domain.OrderPicked
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			stockRepo.EXPECT().Release(gomock.Any(), 10, 2).Return(nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements, ShouldHaveLength, 1)
+				So(movements[0].Type, ShouldEqual, domain.StockMovementSaleShipment)
+				So(movements[0].Quantity, ShouldEqual, -2)
+				So(*movements[0].ReferenceID, ShouldEqual, 1)
+				return nil
+			})
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at", "shipped_at").Return(nil)
+
+			err := orderUseCase.ShipOrder(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("resp success cancel releases the reservation", func() {
+			order.Status = domain.OrderPaid
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			stockRepo.EXPECT().Release(gomock.Any(), 10, 2).Return(nil)
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at", "cancelled_at").Return(nil)
+
+			err := orderUseCase.CancelOrder(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+
+		Convey("resp err return an order with rmas", func() {
+			order.Status = domain.OrderDelivered
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			rmaRepo.EXPECT().ExistsForOrder(gomock.Any(), 1).Return(true, nil)
+
+			err := orderUseCase.ReturnOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success return restocks", func() {
+			order.Status = domain.OrderDelivered
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			rmaRepo.EXPECT().ExistsForOrder(gomock.Any(), 1).Return(false, nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements[0].Type, ShouldEqual, domain.StockMovementSaleReturn)
+				So(movements[0].Quantity, ShouldEqual, 2)
+				return nil
+			})
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at", "returned_at").Return(nil)
+
+			err := orderUseCase.ReturnOrder(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
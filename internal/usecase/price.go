@@ -0,0 +1,353 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/auth"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type PriceUseCaseImpl interface {
+	GetPriceTimeline(ctx context.Context, bookID int) (*domain.PriceTimeline, error)
+	SchedulePrice(ctx context.Context, req *domain.SchedulePriceRequest) error
+	CancelScheduledPrice(ctx context.Context, bookID, id int) error
+	ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error)
+}
+
+type priceUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewPriceUseCase(config *config.MainConfig, repo repository.RepositoryImpl) PriceUseCaseImpl {
+	return &priceUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *priceUseCase) GetPriceTimeline(ctx context.Context, bookID int) (*domain.PriceTimeline, error) {
+	book, err := u.repo.GetBookRepo().GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if book == nil {
+		return nil, errors.New("book not found")
+	}
+
+	history, err := u.repo.GetPriceRepo().GetHistory(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := u.repo.GetPriceRepo().GetSchedules(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PriceTimeline{
+		BookID:    book.ID,
+		Price:     book.Price,
+		Prices:    book.Prices,
+		History:   history,
+		Scheduled: scheduled,
+	}, nil
+}
+
+func (u *priceUseCase) SchedulePrice(ctx context.Context, req *domain.SchedulePriceRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if !req.EffectiveAt.After(time.Now()) {
+		return validator.NewValidationError("effective_at must be in the future")
+	}
+
+	price, err := resolvePrice(u.config, req.Price)
+	if err != nil {
+		return err
+	}
+
+	book, err := u.repo.GetBookRepo().GetByID(ctx, req.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	return u.repo.GetPriceRepo().CreateSchedule(ctx, &domain.ScheduledPrice{
+		BookID:      book.ID,
+		Price:       price,
+		Reason:      req.Reason,
+		EffectiveAt: req.EffectiveAt,
+		Status:      domain.ScheduledPricePending,
+		CreatedBy:   changedBy(ctx),
+		CreatedAt:   time.Now(),
+	})
+}
+
+func (u *priceUseCase) CancelScheduledPrice(ctx context.Context, bookID, id int) error {
+	schedule, err := u.repo.GetPriceRepo().GetSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if schedule == nil || schedule.BookID != bookID {
+		return errors.New("scheduled price not found")
+	}
+
+	if schedule.Status != domain.ScheduledPricePending {
+		return validator.NewValidationError(fmt.Sprintf("scheduled price is already %s", schedule.Status))
+	}
+
+	schedule.Status = domain.ScheduledPriceCancelled
+
+	return u.repo.GetPriceRepo().UpdateSchedule(ctx, schedule, "status")
+}
+
+// ApplyScheduledPrices applies every scheduled price that is due at now, each in its own transaction,
+// and returns how many were applied. A schedule that fails to apply is marked failed so that the ones due
+// after it still apply, the failures are returned together once no schedule is left.
+func (u *priceUseCase) ApplyScheduledPrices(ctx context.Context, now time.Time) (int, error) {
+	var (
+		applied int
+		errs    []error
+	)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return applied, errors.Join(append(errs, err)...)
+		}
+
+		var schedule *domain.ScheduledPrice
+		err := u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			schedule, err = u.repo.GetPriceRepo().ClaimDueSchedule(txCtx, now)
+			if err != nil || schedule == nil {
+				return err
+			}
+
+			return u.applySchedule(txCtx, schedule)
+		})
+
+		switch {
+		case schedule == nil:
+			if err != nil {
+				errs = append(errs, err)
+			}
+
+			return applied, errors.Join(errs...)
+		case err != nil:
+			if ctx.Err() != nil {
+				return applied, errors.Join(append(errs, err)...)
+			}
+
+			errs = append(errs, fmt.Errorf("scheduled price %d: %w", schedule.ID, err))
+
+			// the claim was rolled back with the schedule, the failure is written on its own
+			if err := u.repo.GetPriceRepo().FailSchedule(ctx, schedule.ID, failureReason(err)); err != nil {
+				return applied, errors.Join(append(errs, err)...)
+			}
+		default:
+			applied++
+		}
+	}
+}
+
+// applySchedule applies the price of schedule to its book with the transaction of ctx.
+func (u *priceUseCase) applySchedule(ctx context.Context, schedule *domain.ScheduledPrice) error {
+	book, err := u.repo.GetBookRepo().GetByID(ctx, schedule.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	changes := &bookChanges{
+		reason:           schedule.Reason,
+		changedBy:        schedule.CreatedBy,
+		scheduledPriceID: &schedule.ID,
+	}
+
+	if schedule.Price.Currency == book.Price.Currency {
+		setBasePrice(book, schedule.Price, changes)
+	} else {
+		setListPrice(book, schedule.Price, changes)
+	}
+
+	if err := writeBookChanges(ctx, u.repo, book, changes); err != nil {
+		return err
+	}
+
+	appliedAt := time.Now()
+	schedule.Status, schedule.AppliedAt = domain.ScheduledPriceApplied, &appliedAt
+
+	return u.repo.GetPriceRepo().UpdateSchedule(ctx, schedule, "status", "applied_at")
+}
+
+// failureReason fits err in the failure_reason column of a schedule.
+func failureReason(err error) string {
+	reason := []rune(err.Error())
+	if len(reason) > 255 {
+		reason = reason[:255]
+	}
+
+	return string(reason)
+}
+
+// resolvePrice fills in the default currency for prices sent without one.
+func resolvePrice(cfg *config.MainConfig, price money.Money) (money.Money, error) {
+	resolved, err := price.Resolve(cfg.DefaultCurrency)
+	if err != nil {
+		return money.Money{}, validator.NewValidationError(fmt.Sprintf("price %s", err))
+	}
+
+	return resolved, nil
+}
+
+// setBasePrice changes the base price of the book, a list price in the new base currency becomes redundant and is dropped.
+func setBasePrice(book *domain.Book, price money.Money, changes *bookChanges) {
+	if !book.Price.Equal(price) {
+		old := book.Price
+		changes.history = append(changes.history, domain.NewPriceChange(domain.PriceKindBase, &old, &price))
+	}
+
+	book.Price = price
+	changes.columns = append(changes.columns, "price_amount", "price_currency")
+
+	removeListPrice(book, price.Currency, changes)
+}
+
+// setListPrice adds or changes the list price of the book in the currency of price.
+func setListPrice(book *domain.Book, price money.Money, changes *bookChanges) {
+	for _, listPrice := range book.Prices {
+		if listPrice.Currency != price.Currency {
+			continue
+		}
+
+		if old := listPrice.Money(); !old.Equal(price) {
+			changes.history = append(changes.history, domain.NewPriceChange(domain.PriceKindList, &old, &price))
+			listPrice.Amount, listPrice.UpdatedAt = price.Amount, time.Now()
+			changes.prices = true
+		}
+
+		return
+	}
+
+	changes.history = append(changes.history, domain.NewPriceChange(domain.PriceKindList, nil, &price))
+	book.Prices = append(book.Prices, &domain.BookPrice{
+		BookID:    book.ID,
+		Currency:  price.Currency,
+		Amount:    price.Amount,
+		UpdatedAt: time.Now(),
+	})
+	changes.prices = true
+}
+
+func removeListPrice(book *domain.Book, currency string, changes *bookChanges) {
+	for i, listPrice := range book.Prices {
+		if listPrice.Currency == currency {
+			old := listPrice.Money()
+			changes.history = append(changes.history, domain.NewPriceChange(domain.PriceKindList, &old, nil))
+			book.Prices = append(book.Prices[:i:i], book.Prices[i+1:]...)
+			changes.prices = true
+			return
+		}
+	}
+}
+
+// changedBy names the signed in user for the price history, anything else is a system change.
+func changedBy(ctx context.Context) string {
+	if user := auth.GetUserContext(ctx); user != nil {
+		return user.Username
+	}
+
+	return "system"
+}
+
+func checkCurrency(currency string) error {
+	if currency != "" && !money.IsSupported(currency) {
+		return validator.NewValidationError("currency is not a supported currency")
+	}
+
+	return nil
+}
+
+// convertPrices shows every book priced in currency. A list price in that currency wins over
+// converting the base price with an exchange rate, an empty currency keeps the base price.
+func convertPrices(ctx context.Context, rates exchangerate.Provider, currency string, books ...*domain.Book) error {
+	if err := checkCurrency(currency); err != nil {
+		return err
+	}
+
+	if currency == "" {
+		return nil
+	}
+
+	for _, book := range books {
+		if price, ok := book.ListPrice(currency); ok {
+			book.Price = price
+			continue
+		}
+
+		if rates == nil {
+			return errors.New("exchange rates are not available")
+		}
+
+		rate, err := rates.Rate(ctx, book.Price.Currency, currency)
+		if err != nil {
+			return err
+		}
+
+		price, err := book.Price.Convert(currency, rate)
+		if err != nil {
+			return err
+		}
+
+		book.Price, book.PriceConverted = price, true
+	}
+
+	return nil
+}
+
+// newConverter returns a func converting amounts to currency that fetches each rate once, for reports
+// adding up amounts in many currencies.
+func newConverter(ctx context.Context, rates exchangerate.Provider, currency string) func(m money.Money) (money.Money, error) {
+	cache := make(map[string]*big.Rat)
+
+	return func(m money.Money) (money.Money, error) {
+		if m.Currency == currency {
+			return m, nil
+		}
+
+		rate, ok := cache[m.Currency]
+		if !ok {
+			if rates == nil {
+				return money.Money{}, errors.New("exchange rates are not available")
+			}
+
+			var err error
+			rate, err = rates.Rate(ctx, m.Currency, currency)
+			if err != nil {
+				return money.Money{}, err
+			}
+			cache[m.Currency] = rate
+		}
+
+		return m.Convert(currency, rate)
+	}
+}
@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSchedulePrice(t *testing.T) {
+	Convey("Test schedule price", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		priceRepo := repositoryMock.NewMockPriceRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetPriceRepo().Return(priceRepo).AnyTimes()
+
+		priceUseCase := NewPriceUseCase(config, repoMock)
+
+		var (
+			ctx  = context.Background()
+			book = &domain.Book{ID: 10, Price: money.New(700000, "IDR")}
+			req  = &domain.SchedulePriceRequest{
+				BookID:      10,
+				Price:       money.New(500000, ""),
+				EffectiveAt: time.Now().Add(time.Hour),
+				Reason:      "weekend promotion",
+			}
+		)
+
+		Convey("resp err effective at in the past", func() {
+			req.EffectiveAt = time.Now().Add(-time.Minute)
+
+			err := priceUseCase.SchedulePrice(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(nil, nil)
+
+			err := priceUseCase.SchedulePrice(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			priceRepo.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, schedule *domain.ScheduledPrice) error {
+				So(schedule.Price, ShouldResemble, money.New(500000, "IDR"))
+				So(schedule.Status, ShouldEqual, domain.ScheduledPricePending)
+				So(schedule.CreatedBy, ShouldEqual, "system")
+				return nil
+			})
+
+			err := priceUseCase.SchedulePrice(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	Convey("Test cancel scheduled price", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		priceRepo := repositoryMock.NewMockPriceRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPriceRepo().Return(priceRepo).AnyTimes()
+
+		priceUseCase := NewPriceUseCase(config, repoMock)
+
+		ctx := context.Background()
+
+		Convey("resp err schedule of another book", func() {
+			priceRepo.EXPECT().GetSchedule(gomock.Any(), 3).Return(&domain.ScheduledPrice{ID: 3, BookID: 11, Status: domain.ScheduledPricePending}, nil)
+
+			err := priceUseCase.CancelScheduledPrice(ctx, 10, 3)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err already applied", func() {
+			priceRepo.EXPECT().GetSchedule(gomock.Any(), 3).Return(&domain.ScheduledPrice{ID: 3, BookID: 10, Status: domain.ScheduledPriceApplied}, nil)
+
+			err := priceUseCase.CancelScheduledPrice(ctx, 10, 3)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			priceRepo.EXPECT().GetSchedule(gomock.Any(), 3).Return(&domain.ScheduledPrice{ID: 3, BookID: 10, Status: domain.ScheduledPricePending}, nil)
+			priceRepo.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any(), "status").DoAndReturn(func(_ context.Context, schedule *domain.ScheduledPrice, _ ...string) error {
+				So(schedule.Status, ShouldEqual, domain.ScheduledPriceCancelled)
+				return nil
+			})
+
+			err := priceUseCase.CancelScheduledPrice(ctx, 10, 3)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestApplyScheduledPrices(t *testing.T) {
+	Convey("Test apply scheduled prices", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		priceRepo := repositoryMock.NewMockPriceRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetPriceRepo().Return(priceRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		priceUseCase := NewPriceUseCase(config, repoMock)
+
+		var (
+			ctx  = context.Background()
+			now  = time.Now()
+			book = &domain.Book{ID: 10, Price: money.New(700000, "IDR")}
+		)
+
+		Convey("resp err stops when no schedule can be claimed", func() {
+			priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(nil, errors.New("error"))
+
+			applied, err := priceUseCase.ApplyScheduledPrices(ctx, now)
+			So(err, ShouldNotBeNil)
+			So(applied, ShouldEqual, 0)
+		})
+
+		Convey("resp err marks the failing schedule failed and applies the ones after it", func() {
+			gomock.InOrder(
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(&domain.ScheduledPrice{ID: 1, BookID: 9, Price: money.New(500000, "IDR"), Status: domain.ScheduledPricePending}, nil),
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(&domain.ScheduledPrice{ID: 2, BookID: 10, Price: money.New(500000, "IDR"), Status: domain.ScheduledPricePending}, nil),
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(nil, nil),
+			)
+
+			bookRepo.EXPECT().GetByID(gomock.Any(), 9).Return(nil, nil)
+			priceRepo.EXPECT().FailSchedule(gomock.Any(), 1, "book not found").Return(nil)
+
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil)
+			bookRepo.EXPECT().Update(gomock.Any(), book, "price_amount", "price_currency").Return(nil)
+			priceRepo.EXPECT().CreateHistory(gomock.Any(), gomock.Any()).Return(nil)
+			priceRepo.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any(), "status", "applied_at").Return(nil)
+
+			applied, err := priceUseCase.ApplyScheduledPrices(ctx, now)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldEqual, "scheduled price 1: book not found")
+			So(applied, ShouldEqual, 1)
+		})
+
+		Convey("resp success applies base and list prices", func() {
+			gomock.InOrder(
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(&domain.ScheduledPrice{ID: 1, BookID: 10, Price: money.New(500000, "IDR"), Reason: "promo", CreatedBy: "jamil", Status: domain.ScheduledPricePending}, nil),
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(&domain.ScheduledPrice{ID: 2, BookID: 10, Price: money.New(45, "USD"), CreatedBy: "jamil", Status: domain.ScheduledPricePending}, nil),
+				priceRepo.EXPECT().ClaimDueSchedule(gomock.Any(), now).Return(nil, nil),
+			)
+
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(book, nil).Times(2)
+			bookRepo.EXPECT().Update(gomock.Any(), book, "price_amount", "price_currency").Return(nil)
+			bookRepo.EXPECT().ReplacePrices(gomock.Any(), 10, gomock.Any()).Return(nil)
+
+			var history []*domain.PriceChange
+			priceRepo.EXPECT().CreateHistory(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, changes []*domain.PriceChange) error {
+				history = append(history, changes...)
+				return nil
+			}).Times(2)
+			priceRepo.EXPECT().UpdateSchedule(gomock.Any(), gomock.Any(), "status", "applied_at").DoAndReturn(func(_ context.Context, schedule *domain.ScheduledPrice, _ ...string) error {
+				So(schedule.Status, ShouldEqual, domain.ScheduledPriceApplied)
+				So(schedule.AppliedAt, ShouldNotBeNil)
+				return nil
+			}).Times(2)
+
+			applied, err := priceUseCase.ApplyScheduledPrices(ctx, now)
+			So(err, ShouldBeNil)
+			So(applied, ShouldEqual, 2)
+
+			So(history, ShouldHaveLength, 2)
+			So(history[0].Kind, ShouldEqual, domain.PriceKindBase)
+			So(*history[0].OldAmount, ShouldEqual, 700000)
+			So(*history[0].NewAmount, ShouldEqual, 500000)
+			So(history[0].Reason, ShouldEqual, "promo")
+			So(history[0].ChangedBy, ShouldEqual, "jamil")
+			So(*history[0].ScheduledPriceID, ShouldEqual, 1)
+			So(history[1].Kind, ShouldEqual, domain.PriceKindList)
+			So(*history[1].NewCurrency, ShouldEqual, "USD")
+		})
+	})
+}
@@ -0,0 +1,471 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type PromotionUseCaseImpl interface {
+	CreatePromotion(ctx context.Context, req *domain.CreatePromotionRequest) error
+	GetPromotion(ctx context.Context, id int) (*domain.Promotion, error)
+	ListPromotions(ctx context.Context) ([]*domain.Promotion, error)
+	UpdatePromotion(ctx context.Context, req *domain.UpdatePromotionRequest) error
+	DeletePromotion(ctx context.Context, id int) error
+	QuoteBasket(ctx context.Context, req *domain.QuoteRequest) (*domain.Quote, error)
+}
+
+type promotionUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
+}
+
+func NewPromotionUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) PromotionUseCaseImpl {
+	return &promotionUseCase{
+		config: config,
+		repo:   repo,
+		rates:  rates,
+	}
+}
+
+func (u *promotionUseCase) CreatePromotion(ctx context.Context, req *domain.CreatePromotionRequest) error {
+	promotion := &domain.Promotion{CreatedAt: time.Now()}
+	if err := u.setPromotion(ctx, promotion, req); err != nil {
+		return err
+	}
+
+	return u.repo.GetPromotionRepo().Create(ctx, promotion)
+}
+
+func (u *promotionUseCase) GetPromotion(ctx context.Context, id int) (*domain.Promotion, error) {
+	promotion, err := u.repo.GetPromotionRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if promotion == nil {
+		return nil, errors.New("promotion not found")
+	}
+
+	return promotion, nil
+}
+
+func (u *promotionUseCase) ListPromotions(ctx context.Context) ([]*domain.Promotion, error) {
+	return u.repo.GetPromotionRepo().List(ctx)
+}
+
+func (u *promotionUseCase) UpdatePromotion(ctx context.Context, req *domain.UpdatePromotionRequest) error {
+	promotion, err := u.GetPromotion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.setPromotion(ctx, promotion, &req.CreatePromotionRequest); err != nil {
+		return err
+	}
+
+	return u.repo.GetPromotionRepo().Update(ctx, promotion)
+}
+
+func (u *promotionUseCase) DeletePromotion(ctx context.Context, id int) error {
+	if _, err := u.GetPromotion(ctx, id); err != nil {
+		return err
+	}
+
+	return u.repo.GetPromotionRepo().Delete(ctx, id)
+}
+
+// setPromotion validates req and copies it into promotion, keeping only the fields its type uses.
+func (u *promotionUseCase) setPromotion(ctx context.Context, promotion *domain.Promotion, req *domain.CreatePromotionRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		return validator.NewValidationError("ends_at must be after starts_at")
+	}
+
+	if err := u.checkScope(ctx, req.Scope, req.ScopeID); err != nil {
+		return err
+	}
+
+	*promotion = domain.Promotion{
+		ID:        promotion.ID,
+		Name:      req.Name,
+		Type:      req.Type,
+		Scope:     req.Scope,
+		ScopeID:   req.ScopeID,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Priority:  req.Priority,
+		Stackable: req.Stackable,
+		Active:    req.Active == nil || *req.Active,
+		CreatedAt: promotion.CreatedAt,
+		UpdatedAt: time.Now(),
+	}
+
+	switch req.Type {
+	case domain.PromotionTypePercentage:
+		if req.Percentage == 0 {
+			return validator.NewValidationError("percentage is required")
+		}
+
+		promotion.Percentage = req.Percentage
+	case domain.PromotionTypeFixed:
+		if req.Discount == nil {
+			return validator.NewValidationError("discount is required")
+		}
+
+		discount, err := resolvePrice(u.config, *req.Discount)
+		if err != nil {
+			return err
+		}
+
+		promotion.Discount = discount
+	case domain.PromotionTypeBundle:
+		if req.BuyQuantity == 0 || req.PayQuantity == 0 {
+			return validator.NewValidationError("buy_quantity and pay_quantity are required")
+		}
+
+		promotion.BuyQuantity, promotion.PayQuantity = req.BuyQuantity, req.PayQuantity
+	}
+
+	return nil
+}
+
+// checkScope makes sure a scoped promotion targets an existing book, author or category.
+func (u *promotionUseCase) checkScope(ctx context.Context, scope string, scopeID *int) error {
+	if scope == domain.PromotionScopeAll {
+		if scopeID != nil {
+			return validator.NewValidationError("scope_id must be empty when scope is all")
+		}
+
+		return nil
+	}
+
+	if scopeID == nil {
+		return validator.NewValidationError("scope_id is required")
+	}
+
+	var exists bool
+	switch scope {
+	case domain.PromotionScopeBook:
+		book, err := u.repo.GetBookRepo().GetByID(ctx, *scopeID)
+		if err != nil {
+			return err
+		}
+		exists = book != nil
+	case domain.PromotionScopeAuthor:
+		author, err := u.repo.GetAuthorRepo().GetByID(ctx, *scopeID)
+		if err != nil {
+			return err
+		}
+		exists = author != nil
+	case domain.PromotionScopeCategory:
+		category, err := u.repo.GetCategoryRepo().GetByID(ctx, *scopeID)
+		if err != nil {
+			return err
+		}
+		exists = category != nil
+	}
+
+	if !exists {
+		return fmt.Errorf("%s not found", scope)
+	}
+
+	return nil
+}
+
+// QuoteBasket prices every item in the requested currency and applies the promotions valid right now.
+func (u *promotionUseCase) QuoteBasket(ctx context.Context, req *domain.QuoteRequest) (*domain.Quote, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = u.config.DefaultCurrency
+	}
+
+	// the same book listed twice is one line
+	var ids []int
+	quantities := make(map[int]int, len(req.Items))
+	for _, item := range req.Items {
+		if _, ok := quantities[item.BookID]; !ok {
+			ids = append(ids, item.BookID)
+		}
+		quantities[item.BookID] += item.Quantity
+	}
+
+	books, err := u.repo.GetBookRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(books) != len(ids) {
+		return nil, errors.New("book not found")
+	}
+
+	if err := convertPrices(ctx, u.rates, currency, books...); err != nil {
+		return nil, err
+	}
+
+	promotions, err := u.repo.GetPromotionRepo().ListValidAt(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*domain.Book, len(books))
+	for _, book := range books {
+		byID[book.ID] = book
+	}
+
+	basket := &basket{currency: currency}
+	for _, id := range ids {
+		basket.add(byID[id], quantities[id])
+	}
+
+	for _, promotion := range promotions {
+		if err := u.applyPromotion(ctx, basket, promotion); err != nil {
+			return nil, err
+		}
+	}
+
+	return basket.quote(), nil
+}
+
+func (u *promotionUseCase) applyPromotion(ctx context.Context, basket *basket, promotion *domain.Promotion) error {
+	var categories map[int]bool
+	if promotion.Scope == domain.PromotionScopeCategory {
+		ids, err := u.repo.GetCategoryRepo().GetDescendantIDs(ctx, *promotion.ScopeID)
+		if err != nil {
+			return err
+		}
+
+		categories = make(map[int]bool, len(ids))
+		for _, id := range ids {
+			categories[id] = true
+		}
+	}
+
+	var lines []*basketLine
+	for _, line := range basket.lines {
+		if line.accepts(promotion) && promotionMatches(promotion, line.book, categories) {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	explanation := promotion.Describe()
+	applied := false
+
+	switch promotion.Type {
+	case domain.PromotionTypePercentage:
+		for _, line := range lines {
+			applied = line.discount(promotion, explanation, (line.remaining*int64(promotion.Percentage)+50)/100) || applied
+		}
+	case domain.PromotionTypeFixed:
+		discount := promotion.Discount
+		if discount.Currency != basket.currency {
+			if u.rates == nil {
+				return errors.New("exchange rates are not available")
+			}
+
+			rate, err := u.rates.Rate(ctx, discount.Currency, basket.currency)
+			if err != nil {
+				return err
+			}
+
+			if discount, err = discount.Convert(basket.currency, rate); err != nil {
+				return err
+			}
+		}
+
+		for _, line := range lines {
+			applied = line.discount(promotion, explanation, discount.Mul(int64(line.quantity)).Amount) || applied
+		}
+	case domain.PromotionTypeBundle:
+		// every full bundle across the matching lines makes its cheapest copies free
+		total := 0
+		for _, line := range lines {
+			total += line.quantity
+		}
+
+		free := total / promotion.BuyQuantity * (promotion.BuyQuantity - promotion.PayQuantity)
+
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].unitPrice.Amount < lines[j].unitPrice.Amount
+		})
+
+		for _, line := range lines {
+			if free == 0 {
+				break
+			}
+
+			copies := line.quantity
+			if copies > free {
+				copies = free
+			}
+			free -= copies
+
+			applied = line.discount(promotion, fmt.Sprintf("%s, %d free", explanation, copies), line.unitPrice.Mul(int64(copies)).Amount) || applied
+		}
+	}
+
+	if applied {
+		basket.applied = append(basket.applied, promotion)
+	}
+
+	return nil
+}
+
+// promotionMatches reports whether the scope of the promotion covers the book, categories holds
+// the category of a category promotion with every category below it.
+func promotionMatches(promotion *domain.Promotion, book *domain.Book, categories map[int]bool) bool {
+	switch promotion.Scope {
+	case domain.PromotionScopeBook:
+		return book.ID == *promotion.ScopeID
+	case domain.PromotionScopeAuthor:
+		for _, contributor := range book.Contributors {
+			if contributor.AuthorID == *promotion.ScopeID && contributor.Role == domain.ContributorRoleAuthor {
+				return true
+			}
+		}
+
+		return false
+	case domain.PromotionScopeCategory:
+		for _, category := range book.Categories {
+			if categories[category.ID] {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+type basket struct {
+	currency string
+	lines    []*basketLine
+
+	// applied lists the promotions that discounted at least one line, in the order they applied
+	applied []*domain.Promotion
+}
+
+type basketLine struct {
+	book      *domain.Book
+	quantity  int
+	unitPrice money.Money
+	remaining int64
+
+	// exclusive is set once a promotion that does not stack discounted the line
+	exclusive  bool
+	discounted bool
+	applied    []*domain.AppliedPromotion
+}
+
+func (b *basket) add(book *domain.Book, quantity int) {
+	b.lines = append(b.lines, &basketLine{
+		book:      book,
+		quantity:  quantity,
+		unitPrice: book.Price,
+		remaining: book.Price.Mul(int64(quantity)).Amount,
+	})
+}
+
+func (l *basketLine) accepts(promotion *domain.Promotion) bool {
+	return !l.exclusive && (promotion.Stackable || !l.discounted)
+}
+
+// discount takes amount off the line, never more than what is left of it, and reports whether it did.
+func (l *basketLine) discount(promotion *domain.Promotion, explanation string, amount int64) bool {
+	if amount > l.remaining {
+		amount = l.remaining
+	}
+
+	if amount <= 0 {
+		return false
+	}
+
+	l.remaining -= amount
+	l.discounted = true
+	l.exclusive = !promotion.Stackable
+
+	l.applied = append(l.applied, &domain.AppliedPromotion{
+		PromotionID: promotion.ID,
+		Name:        promotion.Name,
+		Explanation: explanation,
+		Discount:    money.New(amount, l.unitPrice.Currency),
+	})
+
+	return true
+}
+
+func (b *basket) quote() *domain.Quote {
+	quote := &domain.Quote{
+		Currency:   b.currency,
+		Lines:      make([]*domain.QuoteLine, 0, len(b.lines)),
+		Subtotal:   money.New(0, b.currency),
+		Discount:   money.New(0, b.currency),
+		Total:      money.New(0, b.currency),
+		Promotions: []*domain.AppliedPromotion{},
+	}
+
+	summary := make(map[int]*domain.AppliedPromotion, len(b.applied))
+	for _, promotion := range b.applied {
+		summary[promotion.ID] = &domain.AppliedPromotion{
+			PromotionID: promotion.ID,
+			Name:        promotion.Name,
+			Explanation: promotion.Describe(),
+			Discount:    money.New(0, b.currency),
+		}
+		quote.Promotions = append(quote.Promotions, summary[promotion.ID])
+	}
+
+	for _, line := range b.lines {
+		subtotal := line.unitPrice.Mul(int64(line.quantity))
+		total := money.New(line.remaining, b.currency)
+		discount := money.New(subtotal.Amount-line.remaining, b.currency)
+
+		applied := line.applied
+		if applied == nil {
+			applied = []*domain.AppliedPromotion{}
+		}
+
+		quote.Lines = append(quote.Lines, &domain.QuoteLine{
+			BookID:     line.book.ID,
+			Title:      line.book.Title,
+			Quantity:   line.quantity,
+			UnitPrice:  line.unitPrice,
+			Subtotal:   subtotal,
+			Discount:   discount,
+			Total:      total,
+			Promotions: applied,
+		})
+
+		quote.Subtotal.Amount += subtotal.Amount
+		quote.Discount.Amount += discount.Amount
+		quote.Total.Amount += total.Amount
+
+		for _, promotion := range line.applied {
+			summary[promotion.PromotionID].Discount.Amount += promotion.Discount.Amount
+		}
+	}
+
+	return quote
+}
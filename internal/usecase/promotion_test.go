@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePromotion(t *testing.T) {
+	Convey("Test create promotion", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		promotionRepo := repositoryMock.NewMockPromotionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetPromotionRepo().Return(promotionRepo).AnyTimes()
+
+		promotionUseCase := NewPromotionUseCase(config, repoMock, nil)
+
+		var (
+			ctx      = context.Background()
+			authorID = 1
+			req      = &domain.CreatePromotionRequest{
+				Name:       "author month",
+				Type:       domain.PromotionTypePercentage,
+				Scope:      domain.PromotionScopeAuthor,
+				ScopeID:    &authorID,
+				Percentage: 20,
+				StartsAt:   time.Now(),
+			}
+		)
+
+		Convey("resp err scope without scope id", func() {
+			req.ScopeID = nil
+
+			err := promotionUseCase.CreatePromotion(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err bundle paying more than it buys", func() {
+			req.Type, req.BuyQuantity, req.PayQuantity = domain.PromotionTypeBundle, 3, 3
+
+			err := promotionUseCase.CreatePromotion(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when author doesnt exist", func() {
+			authorRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+
+			err := promotionUseCase.CreatePromotion(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success keeps only the fields of its type", func() {
+			req.BuyQuantity, req.PayQuantity = 3, 2
+
+			authorRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Author{ID: 1}, nil)
+			promotionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, promotion *domain.Promotion) error {
+				So(promotion.Percentage, ShouldEqual, 20)
+				So(promotion.BuyQuantity, ShouldEqual, 0)
+				So(promotion.Active, ShouldBeTrue)
+				return nil
+			})
+
+			err := promotionUseCase.CreatePromotion(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestQuoteBasket(t *testing.T) {
+	Convey("Test quote basket", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		categoryRepo := repositoryMock.NewMockCategoryRepositoryImpl(ctrl)
+		promotionRepo := repositoryMock.NewMockPromotionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetCategoryRepo().Return(categoryRepo).AnyTimes()
+		repoMock.EXPECT().GetPromotionRepo().Return(promotionRepo).AnyTimes()
+
+		promotionUseCase := NewPromotionUseCase(config, repoMock, nil)
+
+		var (
+			ctx        = context.Background()
+			authorID   = 1
+			categoryID = 5
+			books      = []*domain.Book{
+				{
+					ID: 1, Title: "petualangan sherina", Price: money.New(10000000, "IDR"),
+					Contributors: []*domain.BookContributor{{BookID: 1, AuthorID: 1, Role: domain.ContributorRoleAuthor}},
+				},
+				{
+					ID: 2, Title: "anak anak", Price: money.New(5000000, "IDR"),
+					Contributors: []*domain.BookContributor{{BookID: 2, AuthorID: 2, Role: domain.ContributorRoleAuthor}},
+					Categories:   []*domain.Category{{ID: 6}},
+				},
+			}
+			authorMonth = &domain.Promotion{ID: 1, Name: "author month", Type: domain.PromotionTypePercentage, Scope: domain.PromotionScopeAuthor, ScopeID: &authorID, Percentage: 20, Priority: 10}
+			buy3Pay2    = &domain.Promotion{ID: 2, Name: "buy 3 pay 2", Type: domain.PromotionTypeBundle, Scope: domain.PromotionScopeAll, BuyQuantity: 3, PayQuantity: 2, Priority: 5, Stackable: true}
+			kids        = &domain.Promotion{ID: 3, Name: "kids corner", Type: domain.PromotionTypeFixed, Scope: domain.PromotionScopeCategory, ScopeID: &categoryID, Discount: money.New(500000, "IDR"), Stackable: true}
+		)
+
+		Convey("resp err when a book doesnt exist", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{1, 3}).Return(books[:1], nil)
+
+			resp, err := promotionUseCase.QuoteBasket(ctx, &domain.QuoteRequest{Items: []*domain.QuoteItem{{BookID: 1, Quantity: 1}, {BookID: 3, Quantity: 1}}})
+			So(err, ShouldNotBeNil)
+			So(resp, ShouldBeNil)
+		})
+
+		Convey("resp success applies promotions by priority and stacking", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{1, 2}).Return(books, nil)
+			promotionRepo.EXPECT().ListValidAt(gomock.Any(), gomock.Any()).Return([]*domain.Promotion{authorMonth, buy3Pay2, kids}, nil)
+			categoryRepo.EXPECT().GetDescendantIDs(gomock.Any(), 5).Return([]int{5, 6}, nil)
+
+			resp, err := promotionUseCase.QuoteBasket(ctx, &domain.QuoteRequest{Items: []*domain.QuoteItem{
+				{BookID: 1, Quantity: 1},
+				{BookID: 2, Quantity: 2},
+				{BookID: 2, Quantity: 1},
+			}})
+			So(err, ShouldBeNil)
+			So(resp.Currency, ShouldEqual, "IDR")
+			So(resp.Lines, ShouldHaveLength, 2)
+
+			// the author promotion does not stack, so the bundle skips the first line
+			So(resp.Lines[0].Discount, ShouldResemble, money.New(2000000, "IDR"))
+			So(resp.Lines[0].Promotions, ShouldHaveLength, 1)
+
+			// one of three copies is free, then 5.000 off each of the three copies in the subcategory
+			So(resp.Lines[1].Quantity, ShouldEqual, 3)
+			So(resp.Lines[1].Discount, ShouldResemble, money.New(6500000, "IDR"))
+			So(resp.Lines[1].Promotions, ShouldHaveLength, 2)
+			So(resp.Lines[1].Promotions[0].Explanation, ShouldEqual, "buy 3 pay 2 all books, 1 free")
+
+			So(resp.Subtotal, ShouldResemble, money.New(25000000, "IDR"))
+			So(resp.Discount, ShouldResemble, money.New(8500000, "IDR"))
+			So(resp.Total, ShouldResemble, money.New(16500000, "IDR"))
+			So(resp.Promotions, ShouldHaveLength, 3)
+			So(resp.Promotions[2].Discount, ShouldResemble, money.New(1500000, "IDR"))
+		})
+
+		Convey("resp success without promotions", func() {
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return(books[:1], nil)
+			promotionRepo.EXPECT().ListValidAt(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+			resp, err := promotionUseCase.QuoteBasket(ctx, &domain.QuoteRequest{Items: []*domain.QuoteItem{{BookID: 1, Quantity: 2}}})
+			So(err, ShouldBeNil)
+			So(resp.Total, ShouldResemble, money.New(20000000, "IDR"))
+			So(resp.Promotions, ShouldBeEmpty)
+		})
+	})
+}
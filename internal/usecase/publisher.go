@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type PublisherUseCaseImpl interface {
+	CreatePublisher(ctx context.Context, req *domain.CreatePublisherRequest) error
+	GetPublisher(ctx context.Context, id int) (*domain.Publisher, error)
+	ListPublishers(ctx context.Context) ([]*domain.Publisher, error)
+	UpdatePublisher(ctx context.Context, req *domain.UpdatePublisherRequest) error
+	DeletePublisher(ctx context.Context, id int) error
+}
+
+type publisherUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewPublisherUseCase(config *config.MainConfig, repo repository.RepositoryImpl) PublisherUseCaseImpl {
+	return &publisherUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *publisherUseCase) CreatePublisher(ctx context.Context, req *domain.CreatePublisherRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	publisher, err := u.repo.GetPublisherRepo().GetByName(ctx, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if publisher != nil {
+		return errors.New("publisher already exist")
+	}
+
+	return u.repo.GetPublisherRepo().Create(ctx, &domain.Publisher{
+		Name:      req.Name,
+		Website:   req.Website,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (u *publisherUseCase) GetPublisher(ctx context.Context, id int) (*domain.Publisher, error) {
+	publisher, err := u.repo.GetPublisherRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if publisher == nil {
+		return nil, errors.New("publisher not found")
+	}
+
+	return publisher, nil
+}
+
+func (u *publisherUseCase) ListPublishers(ctx context.Context) ([]*domain.Publisher, error) {
+	return u.repo.GetPublisherRepo().List(ctx)
+}
+
+func (u *publisherUseCase) UpdatePublisher(ctx context.Context, req *domain.UpdatePublisherRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	publisher, err := u.GetPublisher(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := u.repo.GetPublisherRepo().GetByName(ctx, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != publisher.ID {
+		return errors.New("publisher already exist")
+	}
+
+	publisher.Name = req.Name
+	publisher.Website = req.Website
+
+	return u.repo.GetPublisherRepo().Update(ctx, publisher)
+}
+
+// DeletePublisher removes the publisher, its books are kept without a publisher.
+func (u *publisherUseCase) DeletePublisher(ctx context.Context, id int) error {
+	if _, err := u.GetPublisher(ctx, id); err != nil {
+		return err
+	}
+
+	return u.repo.GetPublisherRepo().Delete(ctx, id)
+}
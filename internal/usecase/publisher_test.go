@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePublisher(t *testing.T) {
+	Convey("Test create publisher", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		publisherRepo := repositoryMock.NewMockPublisherRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPublisherRepo().Return(publisherRepo).AnyTimes()
+
+		publisherUseCase := NewPublisherUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			req = &domain.CreatePublisherRequest{Name: "Gramedia", Website: "https://gramedia.com"}
+		)
+
+		Convey("resp err validator", func() {
+			err := publisherUseCase.CreatePublisher(ctx, &domain.CreatePublisherRequest{Name: "Gramedia", Website: "gramedia"})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when publisher already exist", func() {
+			publisherRepo.EXPECT().GetByName(gomock.Any(), "Gramedia").Return(&domain.Publisher{ID: 1, Name: "Gramedia"}, nil)
+			err := publisherUseCase.CreatePublisher(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			publisherRepo.EXPECT().GetByName(gomock.Any(), "Gramedia").Return(nil, nil)
+			publisherRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Publisher) error {
+				So(req.Name, ShouldEqual, "Gramedia")
+				So(req.Website, ShouldEqual, "https://gramedia.com")
+				return nil
+			})
+			err := publisherUseCase.CreatePublisher(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestUpdatePublisher(t *testing.T) {
+	Convey("Test update publisher", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		publisherRepo := repositoryMock.NewMockPublisherRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPublisherRepo().Return(publisherRepo).AnyTimes()
+
+		publisherUseCase := NewPublisherUseCase(config, repoMock)
+
+		var (
+			ctx       = context.Background()
+			req       = &domain.UpdatePublisherRequest{ID: 1, Name: "Mizan"}
+			publisher = &domain.Publisher{ID: 1, Name: "Gramedia"}
+		)
+
+		Convey("resp err when publisher doesnt exist", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := publisherUseCase.UpdatePublisher(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when renamed to the name of another publisher", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(publisher, nil)
+			publisherRepo.EXPECT().GetByName(gomock.Any(), "Mizan").Return(&domain.Publisher{ID: 2, Name: "Mizan"}, nil)
+			err := publisherUseCase.UpdatePublisher(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success keeps its own name", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(publisher, nil)
+			publisherRepo.EXPECT().GetByName(gomock.Any(), "Gramedia").Return(publisher, nil)
+			publisherRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+			err := publisherUseCase.UpdatePublisher(ctx, &domain.UpdatePublisherRequest{ID: 1, Name: "Gramedia"})
+			So(err, ShouldBeNil)
+		})
+
+		Convey("resp success renames publisher", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(publisher, nil)
+			publisherRepo.EXPECT().GetByName(gomock.Any(), "Mizan").Return(nil, nil)
+			publisherRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Publisher) error {
+				So(req.Name, ShouldEqual, "Mizan")
+				return nil
+			})
+			err := publisherUseCase.UpdatePublisher(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestDeletePublisher(t *testing.T) {
+	Convey("Test delete publisher", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		publisherRepo := repositoryMock.NewMockPublisherRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPublisherRepo().Return(publisherRepo).AnyTimes()
+
+		publisherUseCase := NewPublisherUseCase(config, repoMock)
+
+		var (
+			ctx     = context.Background()
+			errResp = errors.New("error")
+		)
+
+		Convey("resp err when get publisher by id", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errResp)
+			err := publisherUseCase.DeletePublisher(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when publisher doesnt exist", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := publisherUseCase.DeletePublisher(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			publisherRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Publisher{ID: 1, Name: "Gramedia"}, nil)
+			publisherRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			err := publisherUseCase.DeletePublisher(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
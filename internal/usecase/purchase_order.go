@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type PurchaseOrderUseCaseImpl interface {
+	CreatePurchaseOrder(ctx context.Context, req *domain.CreatePurchaseOrderRequest) error
+	GetPurchaseOrder(ctx context.Context, id int) (*domain.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, filter *domain.PurchaseOrderFilter) ([]*domain.PurchaseOrder, error)
+	UpdatePurchaseOrder(ctx context.Context, req *domain.UpdatePurchaseOrderRequest) error
+	SubmitPurchaseOrder(ctx context.Context, id int) error
+	CancelPurchaseOrder(ctx context.Context, id int) error
+	ReceiveGoods(ctx context.Context, req *domain.GoodsReceiptRequest) error
+}
+
+type purchaseOrderUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewPurchaseOrderUseCase(config *config.MainConfig, repo repository.RepositoryImpl) PurchaseOrderUseCaseImpl {
+	return &purchaseOrderUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *purchaseOrderUseCase) CreatePurchaseOrder(ctx context.Context, req *domain.CreatePurchaseOrderRequest) error {
+	now := time.Now()
+	order := &domain.PurchaseOrder{
+		Status:    domain.PurchaseOrderDraft,
+		CreatedBy: changedBy(ctx),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := u.setPurchaseOrder(ctx, order, req); err != nil {
+		return err
+	}
+
+	return u.repo.GetPurchaseOrderRepo().Create(ctx, order)
+}
+
+func (u *purchaseOrderUseCase) GetPurchaseOrder(ctx context.Context, id int) (*domain.PurchaseOrder, error) {
+	order, err := u.repo.GetPurchaseOrderRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if order == nil {
+		return nil, errors.New("purchase order not found")
+	}
+
+	return order, nil
+}
+
+func (u *purchaseOrderUseCase) ListPurchaseOrders(ctx context.Context, filter *domain.PurchaseOrderFilter) ([]*domain.PurchaseOrder, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetPurchaseOrderRepo().List(ctx, filter)
+}
+
+// UpdatePurchaseOrder replaces the supplier, note and lines of a draft order.
+func (u *purchaseOrderUseCase) UpdatePurchaseOrder(ctx context.Context, req *domain.UpdatePurchaseOrderRequest) error {
+	order, err := u.GetPurchaseOrder(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != domain.PurchaseOrderDraft {
+		return validator.NewValidationError(fmt.Sprintf("purchase order is %s, only draft orders can be changed", order.Status))
+	}
+
+	if err := u.setPurchaseOrder(ctx, order, &req.CreatePurchaseOrderRequest); err != nil {
+		return err
+	}
+
+	order.UpdatedAt = time.Now()
+
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := u.repo.GetPurchaseOrderRepo().Update(txCtx, order, "supplier_id", "currency", "note", "updated_at"); err != nil {
+			return err
+		}
+
+		return u.repo.GetPurchaseOrderRepo().ReplaceLines(txCtx, order.ID, order.Lines)
+	})
+}
+
+func (u *purchaseOrderUseCase) SubmitPurchaseOrder(ctx context.Context, id int) error {
+	now := time.Now()
+
+	return u.transition(ctx, id, domain.PurchaseOrderSubmitted, func(order *domain.PurchaseOrder) []string {
+		order.SubmittedAt = &now
+		return []string{"submitted_at"}
+	})
+}
+
+// CancelPurchaseOrder closes an order that has not been fully received, goods already received stay in stock.
+func (u *purchaseOrderUseCase) CancelPurchaseOrder(ctx context.Context, id int) error {
+	now := time.Now()
+
+	return u.transition(ctx, id, domain.PurchaseOrderCancelled, func(order *domain.PurchaseOrder) []string {
+		order.ClosedAt = &now
+		return []string{"closed_at"}
+	})
+}
+
+func (u *purchaseOrderUseCase) transition(ctx context.Context, id int, to string, set func(order *domain.PurchaseOrder) []string) error {
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		order, err := u.repo.GetPurchaseOrderRepo().GetByIDForUpdate(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if order == nil {
+			return errors.New("purchase order not found")
+		}
+
+		if !order.CanTransition(to) {
+			return validator.NewValidationError(fmt.Sprintf("purchase order can not move from %s to %s", order.Status, to))
+		}
+
+		order.Status, order.UpdatedAt = to, time.Now()
+		columns := append([]string{"status", "updated_at"}, set(order)...)
+
+		return u.repo.GetPurchaseOrderRepo().Update(txCtx, order, columns...)
+	})
+}
+
+// ReceiveGoods posts a goods receipt: it raises the received quantities, adds the goods to stock at
+// the unit cost of their order lines and moves the order on, all in one transaction.
+func (u *purchaseOrderUseCase) ReceiveGoods(ctx context.Context, req *domain.GoodsReceiptRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		order, err := u.repo.GetPurchaseOrderRepo().GetByIDForUpdate(txCtx, req.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+
+		if order == nil {
+			return errors.New("purchase order not found")
+		}
+
+		if !order.CanTransition(domain.PurchaseOrderReceived) {
+			return validator.NewValidationError(fmt.Sprintf("purchase order is %s, only submitted orders can receive goods", order.Status))
+		}
+
+		now := time.Now()
+		by := changedBy(txCtx)
+
+		receipt := &domain.GoodsReceipt{
+			PurchaseOrderID: order.ID,
+			Note:            req.Note,
+			ReceivedBy:      by,
+			ReceivedAt:      now,
+		}
+
+		received := make(map[int]bool, len(req.Lines))
+		for _, item := range req.Lines {
+			line := order.Line(item.BookID)
+			if line == nil {
+				return validator.NewValidationError(fmt.Sprintf("book %d is not on the purchase order", item.BookID))
+			}
+
+			if received[item.BookID] {
+				return validator.NewValidationError(fmt.Sprintf("book %d is listed more than once", item.BookID))
+			}
+			received[item.BookID] = true
+
+			if item.Quantity > line.Outstanding() {
+				return validator.NewValidationError(fmt.Sprintf("book %d only has %d outstanding", item.BookID, line.Outstanding()))
+			}
+
+			line.QuantityReceived += item.Quantity
+			if err := u.repo.GetPurchaseOrderRepo().UpdateLineReceived(txCtx, line); err != nil {
+				return err
+			}
+
+			receipt.Lines = append(receipt.Lines, &domain.GoodsReceiptLine{
+				PurchaseOrderLineID: line.ID,
+				BookID:              line.BookID,
+				Quantity:            item.Quantity,
+				UnitCost:            line.UnitCost,
+			})
+		}
+
+		if err := u.repo.GetPurchaseOrderRepo().CreateReceipt(txCtx, receipt); err != nil {
+			return err
+		}
+
+		movements := make([]*domain.StockMovement, 0, len(receipt.Lines))
+		for _, line := range receipt.Lines {
+			movements = append(movements, &domain.StockMovement{
+				BookID:        line.BookID,
+				Type:          domain.StockMovementPurchaseReceipt,
+				Quantity:      line.Quantity,
+				UnitCost:      line.UnitCost,
+				ReferenceType: domain.StockReferenceGoodsReceipt,
+				ReferenceID:   &receipt.ID,
+				Note:          req.Note,
+				CreatedBy:     by,
+				CreatedAt:     now,
+			})
+		}
+
+		if err := recordMovements(txCtx, u.repo, movements); err != nil {
+			return err
+		}
+
+		columns := []string{"status", "updated_at"}
+		order.Status, order.UpdatedAt = domain.PurchaseOrderPartiallyReceived, now
+		if order.IsFullyReceived() {
+			order.Status, order.ClosedAt = domain.PurchaseOrderReceived, &now
+			columns = append(columns, "closed_at")
+		}
+
+		return u.repo.GetPurchaseOrderRepo().Update(txCtx, order, columns...)
+	})
+}
+
+// setPurchaseOrder validates req and copies it into order, unit costs without currency are in the order currency.
+func (u *purchaseOrderUseCase) setPurchaseOrder(ctx context.Context, order *domain.PurchaseOrder, req *domain.CreatePurchaseOrderRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	supplier, err := u.repo.GetSupplierRepo().GetByID(ctx, req.SupplierID)
+	if err != nil {
+		return err
+	}
+
+	if supplier == nil {
+		return errors.New("supplier not found")
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = u.config.DefaultCurrency
+	}
+
+	ids := make([]int, 0, len(req.Lines))
+	lines := make([]*domain.PurchaseOrderLine, 0, len(req.Lines))
+	seen := make(map[int]bool, len(req.Lines))
+	for _, item := range req.Lines {
+		if seen[item.BookID] {
+			return validator.NewValidationError(fmt.Sprintf("book %d is listed more than once", item.BookID))
+		}
+		seen[item.BookID] = true
+
+		cost, err := item.UnitCost.Resolve(currency)
+		if err != nil {
+			return validator.NewValidationError(fmt.Sprintf("unit_cost %s", err))
+		}
+
+		if cost.Currency != currency {
+			return validator.NewValidationError(fmt.Sprintf("unit_cost must be in %s", currency))
+		}
+
+		ids = append(ids, item.BookID)
+		lines = append(lines, &domain.PurchaseOrderLine{
+			BookID:          item.BookID,
+			QuantityOrdered: item.Quantity,
+			UnitCost:        cost,
+		})
+	}
+
+	books, err := u.repo.GetBookRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(books) != len(ids) {
+		return errors.New("book not found")
+	}
+
+	order.SupplierID, order.Supplier = supplier.ID, supplier
+	order.Currency = currency
+	order.Note = req.Note
+	order.Lines = lines
+
+	return nil
+}
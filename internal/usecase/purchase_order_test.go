@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreatePurchaseOrder(t *testing.T) {
+	Convey("Test create purchase order", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockPurchaseOrderRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+		repoMock.EXPECT().GetPurchaseOrderRepo().Return(orderRepo).AnyTimes()
+
+		orderUseCase := NewPurchaseOrderUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			req = &domain.CreatePurchaseOrderRequest{
+				SupplierID: 1,
+				Lines: []*domain.PurchaseOrderLineRequest{
+					{BookID: 10, Quantity: 5, UnitCost: money.New(40000, "")},
+					{BookID: 11, Quantity: 2, UnitCost: money.New(55000, "IDR")},
+				},
+			}
+		)
+
+		Convey("resp err when supplier doesnt exist", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+
+			err := orderUseCase.CreatePurchaseOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err unit cost in another currency", func() {
+			req.Lines[1].UnitCost = money.New(5, "USD")
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Supplier{ID: 1}, nil)
+
+			err := orderUseCase.CreatePurchaseOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err book listed twice", func() {
+			req.Lines[1].BookID = 10
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Supplier{ID: 1}, nil)
+
+			err := orderUseCase.CreatePurchaseOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when book doesnt exist", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Supplier{ID: 1}, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return([]*domain.Book{{ID: 10}}, nil)
+
+			err := orderUseCase.CreatePurchaseOrder(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Supplier{ID: 1}, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return([]*domain.Book{{ID: 10}, {ID: 11}}, nil)
+			orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *domain.PurchaseOrder) error {
+				So(order.Status, ShouldEqual, domain.PurchaseOrderDraft)
+				So(order.Currency, ShouldEqual, "IDR")
+				So(order.Lines, ShouldHaveLength, 2)
+				So(order.Lines[0].UnitCost, ShouldResemble, money.New(40000, "IDR"))
+				So(order.Total(), ShouldResemble, money.New(310000, "IDR"))
+				return nil
+			})
+
+			err := orderUseCase.CreatePurchaseOrder(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestPurchaseOrderTransitions(t *testing.T) {
+	Convey("Test purchase order transitions", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockPurchaseOrderRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPurchaseOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		orderUseCase := NewPurchaseOrderUseCase(config, repoMock)
+
+		ctx := context.Background()
+
+		Convey("resp err submit a cancelled order", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(&domain.PurchaseOrder{ID: 1, Status: domain.PurchaseOrderCancelled}, nil)
+
+			err := orderUseCase.SubmitPurchaseOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err cancel a received order", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(&domain.PurchaseOrder{ID: 1, Status: domain.PurchaseOrderReceived}, nil)
+
+			err := orderUseCase.CancelPurchaseOrder(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err update a submitted order", func() {
+			orderRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.PurchaseOrder{ID: 1, Status: domain.PurchaseOrderSubmitted}, nil)
+
+			err := orderUseCase.UpdatePurchaseOrder(ctx, &domain.UpdatePurchaseOrderRequest{ID: 1})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success submit a draft order", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(&domain.PurchaseOrder{ID: 1, Status: domain.PurchaseOrderDraft}, nil)
+			orderRepo.EXPECT().Update(gomock.Any(), gomock.Any(), "status", "updated_at", "submitted_at").DoAndReturn(func(_ context.Context, order *domain.PurchaseOrder, _ ...string) error {
+				So(order.Status, ShouldEqual, domain.PurchaseOrderSubmitted)
+				So(order.SubmittedAt, ShouldNotBeNil)
+				return nil
+			})
+
+			err := orderUseCase.SubmitPurchaseOrder(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestReceiveGoods(t *testing.T) {
+	Convey("Test receive goods", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockPurchaseOrderRepositoryImpl(ctrl)
+		stockRepo := repositoryMock.NewMockStockRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetPurchaseOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetStockRepo().Return(stockRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		orderUseCase := NewPurchaseOrderUseCase(config, repoMock)
+
+		var (
+			ctx   = context.Background()
+			order = &domain.PurchaseOrder{
+				ID:       1,
+				Status:   domain.PurchaseOrderSubmitted,
+				Currency: "IDR",
+				Lines: []*domain.PurchaseOrderLine{
+					{ID: 100, BookID: 10, QuantityOrdered: 5, UnitCost: money.New(40000, "IDR")},
+					{ID: 101, BookID: 11, QuantityOrdered: 2, QuantityReceived: 1, UnitCost: money.New(55000, "IDR")},
+				},
+			}
+			req = &domain.GoodsReceiptRequest{
+				PurchaseOrderID: 1,
+				Lines:           []*domain.GoodsReceiptLineRequest{{BookID: 10, Quantity: 3}},
+			}
+		)
+
+		Convey("resp err draft order", func() {
+			order.Status = domain.PurchaseOrderDraft
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			err := orderUseCase.ReceiveGoods(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err book not on the order", func() {
+			req.Lines[0].BookID = 12
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			err := orderUseCase.ReceiveGoods(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err receiving more than outstanding", func() {
+			req.Lines[0] = &domain.GoodsReceiptLineRequest{BookID: 11, Quantity: 2}
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			err := orderUseCase.ReceiveGoods(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success partially received", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			orderRepo.EXPECT().UpdateLineReceived(gomock.Any(), order.Lines[0]).Return(nil)
+			orderRepo.EXPECT().CreateReceipt(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, receipt *domain.GoodsReceipt) error {
+				receipt.ID = 7
+				return nil
+			})
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements, ShouldHaveLength, 1)
+				So(movements[0].Quantity, ShouldEqual, 3)
+				So(movements[0].UnitCost, ShouldResemble, money.New(40000, "IDR"))
+				So(*movements[0].ReferenceID, ShouldEqual, 7)
+				return nil
+			})
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at").Return(nil)
+
+			err := orderUseCase.ReceiveGoods(ctx, req)
+			So(err, ShouldBeNil)
+			So(order.Status, ShouldEqual, domain.PurchaseOrderPartiallyReceived)
+			So(order.Lines[0].QuantityReceived, ShouldEqual, 3)
+		})
+
+		Convey("resp success fully received", func() {
+			req.Lines = []*domain.GoodsReceiptLineRequest{{BookID: 10, Quantity: 5}, {BookID: 11, Quantity: 1}}
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			orderRepo.EXPECT().UpdateLineReceived(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			orderRepo.EXPECT().CreateReceipt(gomock.Any(), gomock.Any()).Return(nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+			orderRepo.EXPECT().Update(gomock.Any(), order, "status", "updated_at", "closed_at").Return(nil)
+
+			err := orderUseCase.ReceiveGoods(ctx, req)
+			So(err, ShouldBeNil)
+			So(order.Status, ShouldEqual, domain.PurchaseOrderReceived)
+			So(order.ClosedAt, ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type RMAUseCaseImpl interface {
+	CreateRMA(ctx context.Context, req *domain.CreateRMARequest) (*domain.RMA, error)
+	GetRMA(ctx context.Context, id int) (*domain.RMA, error)
+	ListRMAs(ctx context.Context, filter *domain.RMAFilter) ([]*domain.RMA, error)
+	InspectRMA(ctx context.Context, req *domain.InspectRMARequest) error
+	CancelRMA(ctx context.Context, id int) error
+}
+
+type rmaUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewRMAUseCase(config *config.MainConfig, repo repository.RepositoryImpl) RMAUseCaseImpl {
+	return &rmaUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// CreateRMA authorises the return of books the customer received, a book can not be returned more
+// often than it was ordered across every rma of the order.
+func (u *rmaUseCase) CreateRMA(ctx context.Context, req *domain.CreateRMARequest) (*domain.RMA, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var rma *domain.RMA
+	err := u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		order, err := u.repo.GetOrderRepo().GetByIDForUpdate(txCtx, req.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if order == nil {
+			return errors.New("order not found")
+		}
+
+		if order.Status != domain.OrderShipped && order.Status != domain.OrderDelivered {
+			return validator.NewValidationError(fmt.Sprintf("order is %s, only shipped or delivered orders can be returned", order.Status))
+		}
+
+		returned, err := u.repo.GetRMARepo().ReturnedQuantities(txCtx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		rma = &domain.RMA{
+			OrderID:       order.ID,
+			CustomerEmail: order.CustomerEmail,
+			Status:        domain.RMAOpen,
+			Note:          req.Note,
+			CreatedBy:     changedBy(txCtx),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+
+		for _, item := range req.Lines {
+			line := order.Line(item.BookID)
+			if line == nil {
+				return validator.NewValidationError(fmt.Sprintf("book %d is not on the order", item.BookID))
+			}
+
+			if rma.Line(item.BookID) != nil {
+				return validator.NewValidationError(fmt.Sprintf("book %d is listed more than once", item.BookID))
+			}
+
+			if left := line.Quantity - returned[item.BookID]; item.Quantity > left {
+				return validator.NewValidationError(fmt.Sprintf("book %d only has %d left to return", item.BookID, left))
+			}
+
+			rma.Lines = append(rma.Lines, &domain.RMALine{
+				OrderLineID: line.ID,
+				BookID:      line.BookID,
+				Quantity:    item.Quantity,
+				Reason:      item.Reason,
+				UnitPrice:   line.UnitPrice,
+			})
+		}
+
+		return u.repo.GetRMARepo().Create(txCtx, rma)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rma, nil
+}
+
+func (u *rmaUseCase) GetRMA(ctx context.Context, id int) (*domain.RMA, error) {
+	rma, err := u.repo.GetRMARepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rma == nil {
+		return nil, errors.New("rma not found")
+	}
+
+	return rma, nil
+}
+
+func (u *rmaUseCase) ListRMAs(ctx context.Context, filter *domain.RMAFilter) ([]*domain.RMA, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetRMARepo().List(ctx, filter)
+}
+
+// InspectRMA records the outcome of returned books. Every inspected book first comes back into stock,
+// written off books and books sent back to the supplier leave it again right away so the ledger shows both.
+func (u *rmaUseCase) InspectRMA(ctx context.Context, req *domain.InspectRMARequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		rma, err := u.repo.GetRMARepo().GetByIDForUpdate(txCtx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if rma == nil {
+			return errors.New("rma not found")
+		}
+
+		if rma.Status != domain.RMAOpen {
+			return validator.NewValidationError(fmt.Sprintf("rma is %s, only open rmas can be inspected", rma.Status))
+		}
+
+		now := time.Now()
+		by := changedBy(txCtx)
+
+		var movements []*domain.StockMovement
+		for _, item := range req.Lines {
+			line := rma.Line(item.BookID)
+			if line == nil {
+				return validator.NewValidationError(fmt.Sprintf("book %d is not on the rma", item.BookID))
+			}
+
+			if line.IsInspected() {
+				return validator.NewValidationError(fmt.Sprintf("book %d is already inspected", item.BookID))
+			}
+
+			paid := line.UnitPrice.Mul(int64(line.Quantity))
+			refund := paid
+			if item.RefundAmount != nil {
+				if *item.RefundAmount > paid.Amount {
+					return validator.NewValidationError(fmt.Sprintf("refund of book %d can not be more than %s", item.BookID, paid.Format()))
+				}
+
+				refund = money.New(*item.RefundAmount, paid.Currency)
+			}
+
+			line.Outcome, line.Refund, line.Note = item.Outcome, refund, item.Note
+			line.InspectedBy, line.InspectedAt = by, &now
+			if err := u.repo.GetRMARepo().UpdateLine(txCtx, line); err != nil {
+				return err
+			}
+
+			movements = append(movements, rmaMovement(rma, line, domain.StockMovementSaleReturn, line.Quantity))
+
+			switch line.Outcome {
+			case domain.RMAOutcomeWriteOff:
+				movements = append(movements, rmaMovement(rma, line, domain.StockMovementWriteOff, -line.Quantity))
+			case domain.RMAOutcomeToSupplier:
+				movements = append(movements, rmaMovement(rma, line, domain.StockMovementSupplierReturn, -line.Quantity))
+			}
+		}
+
+		if err := recordMovements(txCtx, u.repo, movements); err != nil {
+			return err
+		}
+
+		columns := []string{"updated_at"}
+		rma.UpdatedAt = now
+		if rma.IsInspected() {
+			rma.Status, rma.ClosedAt = domain.RMAClosed, &now
+			columns = append(columns, "status", "closed_at")
+		}
+
+		return u.repo.GetRMARepo().Update(txCtx, rma, columns...)
+	})
+}
+
+// CancelRMA withdraws an rma before any of its books has been inspected.
+func (u *rmaUseCase) CancelRMA(ctx context.Context, id int) error {
+	return u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+		rma, err := u.repo.GetRMARepo().GetByIDForUpdate(txCtx, id)
+		if err != nil {
+			return err
+		}
+
+		if rma == nil {
+			return errors.New("rma not found")
+		}
+
+		if rma.Status != domain.RMAOpen {
+			return validator.NewValidationError(fmt.Sprintf("rma is %s, only open rmas can be cancelled", rma.Status))
+		}
+
+		for _, line := range rma.Lines {
+			if line.IsInspected() {
+				return validator.NewValidationError("rma has inspected books and can not be cancelled")
+			}
+		}
+
+		now := time.Now()
+		rma.Status, rma.UpdatedAt, rma.ClosedAt = domain.RMACancelled, now, &now
+
+		return u.repo.GetRMARepo().Update(txCtx, rma, "status", "updated_at", "closed_at")
+	})
+}
+
+func rmaMovement(rma *domain.RMA, line *domain.RMALine, kind string, quantity int) *domain.StockMovement {
+	return &domain.StockMovement{
+		BookID:        line.BookID,
+		Type:          kind,
+		Quantity:      quantity,
+		ReferenceType: domain.StockReferenceRMA,
+		ReferenceID:   &rma.ID,
+		Note:          line.Note,
+		CreatedBy:     line.InspectedBy,
+		CreatedAt:     *line.InspectedAt,
+	}
+}
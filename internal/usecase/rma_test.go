@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateRMA(t *testing.T) {
+	Convey("Test create rma", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockOrderRepositoryImpl(ctrl)
+		rmaRepo := repositoryMock.NewMockRMARepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetRMARepo().Return(rmaRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		rmaUseCase := NewRMAUseCase(config, repoMock)
+
+		var (
+			ctx   = context.Background()
+			order = &domain.Order{
+				ID:            1,
+				CustomerEmail: "budi@mail.com",
+				Status:        domain.OrderDelivered,
+				Lines:         []*domain.OrderLine{{ID: 50, BookID: 10, Quantity: 3, UnitPrice: money.New(120000, "IDR")}},
+			}
+			req = &domain.CreateRMARequest{
+				OrderID: 1,
+				Lines:   []*domain.RMALineRequest{{BookID: 10, Quantity: 2, Reason: domain.RMAReasonDamaged}},
+			}
+		)
+
+		Convey("resp err order not shipped", func() {
+			order.Status = domain.OrderPaid
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+
+			_, err := rmaUseCase.CreateRMA(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err book not on the order", func() {
+			req.Lines[0].BookID = 11
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			rmaRepo.EXPECT().ReturnedQuantities(gomock.Any(), 1).Return(map[int]int{}, nil)
+
+			_, err := rmaUseCase.CreateRMA(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err returning more than left", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			rmaRepo.EXPECT().ReturnedQuantities(gomock.Any(), 1).Return(map[int]int{10: 2}, nil)
+
+			_, err := rmaUseCase.CreateRMA(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			orderRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 1).Return(order, nil)
+			rmaRepo.EXPECT().ReturnedQuantities(gomock.Any(), 1).Return(map[int]int{10: 1}, nil)
+			rmaRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+			rma, err := rmaUseCase.CreateRMA(ctx, req)
+			So(err, ShouldBeNil)
+			So(rma.Status, ShouldEqual, domain.RMAOpen)
+			So(rma.CustomerEmail, ShouldEqual, "budi@mail.com")
+			So(rma.Lines[0].OrderLineID, ShouldEqual, 50)
+			So(rma.Lines[0].UnitPrice, ShouldResemble, money.New(120000, "IDR"))
+		})
+	})
+}
+
+func TestInspectRMA(t *testing.T) {
+	Convey("Test inspect rma", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		rmaRepo := repositoryMock.NewMockRMARepositoryImpl(ctrl)
+		stockRepo := repositoryMock.NewMockStockRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetRMARepo().Return(rmaRepo).AnyTimes()
+		repoMock.EXPECT().GetStockRepo().Return(stockRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		rmaUseCase := NewRMAUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			rma = &domain.RMA{
+				ID:     4,
+				Status: domain.RMAOpen,
+				Lines: []*domain.RMALine{
+					{ID: 40, BookID: 10, Quantity: 2, UnitPrice: money.New(120000, "IDR")},
+					{ID: 41, BookID: 11, Quantity: 1, UnitPrice: money.New(80000, "IDR")},
+				},
+			}
+			refund = int64(300000)
+			req    = &domain.InspectRMARequest{
+				ID:    4,
+				Lines: []*domain.InspectRMALineRequest{{BookID: 10, Outcome: domain.RMAOutcomeWriteOff}},
+			}
+		)
+
+		Convey("resp err refund above the price paid", func() {
+			req.Lines[0].RefundAmount = &refund
+			rmaRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 4).Return(rma, nil)
+
+			err := rmaUseCase.InspectRMA(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err line already inspected", func() {
+			rma.Lines[0].Outcome = domain.RMAOutcomeRestock
+			rmaRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 4).Return(rma, nil)
+
+			err := rmaUseCase.InspectRMA(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success write off keeps the rma open", func() {
+			rmaRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 4).Return(rma, nil)
+			rmaRepo.EXPECT().UpdateLine(gomock.Any(), rma.Lines[0]).Return(nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements, ShouldHaveLength, 2)
+				So(movements[0].Type, ShouldEqual, domain.StockMovementSaleReturn)
+				So(movements[0].Quantity, ShouldEqual, 2)
+				So(movements[1].Type, ShouldEqual, domain.StockMovementWriteOff)
+				So(movements[1].Quantity, ShouldEqual, -2)
+				return nil
+			})
+			rmaRepo.EXPECT().Update(gomock.Any(), rma, "updated_at").Return(nil)
+
+			err := rmaUseCase.InspectRMA(ctx, req)
+			So(err, ShouldBeNil)
+			So(rma.Lines[0].Refund, ShouldResemble, money.New(240000, "IDR"))
+			So(rma.Status, ShouldEqual, domain.RMAOpen)
+		})
+
+		Convey("resp success restock closes the rma", func() {
+			refund = 50000
+			rma.Lines[0].Outcome = domain.RMAOutcomeRestock
+			req.Lines[0] = &domain.InspectRMALineRequest{BookID: 11, Outcome: domain.RMAOutcomeRestock, RefundAmount: &refund}
+			rmaRepo.EXPECT().GetByIDForUpdate(gomock.Any(), 4).Return(rma, nil)
+			rmaRepo.EXPECT().UpdateLine(gomock.Any(), rma.Lines[1]).Return(nil)
+			stockRepo.EXPECT().Record(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, movements []*domain.StockMovement) error {
+				So(movements, ShouldHaveLength, 1)
+				So(movements[0].Quantity, ShouldEqual, 1)
+				return nil
+			})
+			rmaRepo.EXPECT().Update(gomock.Any(), rma, "updated_at", "status", "closed_at").Return(nil)
+
+			err := rmaUseCase.InspectRMA(ctx, req)
+			So(err, ShouldBeNil)
+			So(rma.Lines[1].Refund, ShouldResemble, money.New(50000, "IDR"))
+			So(rma.Status, ShouldEqual, domain.RMAClosed)
+		})
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/metrics"
+)
+
+type StockUseCaseImpl interface {
+	GetStockLedger(ctx context.Context, bookID int) (*domain.StockLedger, error)
+}
+
+type stockUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewStockUseCase(config *config.MainConfig, repo repository.RepositoryImpl) StockUseCaseImpl {
+	return &stockUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+// recordMovements records movements in the ledger and counts them once the transaction of ctx commits.
+func recordMovements(ctx context.Context, repo repository.RepositoryImpl, movements []*domain.StockMovement) error {
+	if err := repo.GetStockRepo().Record(ctx, movements); err != nil {
+		return err
+	}
+
+	repository.AfterCommit(ctx, func() {
+		for _, movement := range movements {
+			metrics.StockMoved(movement.Type, movement.Quantity)
+		}
+	})
+
+	return nil
+}
+
+func (u *stockUseCase) GetStockLedger(ctx context.Context, bookID int) (*domain.StockLedger, error) {
+	book, err := u.repo.GetBookRepo().GetByID(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if book == nil {
+		return nil, errors.New("book not found")
+	}
+
+	movements, err := u.repo.GetStockRepo().ListMovements(ctx, book.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.StockLedger{
+		BookID:    book.ID,
+		Stock:     book.Stock,
+		Reserved:  book.Reserved,
+		Available: book.Stock - book.Reserved,
+		Movements: movements,
+	}, nil
+}
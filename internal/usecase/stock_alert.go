@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/notifier"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type StockAlertUseCaseImpl interface {
+	SetReorderPoint(ctx context.Context, req *domain.SetReorderPointRequest) error
+	GetReorderPoint(ctx context.Context, bookID int) (*domain.ReorderPoint, error)
+	DeleteReorderPoint(ctx context.Context, bookID int) error
+	SetLocationReorderPoint(ctx context.Context, req *domain.SetReorderPointRequest) error
+	GetLocationReorderPoint(ctx context.Context, location string) (*domain.LocationReorderPoint, error)
+	DeleteLocationReorderPoint(ctx context.Context, location string) error
+	ListAlerts(ctx context.Context, filter *domain.StockAlertFilter) ([]*domain.StockAlert, error)
+	EvaluateStockAlerts(ctx context.Context, now time.Time) (int, error)
+}
+
+type stockAlertUseCase struct {
+	config   *config.MainConfig
+	repo     repository.RepositoryImpl
+	notifier notifier.Notifier
+}
+
+func NewStockAlertUseCase(config *config.MainConfig, repo repository.RepositoryImpl, notifier notifier.Notifier) StockAlertUseCaseImpl {
+	return &stockAlertUseCase{
+		config:   config,
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+func (u *stockAlertUseCase) SetReorderPoint(ctx context.Context, req *domain.SetReorderPointRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	book, err := u.repo.GetBookRepo().GetByID(ctx, req.BookID)
+	if err != nil {
+		return err
+	}
+
+	if book == nil {
+		return errors.New("book not found")
+	}
+
+	if err := u.checkSupplierExists(ctx, req.SupplierID); err != nil {
+		return err
+	}
+
+	return u.repo.GetStockAlertRepo().SetReorderPoint(ctx, &domain.ReorderPoint{
+		BookID:          book.ID,
+		ReorderPoint:    *req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		SupplierID:      req.SupplierID,
+		UpdatedAt:       time.Now(),
+	})
+}
+
+func (u *stockAlertUseCase) GetReorderPoint(ctx context.Context, bookID int) (*domain.ReorderPoint, error) {
+	point, err := u.repo.GetStockAlertRepo().GetReorderPoint(ctx, bookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if point == nil {
+		return nil, errors.New("reorder point not found")
+	}
+
+	return point, nil
+}
+
+// DeleteReorderPoint stops watching the book, its open alert resolves on the next evaluation.
+func (u *stockAlertUseCase) DeleteReorderPoint(ctx context.Context, bookID int) error {
+	if _, err := u.GetReorderPoint(ctx, bookID); err != nil {
+		return err
+	}
+
+	return u.repo.GetStockAlertRepo().DeleteReorderPoint(ctx, bookID)
+}
+
+// SetLocationReorderPoint watches every book shelved at the location that has no reorder point of its own.
+func (u *stockAlertUseCase) SetLocationReorderPoint(ctx context.Context, req *domain.SetReorderPointRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if req.Location == "" {
+		return validator.NewValidationError("location is required")
+	}
+
+	books, err := u.repo.GetBookRepo().GetByLocation(ctx, req.Location)
+	if err != nil {
+		return err
+	}
+
+	if len(books) == 0 {
+		return validator.NewValidationError(fmt.Sprintf("there are no books at %s", req.Location))
+	}
+
+	if err := u.checkSupplierExists(ctx, req.SupplierID); err != nil {
+		return err
+	}
+
+	return u.repo.GetStockAlertRepo().SetLocationReorderPoint(ctx, &domain.LocationReorderPoint{
+		Location:        req.Location,
+		ReorderPoint:    *req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		SupplierID:      req.SupplierID,
+		UpdatedAt:       time.Now(),
+	})
+}
+
+func (u *stockAlertUseCase) GetLocationReorderPoint(ctx context.Context, location string) (*domain.LocationReorderPoint, error) {
+	point, err := u.repo.GetStockAlertRepo().GetLocationReorderPoint(ctx, location)
+	if err != nil {
+		return nil, err
+	}
+
+	if point == nil {
+		return nil, errors.New("reorder point not found")
+	}
+
+	return point, nil
+}
+
+// DeleteLocationReorderPoint stops watching the books of the location that have no reorder point of
+// their own, their open alerts resolve on the next evaluation.
+func (u *stockAlertUseCase) DeleteLocationReorderPoint(ctx context.Context, location string) error {
+	if _, err := u.GetLocationReorderPoint(ctx, location); err != nil {
+		return err
+	}
+
+	return u.repo.GetStockAlertRepo().DeleteLocationReorderPoint(ctx, location)
+}
+
+func (u *stockAlertUseCase) checkSupplierExists(ctx context.Context, supplierID *int) error {
+	if supplierID == nil {
+		return nil
+	}
+
+	supplier, err := u.repo.GetSupplierRepo().GetByID(ctx, *supplierID)
+	if err != nil {
+		return err
+	}
+
+	if supplier == nil {
+		return errors.New("supplier not found")
+	}
+
+	return nil
+}
+
+func (u *stockAlertUseCase) ListAlerts(ctx context.Context, filter *domain.StockAlertFilter) ([]*domain.StockAlert, error) {
+	if err := validator.ValidateStruct(filter); err != nil {
+		return nil, err
+	}
+
+	return u.repo.GetStockAlertRepo().ListAlerts(ctx, filter)
+}
+
+// EvaluateStockAlerts resolves the alerts of books that recovered and raises one alert per book that
+// dropped to its reorder point, it returns how many alerts were raised. Failed notifications are
+// reported after every alert was tried, the alerts themselves stay raised.
+func (u *stockAlertUseCase) EvaluateStockAlerts(ctx context.Context, now time.Time) (int, error) {
+	if _, err := u.repo.GetStockAlertRepo().ResolveRecovered(ctx, now); err != nil {
+		return 0, err
+	}
+
+	lows, err := u.repo.GetStockAlertRepo().ListLowStock(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var raised []*raisedAlert
+	for _, low := range lows {
+		alert := &domain.StockAlert{
+			BookID:       low.BookID,
+			Available:    low.Available,
+			ReorderPoint: low.ReorderPoint.ReorderPoint,
+			Status:       domain.StockAlertOpen,
+			CreatedAt:    now,
+		}
+
+		// another evaluator may have raised it in the meantime
+		created, err := u.repo.GetStockAlertRepo().CreateAlert(ctx, alert)
+		if err != nil {
+			return len(raised), err
+		}
+
+		if created {
+			raised = append(raised, &raisedAlert{alert: alert, low: low})
+		}
+	}
+
+	if u.config.StockAlertAutoDraft {
+		if err := u.draftPurchaseOrders(ctx, raised, now); err != nil {
+			return len(raised), err
+		}
+	}
+
+	var errs []error
+	for _, r := range raised {
+		if err := u.notify(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(raised), errors.Join(errs...)
+}
+
+type raisedAlert struct {
+	alert *domain.StockAlert
+	low   *domain.LowStock
+}
+
+// draftPurchaseOrders drafts one purchase order per supplier and currency for the raised alerts whose
+// reorder point names a supplier, each book is ordered at its last cost with that supplier. Books never
+// bought from the supplier are left for a person to order.
+func (u *stockAlertUseCase) draftPurchaseOrders(ctx context.Context, raised []*raisedAlert, now time.Time) error {
+	type draft struct {
+		order  *domain.PurchaseOrder
+		alerts []*domain.StockAlert
+	}
+
+	drafts := make(map[string]*draft)
+	for _, r := range raised {
+		if r.low.SupplierID == nil {
+			continue
+		}
+
+		cost, err := u.repo.GetPurchaseOrderRepo().LastUnitCost(ctx, *r.low.SupplierID, r.low.BookID)
+		if err != nil {
+			return err
+		}
+
+		if cost == nil {
+			continue
+		}
+
+		key := fmt.Sprintf("%d/%s", *r.low.SupplierID, cost.Currency)
+		d, ok := drafts[key]
+		if !ok {
+			d = &draft{order: &domain.PurchaseOrder{
+				SupplierID: *r.low.SupplierID,
+				Status:     domain.PurchaseOrderDraft,
+				Currency:   cost.Currency,
+				Note:       "drafted for low stock alerts",
+				CreatedBy:  "system",
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			}}
+			drafts[key] = d
+		}
+
+		d.order.Lines = append(d.order.Lines, &domain.PurchaseOrderLine{
+			BookID:          r.low.BookID,
+			QuantityOrdered: r.low.ReorderQuantity,
+			UnitCost:        *cost,
+		})
+		d.alerts = append(d.alerts, r.alert)
+	}
+
+	keys := make([]string, 0, len(drafts))
+	for key := range drafts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		d := drafts[key]
+
+		err := u.repo.GetTransactionRepo().WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := u.repo.GetPurchaseOrderRepo().Create(txCtx, d.order); err != nil {
+				return err
+			}
+
+			ids := make([]int, 0, len(d.alerts))
+			for _, alert := range d.alerts {
+				ids = append(ids, alert.ID)
+			}
+
+			return u.repo.GetStockAlertRepo().SetAlertPurchaseOrder(txCtx, ids, d.order.ID)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, alert := range d.alerts {
+			alert.PurchaseOrderID = &d.order.ID
+		}
+	}
+
+	return nil
+}
+
+func (u *stockAlertUseCase) notify(ctx context.Context, r *raisedAlert) error {
+	if u.notifier == nil {
+		return nil
+	}
+
+	fields := map[string]interface{}{
+		"alert_id":         r.alert.ID,
+		"book_id":          r.alert.BookID,
+		"available":        r.alert.Available,
+		"reorder_point":    r.alert.ReorderPoint,
+		"reorder_quantity": r.low.ReorderQuantity,
+	}
+	if r.low.Location != "" {
+		fields["location"] = r.low.Location
+	}
+	if r.alert.PurchaseOrderID != nil {
+		fields["purchase_order_id"] = *r.alert.PurchaseOrderID
+	}
+
+	return u.notifier.Notify(ctx, notifier.Message{
+		Subject: fmt.Sprintf("Low stock: %s", r.low.Title),
+		Body:    r.alert.Describe(r.low.Title, r.low.ReorderQuantity),
+		Fields:  fields,
+	})
+}
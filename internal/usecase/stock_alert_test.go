@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/notifier"
+	pkgMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/pkg"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSetReorderPoint(t *testing.T) {
+	Convey("Test set reorder point", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+		alertRepo := repositoryMock.NewMockStockAlertRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+		repoMock.EXPECT().GetStockAlertRepo().Return(alertRepo).AnyTimes()
+
+		alertUseCase := NewStockAlertUseCase(config, repoMock, nil)
+
+		var (
+			ctx        = context.Background()
+			point      = 5
+			supplierID = 3
+			req        = &domain.SetReorderPointRequest{BookID: 10, ReorderPoint: &point, ReorderQuantity: 20, SupplierID: &supplierID}
+		)
+
+		Convey("resp err reorder point is required", func() {
+			req.ReorderPoint = nil
+
+			err := alertUseCase.SetReorderPoint(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when supplier doesnt exist", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(&domain.Book{ID: 10}, nil)
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 3).Return(nil, nil)
+
+			err := alertUseCase.SetReorderPoint(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			bookRepo.EXPECT().GetByID(gomock.Any(), 10).Return(&domain.Book{ID: 10}, nil)
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 3).Return(&domain.Supplier{ID: 3}, nil)
+			alertRepo.EXPECT().SetReorderPoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *domain.ReorderPoint) error {
+				So(point.ReorderPoint, ShouldEqual, 5)
+				So(point.ReorderQuantity, ShouldEqual, 20)
+				So(*point.SupplierID, ShouldEqual, 3)
+				return nil
+			})
+
+			err := alertUseCase.SetReorderPoint(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestSetLocationReorderPoint(t *testing.T) {
+	Convey("Test set location reorder point", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+		alertRepo := repositoryMock.NewMockStockAlertRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+		repoMock.EXPECT().GetStockAlertRepo().Return(alertRepo).AnyTimes()
+
+		alertUseCase := NewStockAlertUseCase(config, repoMock, nil)
+
+		var (
+			ctx   = context.Background()
+			point = 5
+			req   = &domain.SetReorderPointRequest{Location: "A-01", ReorderPoint: &point, ReorderQuantity: 20}
+		)
+
+		Convey("resp err location is required", func() {
+			req.Location = ""
+
+			err := alertUseCase.SetLocationReorderPoint(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when no book is at the location", func() {
+			bookRepo.EXPECT().GetByLocation(gomock.Any(), "A-01").Return(nil, nil)
+
+			err := alertUseCase.SetLocationReorderPoint(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			bookRepo.EXPECT().GetByLocation(gomock.Any(), "A-01").Return([]*domain.Book{{ID: 10, Location: "A-01"}}, nil)
+			alertRepo.EXPECT().SetLocationReorderPoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, point *domain.LocationReorderPoint) error {
+				So(point.Location, ShouldEqual, "A-01")
+				So(point.ReorderPoint, ShouldEqual, 5)
+				So(point.ReorderQuantity, ShouldEqual, 20)
+				So(point.SupplierID, ShouldBeNil)
+				return nil
+			})
+
+			err := alertUseCase.SetLocationReorderPoint(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestEvaluateStockAlerts(t *testing.T) {
+	Convey("Test evaluate stock alerts", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		alertRepo := repositoryMock.NewMockStockAlertRepositoryImpl(ctrl)
+		orderRepo := repositoryMock.NewMockPurchaseOrderRepositoryImpl(ctrl)
+		trx := repositoryMock.NewMockTransactionRepositoryImpl(ctrl)
+		notifyMock := pkgMock.NewMockNotifier(ctrl)
+
+		repoMock.EXPECT().GetStockAlertRepo().Return(alertRepo).AnyTimes()
+		repoMock.EXPECT().GetPurchaseOrderRepo().Return(orderRepo).AnyTimes()
+		repoMock.EXPECT().GetTransactionRepo().Return(trx).AnyTimes()
+		trx.EXPECT().WithTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(txCtx context.Context) error, opts ...repository.TxOption) error {
+			return fn(ctx)
+		}).AnyTimes()
+
+		alertUseCase := NewStockAlertUseCase(config, repoMock, notifyMock)
+
+		var (
+			ctx        = context.Background()
+			now        = time.Now()
+			supplierID = 3
+			lows       = []*domain.LowStock{
+				{ReorderPoint: domain.ReorderPoint{BookID: 10, ReorderPoint: 5, ReorderQuantity: 20, SupplierID: &supplierID}, Title: "Dune", Available: 2},
+				{ReorderPoint: domain.ReorderPoint{BookID: 11, ReorderPoint: 3, ReorderQuantity: 10}, Title: "Emma", Available: 0},
+			}
+		)
+
+		alertRepo.EXPECT().ResolveRecovered(gomock.Any(), now).Return(int64(1), nil)
+		alertRepo.EXPECT().ListLowStock(gomock.Any()).Return(lows, nil)
+
+		Convey("resp success raises and notifies", func() {
+			id := 0
+			alertRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alert *domain.StockAlert) (bool, error) {
+				id++
+				alert.ID = id
+				return true, nil
+			}).Times(2)
+
+			var subjects []string
+			notifyMock.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg notifier.Message) error {
+				subjects = append(subjects, msg.Subject)
+				return nil
+			}).Times(2)
+
+			raised, err := alertUseCase.EvaluateStockAlerts(ctx, now)
+			So(err, ShouldBeNil)
+			So(raised, ShouldEqual, 2)
+			So(subjects, ShouldResemble, []string{"Low stock: Dune", "Low stock: Emma"})
+		})
+
+		Convey("resp success skips books already alerted by another evaluator", func() {
+			alertRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(false, nil)
+			alertRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(true, nil)
+			notifyMock.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+
+			raised, err := alertUseCase.EvaluateStockAlerts(ctx, now)
+			So(err, ShouldBeNil)
+			So(raised, ShouldEqual, 1)
+		})
+
+		Convey("resp err when a notification fails", func() {
+			alertRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).Return(true, nil).Times(2)
+			notifyMock.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(errors.New("webhook down")).Times(2)
+
+			raised, err := alertUseCase.EvaluateStockAlerts(ctx, now)
+			So(err, ShouldNotBeNil)
+			So(raised, ShouldEqual, 2)
+		})
+
+		Convey("resp success drafts a purchase order for books with a supplier", func() {
+			config.StockAlertAutoDraft = true
+
+			alertRepo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alert *domain.StockAlert) (bool, error) {
+				alert.ID = alert.BookID * 10
+				return true, nil
+			}).Times(2)
+			cost := money.New(40000, "IDR")
+			orderRepo.EXPECT().LastUnitCost(gomock.Any(), 3, 10).Return(&cost, nil)
+			orderRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *domain.PurchaseOrder) error {
+				So(order.Status, ShouldEqual, domain.PurchaseOrderDraft)
+				So(order.SupplierID, ShouldEqual, 3)
+				So(order.Lines, ShouldHaveLength, 1)
+				So(order.Lines[0].QuantityOrdered, ShouldEqual, 20)
+				So(order.Lines[0].UnitCost, ShouldResemble, cost)
+				order.ID = 77
+				return nil
+			})
+			alertRepo.EXPECT().SetAlertPurchaseOrder(gomock.Any(), []int{100}, 77).Return(nil)
+
+			var fields []map[string]interface{}
+			notifyMock.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, msg notifier.Message) error {
+				fields = append(fields, msg.Fields)
+				return nil
+			}).Times(2)
+
+			raised, err := alertUseCase.EvaluateStockAlerts(ctx, now)
+			So(err, ShouldBeNil)
+			So(raised, ShouldEqual, 2)
+			So(fields[0]["purchase_order_id"], ShouldEqual, 77)
+			So(fields[1], ShouldNotContainKey, "purchase_order_id")
+		})
+	})
+}
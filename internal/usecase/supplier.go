@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type SupplierUseCaseImpl interface {
+	CreateSupplier(ctx context.Context, req *domain.CreateSupplierRequest) error
+	GetSupplier(ctx context.Context, id int) (*domain.Supplier, error)
+	ListSuppliers(ctx context.Context) ([]*domain.Supplier, error)
+	UpdateSupplier(ctx context.Context, req *domain.UpdateSupplierRequest) error
+	DeleteSupplier(ctx context.Context, id int) error
+}
+
+type supplierUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewSupplierUseCase(config *config.MainConfig, repo repository.RepositoryImpl) SupplierUseCaseImpl {
+	return &supplierUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *supplierUseCase) CreateSupplier(ctx context.Context, req *domain.CreateSupplierRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	supplier, err := u.repo.GetSupplierRepo().GetByName(ctx, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if supplier != nil {
+		return errors.New("supplier already exist")
+	}
+
+	return u.repo.GetSupplierRepo().Create(ctx, &domain.Supplier{
+		Name:         req.Name,
+		Email:        req.Email,
+		PhoneNumber:  req.PhoneNumber,
+		Address:      req.Address,
+		LeadTimeDays: req.LeadTimeDays,
+		CreatedAt:    time.Now(),
+	})
+}
+
+func (u *supplierUseCase) GetSupplier(ctx context.Context, id int) (*domain.Supplier, error) {
+	supplier, err := u.repo.GetSupplierRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if supplier == nil {
+		return nil, errors.New("supplier not found")
+	}
+
+	return supplier, nil
+}
+
+func (u *supplierUseCase) ListSuppliers(ctx context.Context) ([]*domain.Supplier, error) {
+	return u.repo.GetSupplierRepo().List(ctx)
+}
+
+func (u *supplierUseCase) UpdateSupplier(ctx context.Context, req *domain.UpdateSupplierRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	supplier, err := u.GetSupplier(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := u.repo.GetSupplierRepo().GetByName(ctx, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != supplier.ID {
+		return errors.New("supplier already exist")
+	}
+
+	supplier.Name = req.Name
+	supplier.Email = req.Email
+	supplier.PhoneNumber = req.PhoneNumber
+	supplier.Address = req.Address
+	supplier.LeadTimeDays = req.LeadTimeDays
+
+	return u.repo.GetSupplierRepo().Update(ctx, supplier)
+}
+
+// DeleteSupplier removes a supplier that never received a purchase order.
+func (u *supplierUseCase) DeleteSupplier(ctx context.Context, id int) error {
+	if _, err := u.GetSupplier(ctx, id); err != nil {
+		return err
+	}
+
+	ordered, err := u.repo.GetPurchaseOrderRepo().ExistsForSupplier(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if ordered {
+		return errors.New("supplier has purchase orders")
+	}
+
+	return u.repo.GetSupplierRepo().Delete(ctx, id)
+}
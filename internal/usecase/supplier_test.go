@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateSupplier(t *testing.T) {
+	Convey("Test create supplier", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+
+		supplierUseCase := NewSupplierUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			req = &domain.CreateSupplierRequest{Name: "Gramedia", Email: "order@gramedia.com", LeadTimeDays: 14}
+		)
+
+		Convey("resp err validator", func() {
+			err := supplierUseCase.CreateSupplier(ctx, &domain.CreateSupplierRequest{Name: "Gramedia", Email: "gramedia"})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when supplier already exist", func() {
+			supplierRepo.EXPECT().GetByName(gomock.Any(), "Gramedia").Return(&domain.Supplier{ID: 1, Name: "Gramedia"}, nil)
+			err := supplierUseCase.CreateSupplier(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			supplierRepo.EXPECT().GetByName(gomock.Any(), "Gramedia").Return(nil, nil)
+			supplierRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Supplier) error {
+				So(req.Name, ShouldEqual, "Gramedia")
+				So(req.LeadTimeDays, ShouldEqual, 14)
+				return nil
+			})
+			err := supplierUseCase.CreateSupplier(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestGetSupplier(t *testing.T) {
+	Convey("Test get supplier", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+
+		supplierUseCase := NewSupplierUseCase(config, repoMock)
+
+		ctx := context.Background()
+
+		Convey("resp err when supplier doesnt exist", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			supplier, err := supplierUseCase.GetSupplier(ctx, 1)
+			So(err, ShouldNotBeNil)
+			So(supplier, ShouldBeNil)
+		})
+
+		Convey("resp success", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Supplier{ID: 1, Name: "Gramedia"}, nil)
+			supplier, err := supplierUseCase.GetSupplier(ctx, 1)
+			So(err, ShouldBeNil)
+			So(supplier.Name, ShouldEqual, "Gramedia")
+		})
+	})
+}
+
+func TestUpdateSupplier(t *testing.T) {
+	Convey("Test update supplier", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+
+		supplierUseCase := NewSupplierUseCase(config, repoMock)
+
+		var (
+			ctx      = context.Background()
+			req      = &domain.UpdateSupplierRequest{ID: 1, CreateSupplierRequest: domain.CreateSupplierRequest{Name: "Mizan", LeadTimeDays: 7}}
+			supplier = &domain.Supplier{ID: 1, Name: "Gramedia"}
+		)
+
+		Convey("resp err when supplier doesnt exist", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := supplierUseCase.UpdateSupplier(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when renamed to the name of another supplier", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(supplier, nil)
+			supplierRepo.EXPECT().GetByName(gomock.Any(), "Mizan").Return(&domain.Supplier{ID: 2, Name: "Mizan"}, nil)
+			err := supplierUseCase.UpdateSupplier(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(supplier, nil)
+			supplierRepo.EXPECT().GetByName(gomock.Any(), "Mizan").Return(nil, nil)
+			supplierRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Supplier) error {
+				So(req.Name, ShouldEqual, "Mizan")
+				So(req.LeadTimeDays, ShouldEqual, 7)
+				return nil
+			})
+			err := supplierUseCase.UpdateSupplier(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestDeleteSupplier(t *testing.T) {
+	Convey("Test delete supplier", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		supplierRepo := repositoryMock.NewMockSupplierRepositoryImpl(ctrl)
+		purchaseOrderRepo := repositoryMock.NewMockPurchaseOrderRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetSupplierRepo().Return(supplierRepo).AnyTimes()
+		repoMock.EXPECT().GetPurchaseOrderRepo().Return(purchaseOrderRepo).AnyTimes()
+
+		supplierUseCase := NewSupplierUseCase(config, repoMock)
+
+		var (
+			ctx      = context.Background()
+			errResp  = errors.New("error")
+			supplier = &domain.Supplier{ID: 1, Name: "Gramedia"}
+		)
+
+		Convey("resp err when get supplier by id", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errResp)
+			err := supplierUseCase.DeleteSupplier(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when supplier doesnt exist", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := supplierUseCase.DeleteSupplier(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when supplier has purchase orders", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(supplier, nil)
+			purchaseOrderRepo.EXPECT().ExistsForSupplier(gomock.Any(), 1).Return(true, nil)
+			err := supplierUseCase.DeleteSupplier(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			supplierRepo.EXPECT().GetByID(gomock.Any(), 1).Return(supplier, nil)
+			purchaseOrderRepo.EXPECT().ExistsForSupplier(gomock.Any(), 1).Return(false, nil)
+			supplierRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			err := supplierUseCase.DeleteSupplier(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
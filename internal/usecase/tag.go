@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type TagUseCaseImpl interface {
+	CreateTag(ctx context.Context, req *domain.CreateTagRequest) error
+	ListTags(ctx context.Context) ([]*domain.Tag, error)
+	UpdateTag(ctx context.Context, req *domain.UpdateTagRequest) error
+	DeleteTag(ctx context.Context, id int) error
+}
+
+type tagUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+}
+
+func NewTagUseCase(config *config.MainConfig, repo repository.RepositoryImpl) TagUseCaseImpl {
+	return &tagUseCase{
+		config: config,
+		repo:   repo,
+	}
+}
+
+func (u *tagUseCase) CreateTag(ctx context.Context, req *domain.CreateTagRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	name := domain.NormalizeTag(req.Name)
+	if err := u.checkNameAvailable(ctx, name, 0); err != nil {
+		return err
+	}
+
+	return u.repo.GetTagRepo().Create(ctx, &domain.Tag{Name: name})
+}
+
+func (u *tagUseCase) ListTags(ctx context.Context) ([]*domain.Tag, error) {
+	return u.repo.GetTagRepo().List(ctx)
+}
+
+func (u *tagUseCase) UpdateTag(ctx context.Context, req *domain.UpdateTagRequest) error {
+	if err := validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	tag, err := u.getTag(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+
+	tag.Name = domain.NormalizeTag(req.Name)
+	if err := u.checkNameAvailable(ctx, tag.Name, tag.ID); err != nil {
+		return err
+	}
+
+	return u.repo.GetTagRepo().Update(ctx, tag)
+}
+
+// DeleteTag removes the tag from every book that carries it.
+func (u *tagUseCase) DeleteTag(ctx context.Context, id int) error {
+	if _, err := u.getTag(ctx, id); err != nil {
+		return err
+	}
+
+	return u.repo.GetTagRepo().Delete(ctx, id)
+}
+
+func (u *tagUseCase) getTag(ctx context.Context, id int) (*domain.Tag, error) {
+	tag, err := u.repo.GetTagRepo().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if tag == nil {
+		return nil, errors.New("tag not found")
+	}
+
+	return tag, nil
+}
+
+func (u *tagUseCase) checkNameAvailable(ctx context.Context, name string, id int) error {
+	existing, err := u.repo.GetTagRepo().GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return errors.New("tag already exist")
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateTag(t *testing.T) {
+	Convey("Test create tag", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		tagRepo := repositoryMock.NewMockTagRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetTagRepo().Return(tagRepo).AnyTimes()
+
+		tagUseCase := NewTagUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			req = &domain.CreateTagRequest{Name: "  Science  Fiction "}
+		)
+
+		Convey("resp err validator", func() {
+			err := tagUseCase.CreateTag(ctx, &domain.CreateTagRequest{Name: " "})
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when tag already exist", func() {
+			tagRepo.EXPECT().GetByName(gomock.Any(), "science fiction").Return(&domain.Tag{ID: 1, Name: "science fiction"}, nil)
+			err := tagUseCase.CreateTag(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success creates the normalized name", func() {
+			tagRepo.EXPECT().GetByName(gomock.Any(), "science fiction").Return(nil, nil)
+			tagRepo.EXPECT().Create(gomock.Any(), &domain.Tag{Name: "science fiction"}).Return(nil)
+			err := tagUseCase.CreateTag(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestUpdateTag(t *testing.T) {
+	Convey("Test update tag", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		tagRepo := repositoryMock.NewMockTagRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetTagRepo().Return(tagRepo).AnyTimes()
+
+		tagUseCase := NewTagUseCase(config, repoMock)
+
+		var (
+			ctx = context.Background()
+			req = &domain.UpdateTagRequest{ID: 1, Name: "Fantasy"}
+			tag = &domain.Tag{ID: 1, Name: "fiksi"}
+		)
+
+		Convey("resp err when tag doesnt exist", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := tagUseCase.UpdateTag(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when renamed to the name of another tag", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(tag, nil)
+			tagRepo.EXPECT().GetByName(gomock.Any(), "fantasy").Return(&domain.Tag{ID: 2, Name: "fantasy"}, nil)
+			err := tagUseCase.UpdateTag(ctx, req)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success renames tag", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(tag, nil)
+			tagRepo.EXPECT().GetByName(gomock.Any(), "fantasy").Return(nil, nil)
+			tagRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, req *domain.Tag) error {
+				So(req.Name, ShouldEqual, "fantasy")
+				return nil
+			})
+			err := tagUseCase.UpdateTag(ctx, req)
+			So(err, ShouldBeNil)
+		})
+	})
+}
+
+func TestDeleteTag(t *testing.T) {
+	Convey("Test delete tag", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		tagRepo := repositoryMock.NewMockTagRepositoryImpl(ctrl)
+
+		repoMock.EXPECT().GetTagRepo().Return(tagRepo).AnyTimes()
+
+		tagUseCase := NewTagUseCase(config, repoMock)
+
+		var (
+			ctx     = context.Background()
+			errResp = errors.New("error")
+		)
+
+		Convey("resp err when get tag by id", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, errResp)
+			err := tagUseCase.DeleteTag(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp err when tag doesnt exist", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(nil, nil)
+			err := tagUseCase.DeleteTag(ctx, 1)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("resp success", func() {
+			tagRepo.EXPECT().GetByID(gomock.Any(), 1).Return(&domain.Tag{ID: 1, Name: "fiksi"}, nil)
+			tagRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+			err := tagUseCase.DeleteTag(ctx, 1)
+			So(err, ShouldBeNil)
+		})
+	})
+}
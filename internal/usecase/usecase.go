@@ -3,19 +3,61 @@ package usecase
 import (
 	"github.com/imanudd/inventorySvc-clean-architecture/config"
 	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/notifier"
 )
 
 type Usecase struct {
 	AuthUseCase   AuthUseCaseImpl
 	BookUseCase   BookUseCaseImpl
 	AuthorUseCase AuthorUseCaseImpl
+	LabelUseCase  LabelUseCaseImpl
+
+	PublisherUseCase PublisherUseCaseImpl
+	CategoryUseCase  CategoryUseCaseImpl
+	TagUseCase       TagUseCaseImpl
+	PriceUseCase     PriceUseCaseImpl
+	PromotionUseCase PromotionUseCaseImpl
+
+	SupplierUseCase      SupplierUseCaseImpl
+	PurchaseOrderUseCase PurchaseOrderUseCaseImpl
+	StockUseCase         StockUseCaseImpl
+
+	OrderUseCase OrderUseCaseImpl
+	RMAUseCase   RMAUseCaseImpl
+
+	CycleCountUseCase CycleCountUseCaseImpl
+	StockAlertUseCase StockAlertUseCaseImpl
+	ValuationUseCase  ValuationUseCaseImpl
+	AnalyticsUseCase  AnalyticsUseCaseImpl
+	ForecastUseCase   ForecastUseCaseImpl
 }
 
-func NewUsecase(cfg *config.MainConfig, repository repository.RepositoryImpl) Usecase {
+func NewUsecase(cfg *config.MainConfig, repository repository.RepositoryImpl, rates exchangerate.Provider, notifier notifier.Notifier) Usecase {
 	return Usecase{
 		AuthUseCase:   NewAuthUseCase(cfg, repository),
-		BookUseCase:   NewBookUseCase(cfg, repository),
-		AuthorUseCase: NewAuthorUseCase(cfg, repository),
+		BookUseCase:   NewBookUseCase(cfg, repository, rates),
+		AuthorUseCase: NewAuthorUseCase(cfg, repository, rates),
+		LabelUseCase:  NewLabelUseCase(cfg, repository),
+
+		PublisherUseCase: NewPublisherUseCase(cfg, repository),
+		CategoryUseCase:  NewCategoryUseCase(cfg, repository),
+		TagUseCase:       NewTagUseCase(cfg, repository),
+		PriceUseCase:     NewPriceUseCase(cfg, repository),
+		PromotionUseCase: NewPromotionUseCase(cfg, repository, rates),
+
+		SupplierUseCase:      NewSupplierUseCase(cfg, repository),
+		PurchaseOrderUseCase: NewPurchaseOrderUseCase(cfg, repository),
+		StockUseCase:         NewStockUseCase(cfg, repository),
+
+		OrderUseCase: NewOrderUseCase(cfg, repository, rates),
+		RMAUseCase:   NewRMAUseCase(cfg, repository),
+
+		CycleCountUseCase: NewCycleCountUseCase(cfg, repository),
+		StockAlertUseCase: NewStockAlertUseCase(cfg, repository, notifier),
+		ValuationUseCase:  NewValuationUseCase(cfg, repository, rates),
+		AnalyticsUseCase:  NewAnalyticsUseCase(cfg, repository, rates),
+		ForecastUseCase:   NewForecastUseCase(cfg, repository),
 	}
 }
 
@@ -30,3 +72,67 @@ func (u *Usecase) GetBookUseCase() BookUseCaseImpl {
 func (u *Usecase) GetAuthorUseCase() AuthorUseCaseImpl {
 	return u.AuthorUseCase
 }
+
+func (u *Usecase) GetLabelUseCase() LabelUseCaseImpl {
+	return u.LabelUseCase
+}
+
+func (u *Usecase) GetPublisherUseCase() PublisherUseCaseImpl {
+	return u.PublisherUseCase
+}
+
+func (u *Usecase) GetCategoryUseCase() CategoryUseCaseImpl {
+	return u.CategoryUseCase
+}
+
+func (u *Usecase) GetTagUseCase() TagUseCaseImpl {
+	return u.TagUseCase
+}
+
+func (u *Usecase) GetPriceUseCase() PriceUseCaseImpl {
+	return u.PriceUseCase
+}
+
+func (u *Usecase) GetPromotionUseCase() PromotionUseCaseImpl {
+	return u.PromotionUseCase
+}
+
+func (u *Usecase) GetSupplierUseCase() SupplierUseCaseImpl {
+	return u.SupplierUseCase
+}
+
+func (u *Usecase) GetPurchaseOrderUseCase() PurchaseOrderUseCaseImpl {
+	return u.PurchaseOrderUseCase
+}
+
+func (u *Usecase) GetStockUseCase() StockUseCaseImpl {
+	return u.StockUseCase
+}
+
+func (u *Usecase) GetOrderUseCase() OrderUseCaseImpl {
+	return u.OrderUseCase
+}
+
+func (u *Usecase) GetRMAUseCase() RMAUseCaseImpl {
+	return u.RMAUseCase
+}
+
+func (u *Usecase) GetCycleCountUseCase() CycleCountUseCaseImpl {
+	return u.CycleCountUseCase
+}
+
+func (u *Usecase) GetStockAlertUseCase() StockAlertUseCaseImpl {
+	return u.StockAlertUseCase
+}
+
+func (u *Usecase) GetValuationUseCase() ValuationUseCaseImpl {
+	return u.ValuationUseCase
+}
+
+func (u *Usecase) GetAnalyticsUseCase() AnalyticsUseCaseImpl {
+	return u.AnalyticsUseCase
+}
+
+func (u *Usecase) GetForecastUseCase() ForecastUseCaseImpl {
+	return u.ForecastUseCase
+}
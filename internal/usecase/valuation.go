@@ -0,0 +1,396 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/costing"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/exchangerate"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/validator"
+)
+
+type ValuationUseCaseImpl interface {
+	GetValuation(ctx context.Context, req *domain.ValuationRequest) (*domain.ValuationReport, error)
+	GetCOGS(ctx context.Context, req *domain.ReportPeriodRequest) (*domain.COGSReport, error)
+	GetMargin(ctx context.Context, req *domain.ReportPeriodRequest) (*domain.MarginReport, error)
+}
+
+type valuationUseCase struct {
+	config *config.MainConfig
+	repo   repository.RepositoryImpl
+	rates  exchangerate.Provider
+}
+
+func NewValuationUseCase(config *config.MainConfig, repo repository.RepositoryImpl, rates exchangerate.Provider) ValuationUseCaseImpl {
+	return &valuationUseCase{
+		config: config,
+		repo:   repo,
+		rates:  rates,
+	}
+}
+
+// ledger is the stock ledger replayed into cost layers, with the cost of the sales made from a given time on.
+type ledger struct {
+	inventories map[int]costing.Inventory
+	sold        map[int]int64
+	cogs        map[int]int64
+}
+
+func (u *valuationUseCase) GetValuation(ctx context.Context, req *domain.ValuationRequest) (*domain.ValuationReport, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	asOf := req.AsOf
+	if asOf.IsZero() {
+		y, m, d := time.Now().Date()
+		asOf = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	}
+
+	report := &domain.ValuationReport{
+		AsOf:     asOf,
+		Method:   u.method(req.Method),
+		Currency: u.currency(req.Currency),
+		Lines:    []*domain.ValuationLine{},
+	}
+
+	l, err := u.replay(ctx, report.Method, report.Currency, asOf.AddDate(0, 0, 1), time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := u.titles(ctx, sortedKeys(l.inventories))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bookID := range sortedKeys(l.inventories) {
+		inv := l.inventories[bookID]
+		if inv.Quantity() == 0 {
+			continue
+		}
+
+		line := &domain.ValuationLine{
+			BookID:   bookID,
+			Title:    titles[bookID],
+			Quantity: inv.Quantity(),
+			UnitCost: money.New(inv.UnitCost(), report.Currency),
+			Value:    money.New(inv.Value(), report.Currency),
+			Layers:   []*domain.CostLayer{},
+		}
+
+		for _, layer := range inv.Layers() {
+			line.Layers = append(line.Layers, &domain.CostLayer{
+				Quantity:   layer.Quantity,
+				UnitCost:   money.New(layer.UnitCost, report.Currency),
+				ReceivedAt: layer.ReceivedAt,
+			})
+		}
+
+		report.Lines = append(report.Lines, line)
+		report.TotalQuantity += line.Quantity
+		report.TotalValue.Amount += line.Value.Amount
+	}
+
+	report.TotalValue.Currency = report.Currency
+
+	return report, nil
+}
+
+func (u *valuationUseCase) GetCOGS(ctx context.Context, req *domain.ReportPeriodRequest) (*domain.COGSReport, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	report := &domain.COGSReport{
+		From:     req.From,
+		To:       req.To,
+		Method:   u.method(req.Method),
+		Currency: u.currency(req.Currency),
+		Total:    money.New(0, u.currency(req.Currency)),
+		Lines:    []*domain.COGSLine{},
+	}
+
+	l, err := u.replay(ctx, report.Method, report.Currency, req.End(), req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	titles, err := u.titles(ctx, sortedKeys(l.cogs))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bookID := range sortedKeys(l.cogs) {
+		report.Lines = append(report.Lines, &domain.COGSLine{
+			BookID:   bookID,
+			Title:    titles[bookID],
+			Quantity: l.sold[bookID],
+			Cost:     money.New(l.cogs[bookID], report.Currency),
+		})
+		report.Total.Amount += l.cogs[bookID]
+	}
+
+	return report, nil
+}
+
+// GetMargin sets the net sales of the period against their cost of goods sold, per book or per
+// the primary author of the books.
+func (u *valuationUseCase) GetMargin(ctx context.Context, req *domain.ReportPeriodRequest) (*domain.MarginReport, error) {
+	if err := validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	report := &domain.MarginReport{
+		From:     req.From,
+		To:       req.To,
+		Method:   u.method(req.Method),
+		Currency: u.currency(req.Currency),
+		GroupBy:  req.GroupBy,
+		Lines:    []*domain.MarginLine{},
+	}
+
+	if report.GroupBy == "" {
+		report.GroupBy = domain.ReportGroupByBook
+	}
+
+	l, err := u.replay(ctx, report.Method, report.Currency, req.End(), req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := u.repo.GetValuationRepo().ListRevenue(ctx, req.From, req.End())
+	if err != nil {
+		return nil, err
+	}
+
+	convert := newConverter(ctx, u.rates, report.Currency)
+
+	revenue := make(map[int]int64)
+	for _, row := range rows {
+		amount, err := convert(row.Revenue)
+		if err != nil {
+			return nil, err
+		}
+
+		revenue[row.BookID] += amount.Amount
+	}
+
+	bookIDs := make(map[int]bool)
+	for bookID := range revenue {
+		bookIDs[bookID] = true
+	}
+	for bookID := range l.cogs {
+		bookIDs[bookID] = true
+	}
+
+	books, err := u.books(ctx, sortedKeys(bookIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make(map[int]*domain.MarginLine)
+	for _, bookID := range sortedKeys(bookIDs) {
+		id, name := bookID, ""
+		if book, ok := books[bookID]; ok {
+			name = book.Title
+			if report.GroupBy == domain.ReportGroupByAuthor {
+				id = book.PrimaryAuthorID()
+			}
+		}
+
+		line, ok := lines[id]
+		if !ok {
+			line = &domain.MarginLine{ID: id, Name: name}
+			lines[id] = line
+		}
+
+		line.Quantity += l.sold[bookID]
+		line.Revenue.Amount += revenue[bookID]
+		line.Cost.Amount += l.cogs[bookID]
+	}
+
+	if report.GroupBy == domain.ReportGroupByAuthor {
+		if err := u.authorNames(ctx, lines); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, id := range sortedKeys(lines) {
+		line := lines[id]
+		line.Revenue.Currency, line.Cost.Currency = report.Currency, report.Currency
+		line.Margin = money.New(line.Revenue.Amount-line.Cost.Amount, report.Currency)
+		line.MarginPercent = marginPercent(line.Margin.Amount, line.Revenue.Amount)
+
+		report.Lines = append(report.Lines, line)
+		report.Revenue.Amount += line.Revenue.Amount
+		report.Cost.Amount += line.Cost.Amount
+	}
+
+	report.Revenue.Currency, report.Cost.Currency = report.Currency, report.Currency
+	report.Margin = money.New(report.Revenue.Amount-report.Cost.Amount, report.Currency)
+
+	return report, nil
+}
+
+// replay runs the ledger before until through the costing method. Receipts enter at their cost in
+// currency, converted at today's rate, and goods coming back without a cost, returns and positive
+// count adjustments, re-enter at the cost they would have left at. Shipments and returns from
+// from on make up the cost of goods sold, write-offs and supplier returns do not.
+func (u *valuationUseCase) replay(ctx context.Context, method, currency string, until, from time.Time) (*ledger, error) {
+	movements, err := u.repo.GetValuationRepo().ListMovements(ctx, until)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &ledger{
+		inventories: make(map[int]costing.Inventory),
+		sold:        make(map[int]int64),
+		cogs:        make(map[int]int64),
+	}
+
+	convert := newConverter(ctx, u.rates, currency)
+
+	for _, movement := range movements {
+		inv, ok := l.inventories[movement.BookID]
+		if !ok {
+			inv, err = costing.New(method)
+			if err != nil {
+				return nil, err
+			}
+			l.inventories[movement.BookID] = inv
+		}
+
+		quantity := int64(movement.Quantity)
+
+		var cost int64
+		if quantity > 0 {
+			cost = inv.UnitCost()
+			if movement.UnitCost.Currency != "" {
+				unitCost, err := convert(movement.UnitCost)
+				if err != nil {
+					return nil, err
+				}
+				cost = unitCost.Amount
+			}
+
+			inv.Receive(quantity, cost, movement.CreatedAt)
+			cost *= quantity
+		} else {
+			cost = -inv.Issue(-quantity)
+		}
+
+		if movement.CreatedAt.Before(from) {
+			continue
+		}
+
+		switch movement.Type {
+		case domain.StockMovementSaleShipment, domain.StockMovementSaleReturn:
+			l.sold[movement.BookID] -= quantity
+			l.cogs[movement.BookID] -= cost
+		}
+	}
+
+	return l, nil
+}
+
+func (u *valuationUseCase) method(method string) string {
+	if method != "" {
+		return method
+	}
+
+	if u.config.ValuationMethod != "" {
+		return u.config.ValuationMethod
+	}
+
+	return costing.FIFO
+}
+
+func (u *valuationUseCase) currency(currency string) string {
+	if currency != "" {
+		return currency
+	}
+
+	return u.config.DefaultCurrency
+}
+
+func (u *valuationUseCase) books(ctx context.Context, ids []int) (map[int]*domain.Book, error) {
+	books := make(map[int]*domain.Book, len(ids))
+	if len(ids) == 0 {
+		return books, nil
+	}
+
+	list, err := u.repo.GetBookRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, book := range list {
+		books[book.ID] = book
+	}
+
+	return books, nil
+}
+
+func (u *valuationUseCase) titles(ctx context.Context, ids []int) (map[int]string, error) {
+	books, err := u.books(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	titles := make(map[int]string, len(books))
+	for id, book := range books {
+		titles[id] = book.Title
+	}
+
+	return titles, nil
+}
+
+func (u *valuationUseCase) authorNames(ctx context.Context, lines map[int]*domain.MarginLine) error {
+	ids := sortedKeys(lines)
+	if len(ids) == 0 {
+		return nil
+	}
+
+	authors, err := u.repo.GetAuthorRepo().GetByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		line.Name = ""
+	}
+
+	for _, author := range authors {
+		if line, ok := lines[author.ID]; ok {
+			line.Name = author.Name
+		}
+	}
+
+	return nil
+}
+
+func marginPercent(margin, revenue int64) *float64 {
+	if revenue == 0 {
+		return nil
+	}
+
+	percent := math.Round(float64(margin)/float64(revenue)*10000) / 100
+	return &percent
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Ints(keys)
+	return keys
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/imanudd/inventorySvc-clean-architecture/config"
+	"github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	pkgMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/pkg"
+	repositoryMock "github.com/imanudd/inventorySvc-clean-architecture/shared/mock/repository"
+	. "github.com/smartystreets/goconvey/convey"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValuationReports(t *testing.T) {
+	Convey("Test valuation reports", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		config := &config.MainConfig{DefaultCurrency: "IDR", ValuationMethod: "fifo"}
+		repoMock := repositoryMock.NewMockRepositoryImpl(ctrl)
+		bookRepo := repositoryMock.NewMockBookRepositoryImpl(ctrl)
+		authorRepo := repositoryMock.NewMockAuthorRepositoryImpl(ctrl)
+		valuationRepo := repositoryMock.NewMockValuationRepositoryImpl(ctrl)
+		rates := pkgMock.NewMockProvider(ctrl)
+
+		repoMock.EXPECT().GetBookRepo().Return(bookRepo).AnyTimes()
+		repoMock.EXPECT().GetAuthorRepo().Return(authorRepo).AnyTimes()
+		repoMock.EXPECT().GetValuationRepo().Return(valuationRepo).AnyTimes()
+
+		valuationUseCase := NewValuationUseCase(config, repoMock, rates)
+
+		var (
+			ctx    = context.Background()
+			day    = time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+			at     = func(days int) time.Time { return day.AddDate(0, 0, days).Add(10 * time.Hour) }
+			ledger = []*domain.StockMovement{
+				{BookID: 10, Type: domain.StockMovementPurchaseReceipt, Quantity: 10, UnitCost: money.New(10000, "IDR"), CreatedAt: at(0)},
+				{BookID: 11, Type: domain.StockMovementPurchaseReceipt, Quantity: 5, UnitCost: money.New(200, "USD"), CreatedAt: at(0)},
+				{BookID: 10, Type: domain.StockMovementPurchaseReceipt, Quantity: 10, UnitCost: money.New(13000, "IDR"), CreatedAt: at(1)},
+				{BookID: 10, Type: domain.StockMovementSaleShipment, Quantity: -12, CreatedAt: at(2)},
+				{BookID: 10, Type: domain.StockMovementSaleReturn, Quantity: 2, CreatedAt: at(3)},
+				{BookID: 11, Type: domain.StockMovementWriteOff, Quantity: -1, CreatedAt: at(3)},
+			}
+			books = []*domain.Book{
+				{ID: 10, Title: "Dune", Contributors: []*domain.BookContributor{{BookID: 10, AuthorID: 1, Role: domain.ContributorRoleAuthor}}},
+				{ID: 11, Title: "Children of Dune", Contributors: []*domain.BookContributor{{BookID: 11, AuthorID: 1, Role: domain.ContributorRoleAuthor}}},
+			}
+		)
+
+		// 1 USD = 16000 IDR
+		rates.EXPECT().Rate(gomock.Any(), "USD", "IDR").Return(big.NewRat(16000, 1), nil).AnyTimes()
+
+		Convey("valuation keeps the newest fifo layers", func() {
+			valuationRepo.EXPECT().ListMovements(gomock.Any(), day.AddDate(0, 0, 4)).Return(ledger, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10, 11}).Return(books, nil)
+
+			report, err := valuationUseCase.GetValuation(ctx, &domain.ValuationRequest{AsOf: day.AddDate(0, 0, 3)})
+			So(err, ShouldBeNil)
+			So(report.Method, ShouldEqual, "fifo")
+			So(report.Lines, ShouldHaveLength, 2)
+
+			// 8 left of the second receipt and 2 returned at the cost they left at
+			So(report.Lines[0].Quantity, ShouldEqual, 10)
+			So(report.Lines[0].Value, ShouldResemble, money.New(8*13000+2*13000, "IDR"))
+			So(report.Lines[0].Layers, ShouldHaveLength, 2)
+
+			So(report.Lines[1].Title, ShouldEqual, "Children of Dune")
+			So(report.Lines[1].Value, ShouldResemble, money.New(4*3200000, "IDR"))
+			So(report.TotalValue, ShouldResemble, money.New(130000+12800000, "IDR"))
+		})
+
+		Convey("weighted average cogs nets returns and leaves write-offs out", func() {
+			valuationRepo.EXPECT().ListMovements(gomock.Any(), day.AddDate(0, 0, 4)).Return(ledger, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10}).Return(books[:1], nil)
+
+			report, err := valuationUseCase.GetCOGS(ctx, &domain.ReportPeriodRequest{
+				From:   day.AddDate(0, 0, 2),
+				To:     day.AddDate(0, 0, 3),
+				Method: "weighted_average",
+			})
+			So(err, ShouldBeNil)
+			So(report.Lines, ShouldHaveLength, 1)
+			So(report.Lines[0].Quantity, ShouldEqual, 10)
+			So(report.Lines[0].Cost, ShouldResemble, money.New(12*11500-2*11500, "IDR"))
+			So(report.Total, ShouldResemble, money.New(115000, "IDR"))
+		})
+
+		Convey("margin groups books by their primary author", func() {
+			valuationRepo.EXPECT().ListMovements(gomock.Any(), day.AddDate(0, 0, 4)).Return(ledger, nil)
+			valuationRepo.EXPECT().ListRevenue(gomock.Any(), day, day.AddDate(0, 0, 4)).Return([]*domain.SaleRevenue{
+				{BookID: 10, Revenue: money.New(200000, "IDR")},
+				{BookID: 10, Revenue: money.New(100, "USD")},
+			}, nil)
+			bookRepo.EXPECT().GetByIDs(gomock.Any(), []int{10}).Return(books[:1], nil)
+			authorRepo.EXPECT().GetByIDs(gomock.Any(), []int{1}).Return([]*domain.Author{{ID: 1, Name: "Frank Herbert"}}, nil)
+
+			report, err := valuationUseCase.GetMargin(ctx, &domain.ReportPeriodRequest{
+				From:    day,
+				To:      day.AddDate(0, 0, 3),
+				GroupBy: domain.ReportGroupByAuthor,
+			})
+			So(err, ShouldBeNil)
+			So(report.Lines, ShouldHaveLength, 1)
+
+			line := report.Lines[0]
+			So(line.ID, ShouldEqual, 1)
+			So(line.Name, ShouldEqual, "Frank Herbert")
+			So(line.Revenue, ShouldResemble, money.New(1800000, "IDR"))
+			// fifo: 10 at 10000 and 2 at 13000 shipped, 2 back at 13000
+			So(line.Cost, ShouldResemble, money.New(100000, "IDR"))
+			So(line.Margin, ShouldResemble, money.New(1700000, "IDR"))
+			So(*line.MarginPercent, ShouldEqual, 94.44)
+
+			So(report.Records()[1], ShouldResemble, []string{"1", "Frank Herbert", "10", "18000.00", "1000.00", "17000.00", "94.44", "IDR"})
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package barcode
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+)
+
+const (
+	SymbologyCode128 = "code128"
+	SymbologyEAN13   = "ean13"
+	SymbologyQR      = "qr"
+
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+var ErrUnknownSymbology = errors.New("unknown barcode symbology")
+
+// Encode builds the barcode for content and scales it to width x height pixels.
+// QR codes are always square, so only width is used for them.
+func Encode(symbology, content string, width, height int) (barcode.Barcode, error) {
+	var (
+		code barcode.Barcode
+		err  error
+	)
+
+	switch symbology {
+	case SymbologyCode128:
+		code, err = code128.Encode(content)
+	case SymbologyEAN13:
+		code, err = ean.Encode(content)
+	case SymbologyQR:
+		code, err = qr.Encode(content, qr.M, qr.Auto)
+		height = width
+	default:
+		return nil, ErrUnknownSymbology
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return barcode.Scale(code, width, height)
+}
+
+func PNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SVG draws one rect per run of dark modules, merging identical rows so linear barcodes stay small.
+func SVG(code barcode.Barcode) []byte {
+	bounds := code.Bounds()
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		bounds.Dx(), bounds.Dy(), bounds.Dx(), bounds.Dy())
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, bounds.Dx(), bounds.Dy())
+
+	var (
+		prev   [][2]int
+		top    int
+		height int
+	)
+
+	flush := func() {
+		for _, run := range prev {
+			fmt.Fprintf(&buf, `<rect x="%d" y="%d" width="%d" height="%d"/>`, run[0], top, run[1], height)
+		}
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		runs := darkRuns(code, y)
+		if y > bounds.Min.Y && sameRuns(runs, prev) {
+			height++
+			continue
+		}
+
+		flush()
+		prev, top, height = runs, y-bounds.Min.Y, 1
+	}
+	flush()
+
+	buf.WriteString(`</svg>`)
+
+	return buf.Bytes()
+}
+
+// darkRuns returns the x offset and width of every run of dark pixels in row y.
+func darkRuns(code barcode.Barcode, y int) [][2]int {
+	bounds := code.Bounds()
+
+	var runs [][2]int
+	start := -1
+	for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+		dark := x < bounds.Max.X && isDark(code, x, y)
+		switch {
+		case dark && start < 0:
+			start = x
+		case !dark && start >= 0:
+			runs = append(runs, [2]int{start - bounds.Min.X, x - start})
+			start = -1
+		}
+	}
+
+	return runs
+}
+
+func sameRuns(a, b [][2]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDark(code barcode.Barcode, x, y int) bool {
+	r, g, b, _ := code.At(x, y).RGBA()
+	return r+g+b < 3*0x8000
+}
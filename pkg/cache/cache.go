@@ -0,0 +1,206 @@
+// Package cache is a cache-aside cache of gob encoded values, an in-process LRU in front of an
+// optional remote cache the instances of the service share.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Remote is a cache shared between instances, e.g. redis. Errors of a remote cache are logged, the
+// value is loaded as on a miss.
+type Remote interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Stats counts the lookups of one kind of value.
+type Stats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// kindState counts the lookups of one kind of value and tells its loads whether one was deleted.
+type kindState struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+
+	// generation changes with every delete of the kind, a load that started before one is not stored
+	generation atomic.Uint64
+}
+
+type Cache struct {
+	namespace string
+	local     *LRU
+	remote    Remote
+	ttl       time.Duration
+	group     singleflight.Group
+
+	mu    sync.Mutex
+	kinds map[string]*kindState
+}
+
+// New keeps entries for ttl under keys prefixed with namespace, remote may be nil.
+func New(namespace string, local *LRU, remote Remote, ttl time.Duration) *Cache {
+	return &Cache{
+		namespace: namespace,
+		local:     local,
+		remote:    remote,
+		ttl:       ttl,
+		kinds:     make(map[string]*kindState),
+	}
+}
+
+// Load returns the value of kind and id from the cache, or from load on a miss. Concurrent misses of
+// a key share one load, it is not cancelled with the context of the caller that started it. A nil
+// value is not cached. Every caller gets a copy of its own.
+func Load[T any](ctx context.Context, c *Cache, kind, id string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key := c.key(kind, id)
+	state := c.kind(kind)
+
+	if encoded, ok := c.get(ctx, key); ok {
+		if value, err := decode[T](encoded); err == nil {
+			state.hits.Add(1)
+			return value, nil
+		}
+	}
+
+	state.misses.Add(1)
+
+	encoded, err, _ := c.group.Do(key, func() (interface{}, error) {
+		generation := state.generation.Load()
+
+		value, err := load(context.WithoutCancel(ctx))
+		if err != nil || value == nil {
+			return nil, err
+		}
+
+		encoded, err := encode(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if state.generation.Load() == generation {
+			c.set(ctx, key, encoded)
+		}
+
+		return encoded, nil
+	})
+	if err != nil || encoded == nil {
+		return nil, err
+	}
+
+	return decode[T](encoded.([]byte))
+}
+
+// Delete drops the values of kind and ids, a load of the kind in flight is not stored.
+func (c *Cache) Delete(ctx context.Context, kind string, ids ...string) {
+	c.kind(kind).generation.Add(1)
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		key := c.key(kind, id)
+		keys = append(keys, key)
+		c.group.Forget(key)
+	}
+
+	c.local.Delete(keys...)
+
+	if c.remote != nil {
+		if err := c.remote.Delete(ctx, keys...); err != nil {
+			logger().WarnContext(ctx, "failed to delete from the remote cache", "keys", keys, "error", err)
+		}
+	}
+}
+
+// Stats returns the hits and misses of every kind of value.
+func (c *Cache) Stats() map[string]Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := make(map[string]Stats, len(c.kinds))
+	for kind, state := range c.kinds {
+		stats[kind] = Stats{Hits: state.hits.Load(), Misses: state.misses.Load()}
+	}
+
+	return stats
+}
+
+func (c *Cache) key(kind, id string) string {
+	return c.namespace + ":" + kind + ":" + id
+}
+
+func (c *Cache) kind(kind string) *kindState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.kinds[kind]; !ok {
+		c.kinds[kind] = &kindState{}
+	}
+
+	return c.kinds[kind]
+}
+
+func (c *Cache) get(ctx context.Context, key string) ([]byte, bool) {
+	if encoded, ok := c.local.Get(key); ok {
+		return encoded, true
+	}
+
+	if c.remote == nil {
+		return nil, false
+	}
+
+	encoded, ok, err := c.remote.Get(ctx, key)
+	if err != nil {
+		logger().WarnContext(ctx, "failed to get from the remote cache", "key", key, "error", err)
+		return nil, false
+	}
+
+	if ok {
+		c.local.Set(key, encoded, c.ttl)
+	}
+
+	return encoded, ok
+}
+
+func (c *Cache) set(ctx context.Context, key string, encoded []byte) {
+	c.local.Set(key, encoded, c.ttl)
+
+	if c.remote != nil {
+		if err := c.remote.Set(ctx, key, encoded, c.ttl); err != nil {
+			logger().WarnContext(ctx, "failed to set in the remote cache", "key", key, "error", err)
+		}
+	}
+}
+
+// logger is the default logger as the cache component, a failing remote cache degrades to the
+// local one and is only logged.
+func logger() *slog.Logger {
+	return slog.With("component", "cache")
+}
+
+func encode(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decode[T any](encoded []byte) (*T, error) {
+	var value T
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type item struct {
+	ID   int
+	Name string
+}
+
+// remote is a Remote kept in a map.
+type remote struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (r *remote) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	value, ok := r.values[key]
+	return value, ok, nil
+}
+
+func (r *remote) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.values[key] = value
+	return nil
+}
+
+func (r *remote) Delete(ctx context.Context, keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		delete(r.values, key)
+	}
+
+	return nil
+}
+
+func TestLRU(t *testing.T) {
+	Convey("Test lru", t, func() {
+		lru := NewLRU(2)
+
+		Convey("evicts the least recently used entry", func() {
+			lru.Set("a", []byte("1"), time.Minute)
+			lru.Set("b", []byte("2"), time.Minute)
+
+			_, ok := lru.Get("a")
+			So(ok, ShouldBeTrue)
+
+			lru.Set("c", []byte("3"), time.Minute)
+			So(lru.Len(), ShouldEqual, 2)
+
+			_, ok = lru.Get("b")
+			So(ok, ShouldBeFalse)
+
+			value, ok := lru.Get("a")
+			So(ok, ShouldBeTrue)
+			So(string(value), ShouldEqual, "1")
+		})
+
+		Convey("expires entries", func() {
+			lru.Set("a", []byte("1"), -time.Second)
+
+			_, ok := lru.Get("a")
+			So(ok, ShouldBeFalse)
+			So(lru.Len(), ShouldEqual, 0)
+		})
+	})
+}
+
+func TestLoad(t *testing.T) {
+	Convey("Test load", t, func() {
+		ctx := context.Background()
+		shared := &remote{values: make(map[string][]byte)}
+		c := New("test", NewLRU(10), shared, time.Minute)
+
+		var loads atomic.Int32
+		load := func(ctx context.Context) (*item, error) {
+			loads.Add(1)
+			return &item{ID: 1, Name: "dune"}, nil
+		}
+
+		Convey("loads a miss once and counts hits and misses", func() {
+			value, err := Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+			So(value, ShouldResemble, &item{ID: 1, Name: "dune"})
+
+			again, err := Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+			So(again, ShouldResemble, value)
+			So(again, ShouldNotPointTo, value)
+
+			So(loads.Load(), ShouldEqual, 1)
+			So(c.Stats()["item"], ShouldResemble, Stats{Hits: 1, Misses: 1})
+			So(shared.values, ShouldContainKey, "test:item:1")
+		})
+
+		Convey("reads what another instance stored remotely", func() {
+			_, err := Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+
+			other := New("test", NewLRU(10), shared, time.Minute)
+			value, err := Load(ctx, other, "item", "1", load)
+			So(err, ShouldBeNil)
+			So(value.Name, ShouldEqual, "dune")
+			So(loads.Load(), ShouldEqual, 1)
+		})
+
+		Convey("shares one load between concurrent misses", func() {
+			release := make(chan struct{})
+			slow := func(ctx context.Context) (*item, error) {
+				<-release
+				return load(ctx)
+			}
+
+			var wg sync.WaitGroup
+			values := make([]*item, 10)
+			for i := range values {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					values[i], _ = Load(ctx, c, "item", "1", slow)
+				}()
+			}
+
+			for c.Stats()["item"].Misses < 10 {
+				time.Sleep(time.Millisecond)
+			}
+			close(release)
+			wg.Wait()
+
+			So(loads.Load(), ShouldEqual, 1)
+			for _, value := range values {
+				So(value.Name, ShouldEqual, "dune")
+			}
+		})
+
+		Convey("does not cache nil values and errors", func() {
+			value, err := Load(ctx, c, "item", "2", func(ctx context.Context) (*item, error) { return nil, nil })
+			So(err, ShouldBeNil)
+			So(value, ShouldBeNil)
+
+			_, err = Load(ctx, c, "item", "2", func(ctx context.Context) (*item, error) { return nil, errors.New("error") })
+			So(err, ShouldNotBeNil)
+
+			_, err = Load(ctx, c, "item", "2", load)
+			So(err, ShouldBeNil)
+			So(loads.Load(), ShouldEqual, 1)
+		})
+
+		Convey("drops deleted entries and loads in flight", func() {
+			_, err := Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+
+			c.Delete(ctx, "item", "1")
+			So(shared.values, ShouldBeEmpty)
+
+			_, err = Load(ctx, c, "item", "1", func(ctx context.Context) (*item, error) {
+				c.Delete(ctx, "item", "1")
+				return load(ctx)
+			})
+			So(err, ShouldBeNil)
+
+			_, err = Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+			So(loads.Load(), ShouldEqual, 3)
+		})
+
+		Convey("stores a load while another kind is deleted", func() {
+			_, err := Load(ctx, c, "item", "1", func(ctx context.Context) (*item, error) {
+				c.Delete(ctx, "other", "1")
+				return load(ctx)
+			})
+			So(err, ShouldBeNil)
+
+			_, err = Load(ctx, c, "item", "1", load)
+			So(err, ShouldBeNil)
+			So(loads.Load(), ShouldEqual, 1)
+		})
+	})
+}
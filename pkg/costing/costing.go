@@ -0,0 +1,190 @@
+// Package costing values stock from the receipts and issues of a ledger, first in first out or at the
+// weighted average cost. Costs are integer minor units of a single currency.
+package costing
+
+import (
+	"errors"
+	"math/big"
+	"time"
+)
+
+const (
+	FIFO            = "fifo"
+	WeightedAverage = "weighted_average"
+)
+
+var ErrUnknownMethod = errors.New("unknown costing method")
+
+// Layer is a quantity received together at one unit cost, FIFO issues consume the oldest layer first.
+type Layer struct {
+	Quantity   int64     `json:"quantity"`
+	UnitCost   int64     `json:"unit_cost"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+// Inventory holds the cost layers of one item.
+type Inventory interface {
+	// Receive adds quantity at unitCost.
+	Receive(quantity, unitCost int64, at time.Time)
+	// Issue removes quantity and returns its cost. Issuing more than is on hand costs the shortfall
+	// at UnitCost, the quantity on hand does not go below zero.
+	Issue(quantity int64) int64
+	// UnitCost is the cost goods coming back into stock re-enter at, the cost of the last issue or,
+	// before any issue, of the newest receipt.
+	UnitCost() int64
+	Quantity() int64
+	Value() int64
+	Layers() []Layer
+}
+
+func New(method string) (Inventory, error) {
+	switch method {
+	case FIFO:
+		return &fifo{}, nil
+	case WeightedAverage:
+		return &average{}, nil
+	}
+
+	return nil, ErrUnknownMethod
+}
+
+type fifo struct {
+	layers []Layer
+	last   int64
+}
+
+func (f *fifo) Receive(quantity, unitCost int64, at time.Time) {
+	if quantity <= 0 {
+		return
+	}
+
+	f.layers = append(f.layers, Layer{Quantity: quantity, UnitCost: unitCost, ReceivedAt: at})
+	if f.last == 0 {
+		f.last = unitCost
+	}
+}
+
+func (f *fifo) Issue(quantity int64) int64 {
+	var cost int64
+	for quantity > 0 && len(f.layers) > 0 {
+		layer := &f.layers[0]
+
+		take := min(quantity, layer.Quantity)
+		cost += take * layer.UnitCost
+		quantity -= take
+		layer.Quantity -= take
+		f.last = layer.UnitCost
+
+		if layer.Quantity == 0 {
+			f.layers = f.layers[1:]
+		}
+	}
+
+	return cost + quantity*f.last
+}
+
+func (f *fifo) UnitCost() int64 {
+	return f.last
+}
+
+func (f *fifo) Quantity() int64 {
+	var quantity int64
+	for _, layer := range f.layers {
+		quantity += layer.Quantity
+	}
+
+	return quantity
+}
+
+func (f *fifo) Value() int64 {
+	var value int64
+	for _, layer := range f.layers {
+		value += layer.Quantity * layer.UnitCost
+	}
+
+	return value
+}
+
+func (f *fifo) Layers() []Layer {
+	return append([]Layer(nil), f.layers...)
+}
+
+// average keeps the total quantity and value, every issue costs its share of the value so no rounding
+// residue is left behind once the stock is gone.
+type average struct {
+	quantity int64
+	value    int64
+	last     int64
+	at       time.Time
+}
+
+func (a *average) Receive(quantity, unitCost int64, at time.Time) {
+	if quantity <= 0 {
+		return
+	}
+
+	a.quantity += quantity
+	a.value += quantity * unitCost
+	a.last = a.UnitCost()
+	a.at = at
+}
+
+func (a *average) Issue(quantity int64) int64 {
+	if quantity <= 0 {
+		return 0
+	}
+
+	if a.quantity == 0 {
+		return quantity * a.last
+	}
+
+	take := min(quantity, a.quantity)
+	cost := share(a.value, take, a.quantity)
+
+	a.last = a.UnitCost()
+	a.quantity -= take
+	a.value -= cost
+
+	return cost + (quantity-take)*a.last
+}
+
+func (a *average) UnitCost() int64 {
+	if a.quantity == 0 {
+		return a.last
+	}
+
+	return share(a.value, 1, a.quantity)
+}
+
+func (a *average) Quantity() int64 {
+	return a.quantity
+}
+
+func (a *average) Value() int64 {
+	return a.value
+}
+
+func (a *average) Layers() []Layer {
+	if a.quantity == 0 {
+		return nil
+	}
+
+	return []Layer{{Quantity: a.quantity, UnitCost: a.UnitCost(), ReceivedAt: a.at}}
+}
+
+// share returns value * part / whole rounded half away from zero.
+func share(value, part, whole int64) int64 {
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(value), big.NewInt(part)), big.NewInt(whole))
+
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+
+	return q.Int64()
+}
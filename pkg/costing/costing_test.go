@@ -0,0 +1,62 @@
+package costing
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCosting(t *testing.T) {
+	Convey("Test costing", t, func() {
+		now := time.Now()
+
+		Convey("rejects unknown methods", func() {
+			_, err := New("lifo")
+			So(err, ShouldEqual, ErrUnknownMethod)
+		})
+
+		Convey("fifo issues the oldest layers first", func() {
+			inv, err := New(FIFO)
+			So(err, ShouldBeNil)
+
+			inv.Receive(10, 100, now)
+			inv.Receive(10, 130, now.Add(time.Hour))
+
+			So(inv.Issue(12), ShouldEqual, 10*100+2*130)
+			So(inv.Quantity(), ShouldEqual, 8)
+			So(inv.Value(), ShouldEqual, 8*130)
+			So(inv.UnitCost(), ShouldEqual, 130)
+			So(inv.Layers(), ShouldResemble, []Layer{{Quantity: 8, UnitCost: 130, ReceivedAt: now.Add(time.Hour)}})
+
+			Convey("and costs a shortfall at the last unit cost", func() {
+				So(inv.Issue(10), ShouldEqual, 10*130)
+				So(inv.Quantity(), ShouldEqual, 0)
+				So(inv.Layers(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("weighted average spreads the value over the quantity", func() {
+			inv, err := New(WeightedAverage)
+			So(err, ShouldBeNil)
+
+			inv.Receive(10, 100, now)
+			inv.Receive(20, 130, now)
+
+			So(inv.UnitCost(), ShouldEqual, 120)
+			So(inv.Issue(15), ShouldEqual, 1800)
+			So(inv.Quantity(), ShouldEqual, 15)
+			So(inv.Value(), ShouldEqual, 1800)
+
+			Convey("and leaves no rounding residue once sold out", func() {
+				inv.Receive(1, 101, now)
+
+				So(inv.Issue(7), ShouldEqual, 832)
+				So(inv.Issue(9), ShouldEqual, 1069)
+				So(inv.Quantity(), ShouldEqual, 0)
+				So(inv.Value(), ShouldEqual, 0)
+				So(inv.Layers(), ShouldBeEmpty)
+			})
+		})
+	})
+}
@@ -0,0 +1,179 @@
+// Package dialect hides the SQL that differs between the databases the service runs on. Queries
+// build the differing fragments from the Dialect of their connection.
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	Postgres = "postgres"
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+)
+
+// units of DateTrunc
+const (
+	Day   = "day"
+	Week  = "week"
+	Month = "month"
+)
+
+type Dialect interface {
+	// Name is the DB_TYPE of the dialect, the same as the name of its gorm dialector
+	Name() string
+	// ILike is a case insensitive LIKE condition on column with one placeholder for the pattern
+	ILike(column string) string
+	// Date is the calendar date of a timestamp expression, as written without time zone conversion
+	Date(expr string) string
+	// DateTrunc is the date a date expression's day, week starting monday or month starts on
+	DateTrunc(unit, expr string) string
+	// NullsFirst orders by expr ascending with the nulls first
+	NullsFirst(expr string) string
+	// DateArg is t as an argument compared with a DATE column
+	DateArg(t time.Time) interface{}
+	// IgnoreConflicts makes an insert skip the rows that violate onConflict's unique constraint
+	IgnoreConflicts(onConflict clause.OnConflict) clause.Expression
+	// Retryable reports whether err failed a transaction on a serialization failure, a deadlock or a
+	// lock that was not granted in time, running the transaction again may succeed
+	Retryable(err error) bool
+}
+
+var dialects = map[string]Dialect{
+	Postgres: postgres{},
+	MySQL:    mysql{},
+	SQLite:   sqlite{},
+}
+
+// Get returns the dialect called name.
+func Get(name string) (Dialect, error) {
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database type %q", name)
+	}
+
+	return d, nil
+}
+
+// Of returns the dialect of db, Postgres for dialectors it does not know.
+func Of(db *gorm.DB) Dialect {
+	if d, ok := dialects[db.Dialector.Name()]; ok {
+		return d
+	}
+
+	return dialects[Postgres]
+}
+
+type postgres struct{}
+
+func (postgres) Name() string { return Postgres }
+
+func (postgres) ILike(column string) string { return column + " ILIKE ?" }
+
+func (postgres) Date(expr string) string { return "CAST(" + expr + " AS DATE)" }
+
+func (postgres) DateTrunc(unit, expr string) string {
+	switch unit {
+	case Week, Month:
+		return "CAST(date_trunc('" + unit + "', " + expr + ") AS DATE)"
+	}
+
+	return expr
+}
+
+func (postgres) NullsFirst(expr string) string { return expr + " NULLS FIRST" }
+
+func (postgres) DateArg(t time.Time) interface{} { return t }
+
+func (postgres) IgnoreConflicts(onConflict clause.OnConflict) clause.Expression {
+	onConflict.DoNothing = true
+	return onConflict
+}
+
+// Retryable matches serialization_failure and deadlock_detected.
+func (postgres) Retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")
+}
+
+type mysql struct{}
+
+func (mysql) Name() string { return MySQL }
+
+func (mysql) ILike(column string) string { return "LOWER(" + column + ") LIKE LOWER(?)" }
+
+func (mysql) Date(expr string) string { return "CAST(" + expr + " AS DATE)" }
+
+func (mysql) DateTrunc(unit, expr string) string {
+	switch unit {
+	case Week:
+		return "DATE_SUB(" + expr + ", INTERVAL WEEKDAY(" + expr + ") DAY)"
+	case Month:
+		return "DATE_SUB(" + expr + ", INTERVAL DAYOFMONTH(" + expr + ") - 1 DAY)"
+	}
+
+	return expr
+}
+
+// NullsFirst leaves the order as it is, MySQL sorts nulls first already.
+func (mysql) NullsFirst(expr string) string { return expr }
+
+func (mysql) DateArg(t time.Time) interface{} { return t }
+
+// IgnoreConflicts inserts with INSERT IGNORE, MySQL has no conflict target and cannot skip the
+// conflicts of a single constraint.
+func (mysql) IgnoreConflicts(clause.OnConflict) clause.Expression {
+	return clause.Insert{Modifier: "IGNORE"}
+}
+
+// Retryable matches ER_LOCK_DEADLOCK and ER_LOCK_WAIT_TIMEOUT.
+func (mysql) Retryable(err error) bool {
+	var myErr *mysqldriver.MySQLError
+	return errors.As(err, &myErr) && (myErr.Number == 1213 || myErr.Number == 1205)
+}
+
+// sqlite stores timestamps as text in the layout of the driver, 2006-01-02 15:04:05.999999999-07:00,
+// and dates as 2006-01-02.
+type sqlite struct{}
+
+func (sqlite) Name() string { return SQLite }
+
+func (sqlite) ILike(column string) string { return "LOWER(" + column + ") LIKE LOWER(?)" }
+
+// Date cuts the date off the text, date() would convert it to UTC first.
+func (sqlite) Date(expr string) string { return "substr(" + expr + ", 1, 10)" }
+
+func (sqlite) DateTrunc(unit, expr string) string {
+	switch unit {
+	case Week:
+		return "date(" + expr + ", 'weekday 0', '-6 days')"
+	case Month:
+		return "date(" + expr + ", 'start of month')"
+	}
+
+	return expr
+}
+
+func (sqlite) NullsFirst(expr string) string { return expr + " NULLS FIRST" }
+
+// DateArg formats t like the dates are stored, compared as text a time would sort after its date.
+func (sqlite) DateArg(t time.Time) interface{} { return t.Format(time.DateOnly) }
+
+func (sqlite) IgnoreConflicts(onConflict clause.OnConflict) clause.Expression {
+	onConflict.DoNothing = true
+	return onConflict
+}
+
+// Retryable matches SQLITE_BUSY and SQLITE_LOCKED, another connection held the database.
+func (sqlite) Retryable(err error) bool {
+	var liteErr sqlite3.Error
+	return errors.As(err, &liteErr) && (liteErr.Code == sqlite3.ErrBusy || liteErr.Code == sqlite3.ErrLocked)
+}
@@ -0,0 +1,73 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// dateLayouts are the layouts SQLite returns dates and timestamps in
+var dateLayouts = []string{
+	time.DateOnly,
+	time.DateTime,
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+}
+
+func init() {
+	schema.RegisterSerializer("date", DateSerializer{})
+}
+
+// DateSerializer reads a date or time computed by a query into a time.Time or *time.Time field, tag
+// the field with serializer:date. SQLite returns computed dates as text, it only parses the columns
+// declared as a date.
+type DateSerializer struct{}
+
+func (DateSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	value := reflect.New(field.FieldType).Elem()
+
+	if dbValue != nil {
+		t, err := parseDate(dbValue)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if field.FieldType.Kind() == reflect.Ptr {
+			value.Set(reflect.ValueOf(&t))
+		} else {
+			value.Set(reflect.ValueOf(t))
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(value)
+	return nil
+}
+
+func (DateSerializer) Value(_ context.Context, _ *schema.Field, _ reflect.Value, fieldValue interface{}) (interface{}, error) {
+	return fieldValue, nil
+}
+
+func parseDate(value interface{}) (time.Time, error) {
+	var text string
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	default:
+		return time.Time{}, fmt.Errorf("cannot read %T as a date", value)
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, text, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("cannot read %q as a date", text)
+}
@@ -6,7 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 
 	elastic "github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/esapi"
@@ -50,11 +50,10 @@ func (es *elasticsearch) Save(ctx context.Context, index string, document interf
 	defer res.Body.Close()
 
 	if res.IsError() {
-		log.Fatalf("Error indexing document: %s", res.String())
 		return fmt.Errorf("error when indexing document : %s", res.String())
 	}
 
-	log.Println("Document indexed successfully")
+	slog.With("component", "elasticsearch").DebugContext(ctx, "document indexed", "index", index)
 
 	return nil
 }
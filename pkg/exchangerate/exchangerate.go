@@ -0,0 +1,127 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// Provider returns the value of one major unit of from in major units of to.
+type Provider interface {
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+// FileProvider reads rates from a JSON file and reloads it when the file changes:
+//
+//	{"base": "USD", "rates": {"IDR": "16250", "SGD": "1.35"}}
+//
+// Rates are strings so they are read exactly, every rate is the value of one base unit.
+type FileProvider struct {
+	path string
+
+	mu      sync.RWMutex
+	modTime time.Time
+	base    string
+	rates   map[string]*big.Rat
+}
+
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *FileProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	fromRate, ok := p.rate(from)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRateNotFound, from)
+	}
+
+	toRate, ok := p.rate(to)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrRateNotFound, to)
+	}
+
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+func (p *FileProvider) rate(currency string) (*big.Rat, bool) {
+	if currency == p.base {
+		return big.NewRat(1, 1), true
+	}
+
+	rate, ok := p.rates[currency]
+	return rate, ok
+}
+
+// reload parses the file again when its modification time moved, a broken file keeps the last good rates.
+func (p *FileProvider) reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return err
+	}
+
+	p.mu.RLock()
+	fresh := info.ModTime().Equal(p.modTime)
+	p.mu.RUnlock()
+
+	if fresh {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return err
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("exchange rate file %s: %w", p.path, err)
+	}
+
+	if file.Base == "" {
+		return fmt.Errorf("exchange rate file %s: base is required", p.path)
+	}
+
+	rates := make(map[string]*big.Rat, len(file.Rates))
+	for currency, value := range file.Rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return fmt.Errorf("exchange rate file %s: invalid rate %q for %s", p.path, value, currency)
+		}
+
+		rates[currency] = rate
+	}
+
+	p.mu.Lock()
+	p.modTime, p.base, p.rates = info.ModTime(), file.Base, rates
+	p.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package exchangerate
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFileProvider(t *testing.T) {
+	Convey("Test file exchange rate provider", t, func() {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "rates.json")
+		So(os.WriteFile(path, []byte(`{"base": "USD", "rates": {"IDR": "16250", "SGD": "1.25"}}`), 0o644), ShouldBeNil)
+
+		provider, err := NewFileProvider(path)
+		So(err, ShouldBeNil)
+
+		Convey("converts through the base currency", func() {
+			rate, err := provider.Rate(ctx, "SGD", "IDR")
+			So(err, ShouldBeNil)
+			So(rate.Cmp(big.NewRat(13000, 1)), ShouldEqual, 0)
+
+			rate, err = provider.Rate(ctx, "USD", "SGD")
+			So(err, ShouldBeNil)
+			So(rate.Cmp(big.NewRat(5, 4)), ShouldEqual, 0)
+		})
+
+		Convey("resp err for unknown currency", func() {
+			_, err := provider.Rate(ctx, "SGD", "EUR")
+			So(errors.Is(err, ErrRateNotFound), ShouldBeTrue)
+		})
+
+		Convey("reloads a changed file", func() {
+			So(os.WriteFile(path, []byte(`{"base": "USD", "rates": {"SGD": "1.5"}}`), 0o644), ShouldBeNil)
+			later := time.Now().Add(time.Minute)
+			So(os.Chtimes(path, later, later), ShouldBeNil)
+
+			rate, err := provider.Rate(ctx, "USD", "SGD")
+			So(err, ShouldBeNil)
+			So(rate.Cmp(big.NewRat(3, 2)), ShouldEqual, 0)
+		})
+
+		Convey("resp err for an invalid file", func() {
+			So(os.WriteFile(path, []byte(`{"rates": {"SGD": "1.5"}}`), 0o644), ShouldBeNil)
+			_, err := NewFileProvider(path)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
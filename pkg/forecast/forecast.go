@@ -0,0 +1,175 @@
+// Package forecast predicts demand from a series of equally spaced observations, e.g. copies sold per
+// day, with a moving average or simple exponential smoothing, optionally around a seasonal cycle.
+package forecast
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	MovingAverage        = "moving_average"
+	ExponentialSmoothing = "exponential_smoothing"
+)
+
+var (
+	ErrUnknownMethod = errors.New("unknown forecast method")
+	ErrNoHistory     = errors.New("no history to forecast from")
+)
+
+type Options struct {
+	Method string
+	// Window is how many of the latest periods the moving average covers, all of them when 0
+	Window int
+	// Alpha is the smoothing factor between 0 and 1, higher values follow recent demand closer
+	Alpha float64
+	// Season is the length of the seasonal cycle in periods, e.g. 7 for days of the week, 0 for none.
+	// It is ignored until the history covers two full cycles.
+	Season int
+	// Horizon is how many periods to forecast
+	Horizon int
+}
+
+type Result struct {
+	// Level is the deseasonalized demand per period
+	Level float64
+	// Indices are the seasonal factors of the cycle, nil without seasonality
+	Indices []float64
+	// Forecast holds the demand of every period of the horizon
+	Forecast []float64
+	// StdDev is the standard deviation of the one period ahead errors over the history
+	StdDev float64
+}
+
+// Total is the demand forecast over the whole horizon.
+func (r *Result) Total() float64 {
+	var total float64
+	for _, f := range r.Forecast {
+		total += f
+	}
+
+	return total
+}
+
+// Forecast fits history and forecasts the periods following it.
+func Forecast(history []float64, opts Options) (*Result, error) {
+	if len(history) == 0 {
+		return nil, ErrNoHistory
+	}
+
+	var level func(series []float64) (float64, []float64)
+	switch opts.Method {
+	case MovingAverage:
+		level = func(series []float64) (float64, []float64) { return movingAverage(series, opts.Window) }
+	case ExponentialSmoothing:
+		level = func(series []float64) (float64, []float64) { return smooth(series, opts.Alpha) }
+	default:
+		return nil, ErrUnknownMethod
+	}
+
+	result := &Result{}
+
+	series := history
+	if opts.Season > 1 && len(history) >= 2*opts.Season {
+		result.Indices = SeasonalIndices(history, opts.Season)
+
+		series = make([]float64, len(history))
+		for i, h := range history {
+			series[i] = h / result.Indices[i%opts.Season]
+		}
+	}
+
+	var fitted []float64
+	result.Level, fitted = level(series)
+
+	var sum float64
+	for i := range series {
+		diff := (series[i] - fitted[i]) * index(result.Indices, i)
+		sum += diff * diff
+	}
+	result.StdDev = math.Sqrt(sum / float64(len(series)))
+
+	result.Forecast = make([]float64, opts.Horizon)
+	for h := range result.Forecast {
+		result.Forecast[h] = result.Level * index(result.Indices, len(history)+h)
+	}
+
+	return result, nil
+}
+
+// SeasonalIndices returns the multiplicative factor of every position of the cycle: the average at
+// that position over the average of the whole history. Positions never seen with demand get 1.
+func SeasonalIndices(history []float64, season int) []float64 {
+	sums := make([]float64, season)
+	counts := make([]float64, season)
+
+	var total float64
+	for i, h := range history {
+		sums[i%season] += h
+		counts[i%season]++
+		total += h
+	}
+
+	indices := make([]float64, season)
+	mean := total / float64(len(history))
+	for i := range indices {
+		indices[i] = 1
+		if mean > 0 && counts[i] > 0 && sums[i] > 0 {
+			indices[i] = sums[i] / counts[i] / mean
+		}
+	}
+
+	return indices
+}
+
+func index(indices []float64, period int) float64 {
+	if len(indices) == 0 {
+		return 1
+	}
+
+	return indices[period%len(indices)]
+}
+
+// movingAverage returns the mean of the last window periods and, for every period, the mean of the
+// window before it as its fitted value.
+func movingAverage(series []float64, window int) (float64, []float64) {
+	if window <= 0 || window > len(series) {
+		window = len(series)
+	}
+
+	fitted := make([]float64, len(series))
+
+	var sum float64
+	for i, s := range series {
+		if i > 0 {
+			fitted[i] = sum / float64(min(i, window))
+		} else {
+			fitted[i] = s
+		}
+
+		sum += s
+		if i >= window {
+			sum -= series[i-window]
+		}
+	}
+
+	return sum / float64(window), fitted
+}
+
+// smooth runs simple exponential smoothing from the first observation, the fitted value of a period
+// is the level before it.
+func smooth(series []float64, alpha float64) (float64, []float64) {
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.3
+	}
+
+	fitted := make([]float64, len(series))
+
+	level := series[0]
+	for i, s := range series {
+		fitted[i] = level
+		level = alpha*s + (1-alpha)*level
+	}
+
+	return level, fitted
+}
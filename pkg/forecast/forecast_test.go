@@ -0,0 +1,54 @@
+package forecast
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestForecast(t *testing.T) {
+	Convey("Test forecast", t, func() {
+		Convey("rejects an empty history and unknown methods", func() {
+			_, err := Forecast(nil, Options{Method: MovingAverage})
+			So(err, ShouldEqual, ErrNoHistory)
+
+			_, err = Forecast([]float64{1}, Options{Method: "arima"})
+			So(err, ShouldEqual, ErrUnknownMethod)
+		})
+
+		Convey("moving average covers the latest window", func() {
+			result, err := Forecast([]float64{10, 10, 2, 4, 6}, Options{Method: MovingAverage, Window: 3, Horizon: 2})
+			So(err, ShouldBeNil)
+			So(result.Level, ShouldEqual, 4)
+			So(result.Forecast, ShouldResemble, []float64{4, 4})
+			So(result.Total(), ShouldEqual, 8)
+		})
+
+		Convey("exponential smoothing follows recent demand", func() {
+			result, err := Forecast([]float64{10, 20}, Options{Method: ExponentialSmoothing, Alpha: 0.5, Horizon: 1})
+			So(err, ShouldBeNil)
+			So(result.Level, ShouldEqual, 15)
+			So(result.StdDev, ShouldAlmostEqual, 7.0710678, 0.0001)
+		})
+
+		Convey("seasonality lifts the periods that sell more", func() {
+			// weekends sell three times a weekday, two weeks of history
+			week := []float64{2, 2, 2, 2, 2, 6, 6}
+			history := append(append([]float64{}, week...), week...)
+
+			result, err := Forecast(history, Options{Method: MovingAverage, Season: 7, Horizon: 7})
+			So(err, ShouldBeNil)
+			So(result.Indices, ShouldHaveLength, 7)
+			So(result.Forecast[0], ShouldAlmostEqual, 2, 0.0001)
+			So(result.Forecast[5], ShouldAlmostEqual, 6, 0.0001)
+			So(result.Total(), ShouldAlmostEqual, 22, 0.0001)
+			So(result.StdDev, ShouldAlmostEqual, 0, 0.0001)
+
+			Convey("but only with two full cycles of history", func() {
+				result, err := Forecast(week, Options{Method: MovingAverage, Season: 7, Horizon: 1})
+				So(err, ShouldBeNil)
+				So(result.Indices, ShouldBeNil)
+			})
+		})
+	})
+}
@@ -0,0 +1,166 @@
+package identifier
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const (
+	skuAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	skuLength   = 8
+
+	// internal barcodes use the GS1 restricted circulation range so they never clash with ISBN-13 (978/979)
+	internalBarcodePrefix = "21"
+)
+
+var ErrInvalidISBN = errors.New("invalid isbn")
+
+// NormalizeISBN removes hyphens and spaces and upper-cases the ISBN-10 check character.
+func NormalizeISBN(isbn string) string {
+	isbn = strings.ToUpper(isbn)
+	return strings.NewReplacer("-", "", " ", "").Replace(isbn)
+}
+
+func IsValidISBN(isbn string) bool {
+	isbn = NormalizeISBN(isbn)
+	return IsValidISBN10(isbn) || IsValidISBN13(isbn)
+}
+
+func IsValidISBN10(isbn string) bool {
+	if len(isbn) != 10 || !isDigits(isbn[:9]) {
+		return false
+	}
+
+	last := isbn[9]
+	if last != 'X' && !isDigit(last) {
+		return false
+	}
+
+	return isbn10CheckDigit(isbn[:9]) == last
+}
+
+func IsValidISBN13(isbn string) bool {
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+
+	return IsValidEAN13(isbn)
+}
+
+func IsValidEAN13(code string) bool {
+	if len(code) != 13 || !isDigits(code) {
+		return false
+	}
+
+	return EAN13CheckDigit(code[:12]) == code[12]
+}
+
+// ToISBN13 converts a valid ISBN-10 or ISBN-13 to its normalized ISBN-13 form.
+func ToISBN13(isbn string) (string, error) {
+	isbn = NormalizeISBN(isbn)
+
+	switch {
+	case IsValidISBN13(isbn):
+		return isbn, nil
+	case IsValidISBN10(isbn):
+		base := "978" + isbn[:9]
+		return base + string(EAN13CheckDigit(base)), nil
+	}
+
+	return "", ErrInvalidISBN
+}
+
+// ToISBN10 converts a valid ISBN to ISBN-10. Only 978-prefixed ISBN-13s have an ISBN-10 form.
+func ToISBN10(isbn string) (string, error) {
+	isbn = NormalizeISBN(isbn)
+
+	switch {
+	case IsValidISBN10(isbn):
+		return isbn, nil
+	case IsValidISBN13(isbn) && strings.HasPrefix(isbn, "978"):
+		base := isbn[3:12]
+		return base + string(isbn10CheckDigit(base)), nil
+	}
+
+	return "", ErrInvalidISBN
+}
+
+// EAN13CheckDigit computes the check digit for the first 12 digits of an EAN-13 code.
+func EAN13CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(digits[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// GenerateSKU returns a random internal stock keeping unit such as BK-7KQ2M9XA.
+func GenerateSKU(prefix string) (string, error) {
+	code, err := randomString(skuAlphabet, skuLength)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + "-" + code, nil
+}
+
+// GenerateBarcode returns a random internal EAN-13 code for items without an ISBN.
+func GenerateBarcode() (string, error) {
+	digits, err := randomString("0123456789", 12-len(internalBarcodePrefix))
+	if err != nil {
+		return "", err
+	}
+
+	base := internalBarcodePrefix + digits
+
+	return base + string(EAN13CheckDigit(base)), nil
+}
+
+func isbn10CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(digits[i]-'0') * (10 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+
+	return byte('0' + check)
+}
+
+func randomString(alphabet string, length int) (string, error) {
+	max := big.NewInt(int64(len(alphabet)))
+	buf := make([]byte, length)
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = alphabet[n.Int64()]
+	}
+
+	return string(buf), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
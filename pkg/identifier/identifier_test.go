@@ -0,0 +1,50 @@
+package identifier
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestISBN(t *testing.T) {
+	Convey("Test isbn validation and conversion", t, func() {
+		Convey("validates checksums", func() {
+			So(IsValidISBN("0-306-40615-2"), ShouldBeTrue)
+			So(IsValidISBN("978-0-306-40615-7"), ShouldBeTrue)
+			So(IsValidISBN("080442957X"), ShouldBeTrue)
+			So(IsValidISBN("0-306-40615-3"), ShouldBeFalse)
+			So(IsValidISBN("978-0-306-40615-8"), ShouldBeFalse)
+			So(IsValidISBN("9791034304905"), ShouldBeTrue)
+			So(IsValidISBN("4006381333931"), ShouldBeFalse)
+		})
+
+		Convey("converts isbn-10 to isbn-13", func() {
+			isbn, err := ToISBN13("0-306-40615-2")
+			So(err, ShouldBeNil)
+			So(isbn, ShouldEqual, "9780306406157")
+		})
+
+		Convey("converts isbn-13 to isbn-10", func() {
+			isbn, err := ToISBN10("9780804429573")
+			So(err, ShouldBeNil)
+			So(isbn, ShouldEqual, "080442957X")
+
+			_, err = ToISBN10("9791034304905")
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGenerate(t *testing.T) {
+	Convey("Test generated identifiers", t, func() {
+		sku, err := GenerateSKU("BK")
+		So(err, ShouldBeNil)
+		So(sku, ShouldStartWith, "BK-")
+		So(len(sku), ShouldEqual, 11)
+
+		barcode, err := GenerateBarcode()
+		So(err, ShouldBeNil)
+		So(IsValidEAN13(barcode), ShouldBeTrue)
+		So(IsValidISBN13(barcode), ShouldBeFalse)
+	})
+}
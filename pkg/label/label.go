@@ -0,0 +1,164 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/barcode"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/identifier"
+)
+
+type Label struct {
+	Title      string
+	AuthorName string
+	Price      string
+	Location   string
+	SKU        string
+	Barcode    string
+}
+
+// Sheet describes a label stock in millimetres.
+type Sheet struct {
+	Columns     int
+	Rows        int
+	LabelWidth  float64
+	LabelHeight float64
+	MarginLeft  float64
+	MarginTop   float64
+	GapX        float64
+	GapY        float64
+}
+
+// A4 is the common 21 labels per page stock (3 x 7, 63.5 x 38.1 mm).
+var A4 = Sheet{
+	Columns:     3,
+	Rows:        7,
+	LabelWidth:  63.5,
+	LabelHeight: 38.1,
+	MarginLeft:  7.2,
+	MarginTop:   15.1,
+	GapX:        2.5,
+}
+
+const (
+	padding  = 2.0
+	qrSize   = 16.0
+	barcodeH = 9.0
+)
+
+// Render lays out the labels left to right, top to bottom and starts a new page when the sheet is full.
+func Render(sheet Sheet, labels []Label) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.SetTitle("Shelf labels", true)
+
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	perPage := sheet.Columns * sheet.Rows
+
+	for i, l := range labels {
+		if i%perPage == 0 {
+			pdf.AddPage()
+		}
+
+		pos := i % perPage
+		x := sheet.MarginLeft + float64(pos%sheet.Columns)*(sheet.LabelWidth+sheet.GapX)
+		y := sheet.MarginTop + float64(pos/sheet.Columns)*(sheet.LabelHeight+sheet.GapY)
+
+		if err := drawLabel(pdf, tr, sheet, fmt.Sprintf("label-%d", i), x, y, l); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func drawLabel(pdf *fpdf.Fpdf, tr func(string) string, sheet Sheet, name string, x, y float64, l Label) error {
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Rect(x, y, sheet.LabelWidth, sheet.LabelHeight, "D")
+
+	textWidth := sheet.LabelWidth - qrSize - 3*padding
+
+	pdf.SetXY(x+padding, y+padding)
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(textWidth, 4, fit(pdf, tr(l.Title), textWidth), "", 2, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.CellFormat(textWidth, 3.5, fit(pdf, tr(l.AuthorName), textWidth), "", 2, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.CellFormat(textWidth, 4.5, fit(pdf, tr(l.Price), textWidth), "", 2, "L", false, 0, "")
+
+	if l.Location != "" {
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.CellFormat(textWidth, 3.5, fit(pdf, tr("Loc: "+l.Location), textWidth), "", 2, "L", false, 0, "")
+	}
+
+	qr, err := barcode.Encode(barcode.SymbologyQR, l.SKU, 256, 256)
+	if err != nil {
+		return err
+	}
+
+	if err := placeImage(pdf, name+"-qr", qr, x+sheet.LabelWidth-padding-qrSize, y+padding, qrSize, qrSize); err != nil {
+		return err
+	}
+
+	symbology, content := barcode.SymbologyCode128, l.SKU
+	if identifier.IsValidEAN13(l.Barcode) {
+		symbology, content = barcode.SymbologyEAN13, l.Barcode
+	}
+
+	linear, err := barcode.Encode(symbology, content, 400, 90)
+	if err != nil {
+		return err
+	}
+
+	barcodeW := sheet.LabelWidth - 2*padding
+	barcodeY := y + sheet.LabelHeight - padding - 3 - barcodeH
+	if err := placeImage(pdf, name+"-barcode", linear, x+padding, barcodeY, barcodeW, barcodeH); err != nil {
+		return err
+	}
+
+	pdf.SetXY(x+padding, barcodeY+barcodeH)
+	pdf.SetFont("Courier", "", 7)
+	pdf.CellFormat(barcodeW, 3, content, "", 0, "C", false, 0, "")
+
+	return nil
+}
+
+func placeImage(pdf *fpdf.Fpdf, name string, img image.Image, x, y, w, h float64) error {
+	data, err := barcode.PNG(img)
+	if err != nil {
+		return err
+	}
+
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(data))
+	pdf.ImageOptions(name, x, y, w, h, false, options, 0, "")
+
+	return pdf.Error()
+}
+
+// fit truncates s with an ellipsis so it fits in width millimetres with the current font.
+// s is already translated to the single byte font encoding, so it is cut per byte.
+func fit(pdf *fpdf.Fpdf, s string, width float64) string {
+	if pdf.GetStringWidth(s) <= width {
+		return s
+	}
+
+	for len(s) > 0 && pdf.GetStringWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+
+	return s + "..."
+}
@@ -0,0 +1,213 @@
+// Package logger builds the slog loggers of the service. Every record names the component that
+// wrote it, each component logs at a level of its own that can change while the service runs, and
+// records written with a request's context carry its request ID.
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+
+	// ComponentKey is the attribute naming the component of a record, slog.With(ComponentKey, name)
+	// on a logger of the package logs at the level of that component
+	ComponentKey = "component"
+	RequestIDKey = "request_id"
+
+	requestIDCtxKey = "request-id-ctx"
+)
+
+// Logger hands out the loggers of the components and keeps their levels. A component without a
+// level of its own logs at the default level.
+type Logger struct {
+	handler slog.Handler
+
+	mu     sync.RWMutex
+	level  slog.Level
+	levels map[string]slog.Level
+}
+
+// New returns a Logger writing records to w as json or text. levels holds the default level under
+// the empty name and the levels of components under theirs, see ParseLevels.
+func New(w io.Writer, format string, levels map[string]slog.Level) (*Logger, error) {
+	options := &slog.HandlerOptions{Level: slog.Level(-1 << 10)}
+
+	var handler slog.Handler
+	switch format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, options)
+	case FormatText:
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+
+	l := &Logger{handler: handler, level: slog.LevelInfo, levels: make(map[string]slog.Level)}
+	for component, level := range levels {
+		l.SetLevel(component, level)
+	}
+
+	return l, nil
+}
+
+// For returns the logger of component.
+func (l *Logger) For(component string) *slog.Logger {
+	return slog.New(&handler{logger: l, next: l.handler, component: component})
+}
+
+// SetLevel sets the level of component, the empty name sets the default level.
+func (l *Logger) SetLevel(component string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if component == "" {
+		l.level = level
+		return
+	}
+
+	l.levels[component] = level
+}
+
+// ResetLevel makes component log at the default level again.
+func (l *Logger) ResetLevel(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.levels, component)
+}
+
+// Level returns the level component logs at.
+func (l *Logger) Level(component string) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if level, ok := l.levels[component]; ok {
+		return level
+	}
+
+	return l.level
+}
+
+// Levels returns the default level under the empty name and the levels set for components.
+func (l *Logger) Levels() map[string]slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := map[string]slog.Level{"": l.level}
+	for component, level := range l.levels {
+		levels[component] = level
+	}
+
+	return levels
+}
+
+// ParseLevels parses a comma separated list of levels, a bare level is the default one and
+// component=level the one of a component: "info,gorm=debug,http=warn".
+func ParseLevels(spec string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		component, name, ok := strings.Cut(part, "=")
+		if !ok {
+			component, name = "", part
+		}
+
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+			return nil, fmt.Errorf("log level %q: %w", part, err)
+		}
+
+		levels[strings.TrimSpace(component)] = level
+	}
+
+	return levels, nil
+}
+
+// handler filters records by the level of their component and adds the component and the request
+// ID of the context to them. The ComponentKey attribute given last names the component, it is kept
+// out of the attributes so that a record names one component only.
+type handler struct {
+	logger    *Logger
+	next      slog.Handler
+	component string
+	// grouped is set once the component went into next ahead of a group
+	grouped bool
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.logger.Level(h.component)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	if !h.grouped {
+		record.AddAttrs(slog.String(ComponentKey, h.component))
+	}
+
+	if id := GetRequestID(ctx); id != "" {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := *h
+
+	kept := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey && !h.grouped {
+			next.component = attr.Value.String()
+			continue
+		}
+
+		kept = append(kept, attr)
+	}
+
+	next.next = h.next.WithAttrs(kept)
+	return &next
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	next := *h
+	if !h.grouped {
+		next.next = h.next.WithAttrs([]slog.Attr{slog.String(ComponentKey, h.component)})
+		next.grouped = true
+	}
+
+	next.next = next.next.WithGroup(name)
+	return &next
+}
+
+// SetRequestIDContext keeps the request ID of a request in its gin context.
+func SetRequestIDContext(c *gin.Context, id string) {
+	c.Set(requestIDCtxKey, id)
+}
+
+// WithRequestID returns ctx carrying the request ID id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey, id)
+}
+
+// GetRequestID returns the request ID of ctx, empty when it carries none.
+func GetRequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
@@ -0,0 +1,120 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/gorm"
+)
+
+// records decodes the json records written to buf.
+func records(buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		record := make(map[string]interface{})
+		So(json.Unmarshal([]byte(line), &record), ShouldBeNil)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestParseLevels(t *testing.T) {
+	Convey("Test parse levels", t, func() {
+		levels, err := ParseLevels("info, gorm=debug,http=WARN")
+		So(err, ShouldBeNil)
+		So(levels, ShouldResemble, map[string]slog.Level{"": slog.LevelInfo, "gorm": slog.LevelDebug, "http": slog.LevelWarn})
+
+		_, err = ParseLevels("info,gorm=loud")
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestLogger(t *testing.T) {
+	Convey("Test logger", t, func() {
+		var buf bytes.Buffer
+		l, err := New(&buf, FormatJSON, map[string]slog.Level{"": slog.LevelInfo, "gorm": slog.LevelWarn})
+		So(err, ShouldBeNil)
+
+		Convey("logs every component at its own level", func() {
+			l.For("http").Debug("hidden")
+			l.For("http").Info("shown")
+			l.For("gorm").Info("hidden")
+			l.For("gorm").Warn("shown")
+
+			logged := records(&buf)
+			So(logged, ShouldHaveLength, 2)
+			So(logged[0]["component"], ShouldEqual, "http")
+			So(logged[1]["component"], ShouldEqual, "gorm")
+		})
+
+		Convey("changes levels while running", func() {
+			http := l.For("http")
+
+			l.SetLevel("http", slog.LevelDebug)
+			http.Debug("shown")
+
+			l.ResetLevel("http")
+			http.Debug("hidden")
+
+			l.SetLevel("", slog.LevelError)
+			http.Warn("hidden")
+
+			So(records(&buf), ShouldHaveLength, 1)
+			So(l.Levels(), ShouldResemble, map[string]slog.Level{"": slog.LevelError, "gorm": slog.LevelWarn})
+		})
+
+		Convey("names one component and adds the request id", func() {
+			ctx := WithRequestID(context.Background(), "req-1")
+			l.For("app").With("component", "gorm", "key", "value").InfoContext(ctx, "hidden")
+			l.For("app").With("component", "cache").InfoContext(ctx, "shown")
+
+			logged := records(&buf)
+			So(logged, ShouldHaveLength, 1)
+			So(logged[0]["component"], ShouldEqual, "cache")
+			So(logged[0]["request_id"], ShouldEqual, "req-1")
+			So(strings.Count(buf.String(), `"component"`), ShouldEqual, 1)
+		})
+
+		Convey("rejects unknown formats", func() {
+			_, err := New(&buf, "xml", nil)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestGorm(t *testing.T) {
+	Convey("Test gorm logger", t, func() {
+		var buf bytes.Buffer
+		l, err := New(&buf, FormatJSON, map[string]slog.Level{"gorm": slog.LevelWarn})
+		So(err, ShouldBeNil)
+
+		gormLogger := l.Gorm(100 * time.Millisecond)
+		ctx := WithRequestID(context.Background(), "req-1")
+		query := func() (string, int64) { return "SELECT 1", 1 }
+
+		gormLogger.Trace(ctx, time.Now(), query, nil)
+		gormLogger.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)
+		gormLogger.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+		gormLogger.Trace(ctx, time.Now(), query, errors.New("syntax error"))
+
+		logged := records(&buf)
+		So(logged, ShouldHaveLength, 2)
+		So(logged[0]["msg"], ShouldEqual, "slow query")
+		So(logged[0]["sql"], ShouldEqual, "SELECT 1")
+		So(logged[0]["request_id"], ShouldEqual, "req-1")
+		So(logged[1]["msg"], ShouldEqual, "query failed")
+		So(logged[1]["error"], ShouldEqual, "syntax error")
+	})
+}
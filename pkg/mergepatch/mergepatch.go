@@ -0,0 +1,68 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+const ContentType = "application/merge-patch+json"
+
+var ErrNotObject = errors.New("merge patch must be a json object")
+
+// Patch is an RFC 7396 merge patch document keyed by json member name.
+type Patch map[string]json.RawMessage
+
+func Parse(data []byte) (Patch, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, ErrNotObject
+	}
+
+	var patch Patch
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+
+	return patch, nil
+}
+
+func (p Patch) Has(name string) bool {
+	_, ok := p[name]
+	return ok
+}
+
+// IsNull reports whether the member is present with an explicit null, which RFC 7396 treats as a removal.
+func (p Patch) IsNull(name string) bool {
+	raw, ok := p[name]
+	return ok && bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// NullFields returns the sorted member names that are explicitly set to null.
+func (p Patch) NullFields() []string {
+	var fields []string
+	for name := range p {
+		if p.IsNull(name) {
+			fields = append(fields, name)
+		}
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// Decode unmarshals the present members into dst, which should use pointer fields so absent members stay nil.
+// Members that dst does not declare are rejected.
+func (p Patch) Decode(dst interface{}) error {
+	data, err := json.Marshal(map[string]json.RawMessage(p))
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	return decoder.Decode(dst)
+}
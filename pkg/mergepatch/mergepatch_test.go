@@ -0,0 +1,102 @@
+package mergepatch
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type member struct {
+	Name    *string  `json:"name"`
+	Email   *string  `json:"email"`
+	Address *address `json:"address"`
+}
+
+func TestParse(t *testing.T) {
+	Convey("Test parse", t, func() {
+		Convey("rejects a patch that is not an object", func() {
+			for _, body := range []string{``, `[{"name":"jamil"}]`, `"jamil"`, `null`, `1`} {
+				_, err := Parse([]byte(body))
+				So(err, ShouldEqual, ErrNotObject)
+			}
+		})
+
+		Convey("rejects invalid json", func() {
+			_, err := Parse([]byte(`{"name":`))
+			So(err, ShouldNotBeNil)
+			So(err, ShouldNotEqual, ErrNotObject)
+		})
+
+		Convey("keeps the members present", func() {
+			patch, err := Parse([]byte(` {"name":"jamil","email":null} `))
+			So(err, ShouldBeNil)
+			So(patch.Has("name"), ShouldBeTrue)
+			So(patch.Has("email"), ShouldBeTrue)
+			So(patch.Has("address"), ShouldBeFalse)
+		})
+	})
+}
+
+func TestNull(t *testing.T) {
+	Convey("Test null members", t, func() {
+		patch, err := Parse([]byte(`{"name":"jamil","email": null ,"address":{"city":null}}`))
+		So(err, ShouldBeNil)
+
+		So(patch.IsNull("email"), ShouldBeTrue)
+		So(patch.IsNull("name"), ShouldBeFalse)
+		So(patch.IsNull("missing"), ShouldBeFalse)
+
+		// a null inside a nested object does not remove the object
+		So(patch.IsNull("address"), ShouldBeFalse)
+		So(patch.NullFields(), ShouldResemble, []string{"email"})
+
+		patch, err = Parse([]byte(`{"name":null,"email":null}`))
+		So(err, ShouldBeNil)
+		So(patch.NullFields(), ShouldResemble, []string{"email", "name"})
+	})
+}
+
+func TestDecode(t *testing.T) {
+	Convey("Test decode", t, func() {
+		Convey("leaves absent and null members nil", func() {
+			patch, err := Parse([]byte(`{"name":"jamil","email":null}`))
+			So(err, ShouldBeNil)
+
+			var dst member
+			So(patch.Decode(&dst), ShouldBeNil)
+			So(*dst.Name, ShouldEqual, "jamil")
+			So(dst.Email, ShouldBeNil)
+			So(dst.Address, ShouldBeNil)
+		})
+
+		Convey("decodes a nested object as a whole", func() {
+			patch, err := Parse([]byte(`{"address":{"city":"Bandung"}}`))
+			So(err, ShouldBeNil)
+
+			var dst member
+			So(patch.Decode(&dst), ShouldBeNil)
+			So(dst.Address, ShouldResemble, &address{City: "Bandung"})
+		})
+
+		Convey("rejects unknown members", func() {
+			patch, err := Parse([]byte(`{"id":2}`))
+			So(err, ShouldBeNil)
+
+			var dst member
+			So(patch.Decode(&dst), ShouldNotBeNil)
+		})
+
+		Convey("rejects members of the wrong type", func() {
+			patch, err := Parse([]byte(`{"name":1}`))
+			So(err, ShouldBeNil)
+
+			var dst member
+			So(patch.Decode(&dst), ShouldNotBeNil)
+		})
+	})
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// CacheLookups counts the lookups of one kind of cached value.
+type CacheLookups struct {
+	Hits   int64
+	Misses int64
+}
+
+var cacheLookupsDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "cache_lookups_total"),
+	"Cache lookups, by kind of value and result: hit or miss.",
+	[]string{"kind", "result"}, nil,
+)
+
+// cacheCollector reads the lookups from the cache when scraped instead of counting them twice.
+type cacheCollector struct {
+	lookups func() map[string]CacheLookups
+}
+
+func (c *cacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheLookupsDesc
+}
+
+func (c *cacheCollector) Collect(ch chan<- prometheus.Metric) {
+	for kind, lookups := range c.lookups() {
+		ch <- prometheus.MustNewConstMetric(cacheLookupsDesc, prometheus.CounterValue, float64(lookups.Hits), kind, "hit")
+		ch <- prometheus.MustNewConstMetric(cacheLookupsDesc, prometheus.CounterValue, float64(lookups.Misses), kind, "miss")
+	}
+}
+
+// RegisterCache publishes the hits and misses lookups returns by kind of value.
+func RegisterCache(lookups func() map[string]CacheLookups) error {
+	return registry.Register(&cacheCollector{lookups: lookups})
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startKey = "metrics:start"
+
+// Gorm is a gorm plugin timing every statement into db_query_duration_seconds, by the operation of
+// its callback and the table it ran on.
+type Gorm struct{}
+
+func (Gorm) Name() string { return "metrics" }
+
+func (Gorm) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	for _, err := range []error{
+		callback.Create().Before("gorm:create").Register("metrics:create", start),
+		callback.Create().After("gorm:create").Register("metrics:create_done", observe("create")),
+		callback.Query().Before("gorm:query").Register("metrics:query", start),
+		callback.Query().After("gorm:query").Register("metrics:query_done", observe("query")),
+		callback.Update().Before("gorm:update").Register("metrics:update", start),
+		callback.Update().After("gorm:update").Register("metrics:update_done", observe("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:delete", start),
+		callback.Delete().After("gorm:delete").Register("metrics:delete_done", observe("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:row", start),
+		callback.Row().After("gorm:row").Register("metrics:row_done", observe("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:raw", start),
+		callback.Raw().After("gorm:raw").Register("metrics:raw_done", observe("raw")),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func start(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func observe(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+
+		failed := db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound)
+		ObserveQuery(operation, table, time.Since(value.(time.Time)), failed)
+	}
+}
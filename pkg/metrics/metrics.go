@@ -0,0 +1,161 @@
+// Package metrics holds the Prometheus metrics of the service, the HTTP, database and business ones,
+// and serves them. Handlers, usecases and repositories record through the functions of the package
+// instead of touching the collectors.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "inventory"
+
+// outcomes of a transaction
+const (
+	Commit   = "commit"
+	Rollback = "rollback"
+)
+
+// reasons of a failed login
+const (
+	LoginInvalidRequest = "invalid_request"
+	LoginUnknownUser    = "unknown_user"
+	LoginWrongPassword  = "wrong_password"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to handle HTTP requests, by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.With(registry).NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Time taken by SQL statements, by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	dbQueryErrors = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_query_errors_total",
+		Help:      "SQL statements that failed, by operation and table. A record that is not found is no failure.",
+	}, []string{"operation", "table"})
+
+	dbTransactions = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transactions_total",
+		Help:      "Database transactions finished, by outcome: commit or rollback.",
+	}, []string{"outcome"})
+
+	dbTransactionRetries = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "db_transaction_retries_total",
+		Help:      "Transactions run again after a serialization failure or deadlock.",
+	})
+
+	booksCreated = promauto.With(registry).NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "books_created_total",
+		Help:      "Books created.",
+	})
+
+	loginFailures = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Failed logins, by reason.",
+	}, []string{"reason"})
+
+	stockMovements = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_movements_total",
+		Help:      "Stock movements recorded in the ledger, by type.",
+	}, []string{"type"})
+
+	stockQuantity = promauto.With(registry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stock_movement_copies_total",
+		Help:      "Copies moved by the stock movements, by type and direction: in or out.",
+	}, []string{"type", "direction"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// RegisterDB publishes the connection pool stats of db, name tells the pools apart.
+func RegisterDB(name string, db *sql.DB) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveRequest records a handled HTTP request, route is the pattern the request matched.
+func ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveQuery records a SQL statement, failed tells whether it returned an error.
+func ObserveQuery(operation, table string, elapsed time.Duration, failed bool) {
+	dbQueryDuration.WithLabelValues(operation, table).Observe(elapsed.Seconds())
+	if failed {
+		dbQueryErrors.WithLabelValues(operation, table).Inc()
+	}
+}
+
+// TransactionFinished records a transaction that committed or rolled back.
+func TransactionFinished(outcome string) {
+	dbTransactions.WithLabelValues(outcome).Inc()
+}
+
+// TransactionRetried records a transaction run again.
+func TransactionRetried() {
+	dbTransactionRetries.Inc()
+}
+
+// BookCreated records a created book.
+func BookCreated() {
+	booksCreated.Inc()
+}
+
+// LoginFailed records a failed login.
+func LoginFailed(reason string) {
+	loginFailures.WithLabelValues(reason).Inc()
+}
+
+// StockMoved records a stock movement of quantity copies, negative ones leave the stock.
+func StockMoved(kind string, quantity int) {
+	stockMovements.WithLabelValues(kind).Inc()
+
+	direction := "in"
+	if quantity < 0 {
+		direction, quantity = "out", -quantity
+	}
+
+	stockQuantity.WithLabelValues(kind, direction).Add(float64(quantity))
+}
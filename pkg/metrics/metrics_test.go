@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type book struct {
+	ID    int
+	Title string
+}
+
+func TestMetrics(t *testing.T) {
+	Convey("Test metrics", t, func() {
+		Convey("counts requests by route and status", func() {
+			before := testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/books/:id", "200"))
+
+			ObserveRequest("GET", "/books/:id", 200, 20*time.Millisecond)
+			So(testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/books/:id", "200")), ShouldEqual, before+1)
+		})
+
+		Convey("counts stock movements and the copies moved in and out", func() {
+			StockMoved("sale_shipment", -3)
+			StockMoved("sale_shipment", -2)
+			StockMoved("purchase_receipt", 5)
+
+			So(testutil.ToFloat64(stockMovements.WithLabelValues("sale_shipment")), ShouldEqual, 2)
+			So(testutil.ToFloat64(stockQuantity.WithLabelValues("sale_shipment", "out")), ShouldEqual, 5)
+			So(testutil.ToFloat64(stockQuantity.WithLabelValues("purchase_receipt", "in")), ShouldEqual, 5)
+		})
+
+		Convey("times gorm statements and publishes the pool stats", func() {
+			db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "metrics.db")), &gorm.Config{Logger: logger.Discard})
+			So(err, ShouldBeNil)
+			So(db.Use(Gorm{}), ShouldBeNil)
+			So(db.AutoMigrate(&book{}), ShouldBeNil)
+
+			So(db.Create(&book{Title: "Dune"}).Error, ShouldBeNil)
+			So(db.First(&book{}, 2).Error, ShouldEqual, gorm.ErrRecordNotFound)
+			So(db.Exec("SELECT * FROM missing").Error, ShouldNotBeNil)
+
+			So(testutil.CollectAndCount(dbQueryDuration, "inventory_db_query_duration_seconds"), ShouldBeGreaterThanOrEqualTo, 3)
+			So(testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "books")), ShouldEqual, 0)
+			So(testutil.ToFloat64(dbQueryErrors.WithLabelValues("raw", "unknown")), ShouldEqual, 1)
+
+			rdb, err := db.DB()
+			So(err, ShouldBeNil)
+			So(RegisterDB("test", rdb), ShouldBeNil)
+
+			recorder := httptest.NewRecorder()
+			Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+			body, err := io.ReadAll(recorder.Body)
+			So(err, ShouldBeNil)
+			So(string(body), ShouldContainSubstring, `go_sql_open_connections{db_name="test"}`)
+			So(string(body), ShouldContainSubstring, `inventory_db_query_duration_seconds_bucket{operation="create",table="books"`)
+		})
+
+		Convey("publishes the cache lookups", func() {
+			So(RegisterCache(func() map[string]CacheLookups {
+				return map[string]CacheLookups{"book": {Hits: 3, Misses: 1}}
+			}), ShouldBeNil)
+
+			recorder := httptest.NewRecorder()
+			Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+			body, err := io.ReadAll(recorder.Body)
+			So(err, ShouldBeNil)
+			So(string(body), ShouldContainSubstring, `inventory_cache_lookups_total{kind="book",result="hit"} 3`)
+			So(string(body), ShouldContainSubstring, `inventory_cache_lookups_total{kind="book",result="miss"} 1`)
+		})
+	})
+}
@@ -0,0 +1,280 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidAmount    = errors.New("invalid amount")
+)
+
+// exponents holds the ISO 4217 minor unit digits of every supported currency.
+var exponents = map[string]int{
+	"AUD": 2,
+	"CNY": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"PHP": 2,
+	"SGD": 2,
+	"THB": 2,
+	"USD": 2,
+	"VND": 0,
+}
+
+// Money is an exact amount in the minor unit of its ISO 4217 currency, e.g. 1250 SGD is S$12.50.
+type Money struct {
+	Amount   int64  `gorm:"column:amount" json:"amount"`
+	Currency string `gorm:"column:currency" json:"currency"`
+
+	// major is set when the amount was decoded from a bare number, see Resolve
+	major bool
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func IsSupported(currency string) bool {
+	_, ok := exponents[currency]
+	return ok
+}
+
+// Exponent returns the number of minor unit digits of currency.
+func Exponent(currency string) (int, error) {
+	exp, ok := exponents[currency]
+	if !ok {
+		return 0, ErrUnknownCurrency
+	}
+
+	return exp, nil
+}
+
+// FromMajor converts whole units, e.g. FromMajor(7000, "IDR") is 700000 minor units.
+func FromMajor(major int64, currency string) (Money, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(major*pow10(exp), currency), nil
+}
+
+// Parse reads a decimal amount such as "12.50" without going through floats.
+func Parse(amount, currency string) (Money, error) {
+	exp, err := Exponent(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return Money{}, ErrInvalidAmount
+	}
+
+	r.Mul(r, new(big.Rat).SetInt64(pow10(exp)))
+	if !r.IsInt() {
+		return Money{}, fmt.Errorf("%w: %s has more than %d decimals", ErrInvalidAmount, amount, exp)
+	}
+
+	if !r.Num().IsInt64() {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return New(r.Num().Int64(), currency), nil
+}
+
+// UnmarshalJSON accepts {"amount": 1250, "currency": "SGD"} and, for clients that predate currencies,
+// a bare number of whole units in a currency that is filled in later by Resolve.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		major, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidAmount, data)
+		}
+
+		*m = Money{Amount: major, major: true}
+		return nil
+	}
+
+	type plain Money
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	*m = Money(p)
+	return nil
+}
+
+// Resolve fills in currency when the money was sent without one. A bare number counts whole units,
+// an object without currency counts minor units.
+func (m Money) Resolve(currency string) (Money, error) {
+	if m.Currency != "" {
+		if !IsSupported(m.Currency) {
+			return Money{}, ErrUnknownCurrency
+		}
+
+		return New(m.Amount, m.Currency), nil
+	}
+
+	if m.major {
+		return FromMajor(m.Amount, currency)
+	}
+
+	if !IsSupported(currency) {
+		return Money{}, ErrUnknownCurrency
+	}
+
+	return New(m.Amount, currency), nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) Equal(o Money) bool {
+	return m.Amount == o.Amount && m.Currency == o.Currency
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	return New(m.Amount+o.Amount, m.Currency), nil
+}
+
+// Mul multiplies by a whole quantity, e.g. a unit price by the number of copies.
+func (m Money) Mul(quantity int64) Money {
+	return New(m.Amount*quantity, m.Currency)
+}
+
+// Convert applies rate, the value of one major unit of m.Currency in major units of to,
+// and rounds half away from zero to the minor unit of to.
+func (m Money) Convert(to string, rate *big.Rat) (Money, error) {
+	if m.Currency == to {
+		return m, nil
+	}
+
+	from, err := Exponent(m.Currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	exp, err := Exponent(to)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r := new(big.Rat).SetInt64(m.Amount)
+	r.Mul(r, rate)
+	r.Mul(r, new(big.Rat).SetFrac(big.NewInt(pow10(exp)), big.NewInt(pow10(from))))
+
+	amount, err := round(r)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return New(amount, to), nil
+}
+
+// String renders the plain decimal amount, e.g. "SGD 12.50".
+func (m Money) String() string {
+	major, minor := m.split()
+	if minor == "" {
+		return m.Currency + " " + major
+	}
+
+	return m.Currency + " " + major + "." + minor
+}
+
+// Format groups thousands for display, e.g. "IDR 125,000.00".
+func (m Money) Format() string {
+	major, minor := m.split()
+
+	sign := ""
+	if strings.HasPrefix(major, "-") {
+		sign, major = "-", major[1:]
+	}
+
+	var out []byte
+	for i := range major {
+		if i > 0 && (len(major)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, major[i])
+	}
+
+	s := m.Currency + " " + sign + string(out)
+	if minor != "" {
+		s += "." + minor
+	}
+
+	return s
+}
+
+func (m Money) split() (string, string) {
+	exp := exponents[m.Currency]
+
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits, ""
+	}
+
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-exp], digits[len(digits)-exp:]
+}
+
+func round(r *big.Rat) (int64, error) {
+	num, den := new(big.Int).Set(r.Num()), r.Denom()
+
+	neg := num.Sign() < 0
+	num.Abs(num)
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+
+	if neg {
+		q.Neg(q)
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+
+	return q.Int64(), nil
+}
+
+func pow10(exp int) int64 {
+	n := int64(1)
+	for i := 0; i < exp; i++ {
+		n *= 10
+	}
+
+	return n
+}
@@ -0,0 +1,78 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMoney(t *testing.T) {
+	Convey("Test money", t, func() {
+		Convey("parses decimals exactly", func() {
+			m, err := Parse("12.5", "SGD")
+			So(err, ShouldBeNil)
+			So(m, ShouldResemble, New(1250, "SGD"))
+
+			_, err = Parse("12.505", "SGD")
+			So(err, ShouldNotBeNil)
+
+			_, err = Parse("1", "XXX")
+			So(err, ShouldEqual, ErrUnknownCurrency)
+		})
+
+		Convey("formats minor units", func() {
+			So(New(1250, "SGD").String(), ShouldEqual, "SGD 12.50")
+			So(New(5, "SGD").String(), ShouldEqual, "SGD 0.05")
+			So(New(-1250, "SGD").String(), ShouldEqual, "SGD -12.50")
+			So(New(1500, "JPY").String(), ShouldEqual, "JPY 1500")
+			So(New(12500000, "IDR").Format(), ShouldEqual, "IDR 125,000.00")
+			So(New(-125000000, "IDR").Format(), ShouldEqual, "IDR -1,250,000.00")
+			So(New(0, "IDR").Format(), ShouldEqual, "IDR 0.00")
+		})
+
+		Convey("decodes objects and legacy bare numbers", func() {
+			var m Money
+			So(json.Unmarshal([]byte(`{"amount": 1250, "currency": "SGD"}`), &m), ShouldBeNil)
+			So(m, ShouldResemble, New(1250, "SGD"))
+
+			So(json.Unmarshal([]byte(`7000`), &m), ShouldBeNil)
+			resolved, err := m.Resolve("IDR")
+			So(err, ShouldBeNil)
+			So(resolved, ShouldResemble, New(700000, "IDR"))
+
+			So(json.Unmarshal([]byte(`{"amount": 1250}`), &m), ShouldBeNil)
+			resolved, err = m.Resolve("IDR")
+			So(err, ShouldBeNil)
+			So(resolved, ShouldResemble, New(1250, "IDR"))
+
+			So(json.Unmarshal([]byte(`12.5`), &m), ShouldNotBeNil)
+		})
+
+		Convey("converts across exponents and rounds half away from zero", func() {
+			// 1 SGD = 12100.5 IDR
+			m, err := New(1250, "SGD").Convert("IDR", big.NewRat(242010, 20))
+			So(err, ShouldBeNil)
+			So(m, ShouldResemble, New(15125625, "IDR"))
+
+			// 1 SGD = 110.55 JPY, 12.50 SGD is 1381.875 JPY
+			m, err = New(1250, "SGD").Convert("JPY", big.NewRat(11055, 100))
+			So(err, ShouldBeNil)
+			So(m, ShouldResemble, New(1382, "JPY"))
+
+			m, err = New(-1250, "SGD").Convert("JPY", big.NewRat(11055, 100))
+			So(err, ShouldBeNil)
+			So(m, ShouldResemble, New(-1382, "JPY"))
+		})
+
+		Convey("adds only the same currency", func() {
+			sum, err := New(100, "SGD").Add(New(250, "SGD"))
+			So(err, ShouldBeNil)
+			So(sum, ShouldResemble, New(350, "SGD"))
+
+			_, err = New(100, "SGD").Add(New(250, "IDR"))
+			So(err, ShouldEqual, ErrCurrencyMismatch)
+		})
+	})
+}
@@ -0,0 +1,148 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message is one notification, Fields carry the details for machine readers such as webhooks.
+type Message struct {
+	Subject string                 `json:"subject"`
+	Body    string                 `json:"body"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Multi delivers every message to all of its notifiers, one failing does not stop the others.
+type Multi []Notifier
+
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WebhookNotifier posts the message as JSON to url, any status outside 2xx is an error.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", n.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: unexpected status %d", n.url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// EmailNotifier sends the message as a plain text mail, it authenticates only when a username is set.
+type EmailNotifier struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailNotifier(cfg SMTPConfig) *EmailNotifier {
+	return &EmailNotifier{
+		cfg:  cfg,
+		send: smtp.SendMail,
+	}
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, msg Message) error {
+	if len(n.cfg.To) == 0 {
+		return errors.New("email notifier has no recipients")
+	}
+
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	var mail strings.Builder
+	fmt.Fprintf(&mail, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&mail, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&mail, "Subject: %s\r\n", msg.Subject)
+	mail.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	mail.WriteString(msg.Body)
+	mail.WriteString("\r\n")
+
+	addr := fmt.Sprintf("%s:%d", n.cfg.Host, n.cfg.Port)
+	if err := n.send(addr, auth, n.cfg.From, n.cfg.To, []byte(mail.String())); err != nil {
+		return fmt.Errorf("email %s: %w", addr, err)
+	}
+
+	return nil
+}
+
+// LogNotifier writes the message to a logger at info level, for local development.
+type LogNotifier struct {
+	logger *slog.Logger
+}
+
+func NewLogNotifier(logger *slog.Logger) *LogNotifier {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	attrs := []any{slog.String("body", msg.Body)}
+	for key, value := range msg.Fields {
+		attrs = append(attrs, slog.Any(key, value))
+	}
+
+	n.logger.InfoContext(ctx, msg.Subject, slog.Group("notification", attrs...))
+	return nil
+}
@@ -0,0 +1,106 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWebhookNotifier(t *testing.T) {
+	Convey("Test webhook notifier", t, func() {
+		ctx := context.Background()
+		msg := Message{Subject: "low stock", Body: "Dune has 2 left", Fields: map[string]interface{}{"book_id": 10}}
+
+		Convey("posts the message as json", func() {
+			var (
+				got         Message
+				contentType string
+			)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+				json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			err := NewWebhookNotifier(server.URL, time.Second).Notify(ctx, msg)
+			So(err, ShouldBeNil)
+			So(contentType, ShouldEqual, "application/json")
+			So(got.Subject, ShouldEqual, "low stock")
+			So(got.Fields["book_id"], ShouldEqual, float64(10))
+		})
+
+		Convey("resp err on a failed status", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadGateway)
+			}))
+			defer server.Close()
+
+			err := NewWebhookNotifier(server.URL, time.Second).Notify(ctx, msg)
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestEmailNotifier(t *testing.T) {
+	Convey("Test email notifier", t, func() {
+		ctx := context.Background()
+		n := NewEmailNotifier(SMTPConfig{Host: "mail.local", Port: 25, From: "stock@mail.local", To: []string{"buyer@mail.local"}})
+
+		Convey("sends a plain text mail", func() {
+			var sent []byte
+			n.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+				So(addr, ShouldEqual, "mail.local:25")
+				So(a, ShouldBeNil)
+				So(to, ShouldResemble, []string{"buyer@mail.local"})
+				sent = msg
+				return nil
+			}
+
+			err := n.Notify(ctx, Message{Subject: "low stock", Body: "Dune has 2 left"})
+			So(err, ShouldBeNil)
+			So(string(sent), ShouldContainSubstring, "Subject: low stock\r\n")
+			So(string(sent), ShouldEndWith, "Dune has 2 left\r\n")
+		})
+
+		Convey("resp err without recipients", func() {
+			n.cfg.To = nil
+
+			err := n.Notify(ctx, Message{Subject: "low stock"})
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+type failingNotifier struct{}
+
+func (failingNotifier) Notify(ctx context.Context, msg Message) error {
+	return errors.New("down")
+}
+
+func TestMulti(t *testing.T) {
+	Convey("Test multi notifier", t, func() {
+		var buf bytes.Buffer
+		multi := Multi{failingNotifier{}, NewLogNotifier(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		})))}
+
+		err := multi.Notify(context.Background(), Message{Subject: "low stock", Body: "Dune has 2 left"})
+		So(err, ShouldNotBeNil)
+		So(buf.String(), ShouldEqual, "level=INFO msg=\"low stock\" notification.body=\"Dune has 2 left\"\n")
+	})
+}
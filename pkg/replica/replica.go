@@ -0,0 +1,172 @@
+// Package replica is a gorm plugin that sends reads to read replicas. Writes, reads inside a
+// transaction, locking reads and sessions marked with Primary stay on the primary.
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	primaryKey  = "replica:primary"
+	connPoolKey = "replica:conn_pool"
+)
+
+// Replica is a read replica of the primary database.
+type Replica struct {
+	Name string
+	DB   *sql.DB
+
+	healthy atomic.Bool
+	checked bool
+}
+
+// Resolver picks the replica of every read round robin among the healthy ones, reads go to the
+// primary while no replica is healthy.
+type Resolver struct {
+	replicas []*Replica
+	interval time.Duration
+	next     atomic.Uint64
+	done     chan struct{}
+}
+
+// New checks the health of replicas every interval once the resolver is used by gorm.
+func New(interval time.Duration, replicas ...*Replica) *Resolver {
+	return &Resolver{
+		replicas: replicas,
+		interval: interval,
+		done:     make(chan struct{}),
+	}
+}
+
+func (r *Resolver) Name() string {
+	return "replica"
+}
+
+func (r *Resolver) Initialize(db *gorm.DB) error {
+	r.check(context.Background())
+	go r.watch()
+
+	if err := db.Callback().Query().Before("gorm:query").Register("replica:query", r.route); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Query().After("gorm:query").Register("replica:query_done", restore); err != nil {
+		return err
+	}
+
+	if err := db.Callback().Row().Before("gorm:row").Register("replica:row", r.route); err != nil {
+		return err
+	}
+
+	return db.Callback().Row().After("gorm:row").Register("replica:row_done", restore)
+}
+
+// Close stops the health checks, it does not close the replicas.
+func (r *Resolver) Close() {
+	close(r.done)
+}
+
+// Primary keeps the reads of db on the primary.
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Set(primaryKey, true)
+}
+
+func (r *Resolver) route(db *gorm.DB) {
+	if onPrimary(db) {
+		return
+	}
+
+	if replica := r.pick(); replica != nil {
+		db.InstanceSet(connPoolKey, db.Statement.ConnPool)
+		db.Statement.ConnPool = replica.DB
+	}
+}
+
+// restore puts the primary back, the statement may be used again to write.
+func restore(db *gorm.DB) {
+	if pool, ok := db.InstanceGet(connPoolKey); ok {
+		db.Statement.ConnPool = pool.(gorm.ConnPool)
+	}
+}
+
+func onPrimary(db *gorm.DB) bool {
+	if _, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return true
+	}
+
+	if _, ok := db.Get(primaryKey); ok {
+		return true
+	}
+
+	// SELECT ... FOR UPDATE
+	if _, ok := db.Statement.Clauses["FOR"]; ok {
+		return true
+	}
+
+	// raw statements are only routed when they read
+	if raw := strings.ToUpper(strings.TrimSpace(db.Statement.SQL.String())); raw != "" {
+		return !strings.HasPrefix(raw, "SELECT") && !strings.HasPrefix(raw, "WITH")
+	}
+
+	return false
+}
+
+func (r *Resolver) pick() *Replica {
+	n := uint64(len(r.replicas))
+	if n == 0 {
+		return nil
+	}
+
+	start := r.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if replica := r.replicas[(start+i)%n]; replica.healthy.Load() {
+			return replica
+		}
+	}
+
+	return nil
+}
+
+func (r *Resolver) watch() {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			r.check(context.Background())
+		}
+	}
+}
+
+// check pings every replica, a replica that does not answer within the interval is unhealthy.
+func (r *Resolver) check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		pingCtx, cancel := context.WithTimeout(ctx, r.interval)
+		err := replica.DB.PingContext(pingCtx)
+		cancel()
+
+		healthy := err == nil
+		if replica.healthy.Swap(healthy) == healthy && replica.checked {
+			continue
+		}
+
+		replica.checked = true
+
+		logger := slog.With("component", "replica", "replica", replica.Name)
+		if healthy {
+			logger.Info("replica is healthy, reads go to it")
+		} else {
+			logger.Warn("replica is unhealthy, reads fail over", "error", err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package replica
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type book struct {
+	ID    int
+	Title string
+}
+
+// open returns a database holding one book titled title.
+func open(t *testing.T, title string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), title+".db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.AutoMigrate(&book{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Create(&book{Title: title}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestResolver(t *testing.T) {
+	Convey("Test replica resolver", t, func() {
+		db, replicaDB := open(t, "primary"), open(t, "replica")
+
+		pool, err := replicaDB.DB()
+		So(err, ShouldBeNil)
+
+		resolver := New(time.Hour, &Replica{Name: "replica", DB: pool})
+		defer resolver.Close()
+
+		So(db.Use(resolver), ShouldBeNil)
+
+		title := func(db *gorm.DB) string {
+			var found book
+			So(db.First(&found).Error, ShouldBeNil)
+			return found.Title
+		}
+
+		Convey("reads from the replica", func() {
+			So(title(db), ShouldEqual, "replica")
+
+			var titles []string
+			So(db.Raw("SELECT title FROM books").Scan(&titles).Error, ShouldBeNil)
+			So(titles, ShouldResemble, []string{"replica"})
+		})
+
+		Convey("writes to the primary", func() {
+			So(db.Create(&book{Title: "new"}).Error, ShouldBeNil)
+
+			var count int64
+			So(Primary(db).Model(&book{}).Count(&count).Error, ShouldBeNil)
+			So(count, ShouldEqual, 2)
+
+			So(db.Model(&book{}).Count(&count).Error, ShouldBeNil)
+			So(count, ShouldEqual, 1)
+		})
+
+		Convey("keeps marked sessions and transactions on the primary", func() {
+			So(title(Primary(db)), ShouldEqual, "primary")
+
+			So(db.Transaction(func(tx *gorm.DB) error {
+				So(title(tx), ShouldEqual, "primary")
+				return nil
+			}), ShouldBeNil)
+		})
+
+		Convey("fails over to the primary while the replica is unhealthy", func() {
+			So(pool.Close(), ShouldBeNil)
+			resolver.check(context.Background())
+
+			So(title(db), ShouldEqual, "primary")
+		})
+	})
+}
@@ -3,14 +3,46 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/identifier"
+	"github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
 )
 
 var validate *validator.Validate
 
 func init() {
 	validate = validator.New()
+	validate.RegisterValidation("notEmpty", notEmpty)
+	validate.RegisterValidation("isbn", isbn)
+	validate.RegisterValidation("currency", currency)
+	validate.RegisterStructValidation(moneyValidation, money.Money{})
+}
+
+func notEmpty(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
+
+func isbn(fl validator.FieldLevel) bool {
+	return identifier.IsValidISBN(fl.Field().String())
+}
+
+func currency(fl validator.FieldLevel) bool {
+	return money.IsSupported(fl.Field().String())
+}
+
+// moneyValidation requires a positive amount, the currency may be left out for the default one.
+func moneyValidation(sl validator.StructLevel) {
+	m := sl.Current().Interface().(money.Money)
+
+	if m.Amount <= 0 {
+		sl.ReportError(m.Amount, "amount", "Amount", "gt", "0")
+	}
+
+	if m.Currency != "" && !money.IsSupported(m.Currency) {
+		sl.ReportError(m.Currency, "currency", "Currency", "currency", "")
+	}
 }
 
 func ValidateStruct(obj interface{}) error {
@@ -28,6 +60,9 @@ func customError(err error) error {
 			case "email":
 				return NewValidationError(fmt.Sprintf("%s is not valid email",
 					err.Field()))
+			case "isbn":
+				return NewValidationError(fmt.Sprintf("%s is not valid isbn",
+					err.Field()))
 			case "unique":
 				return NewValidationError(fmt.Sprintf("%s must unique value",
 					err.Field()))
@@ -38,6 +73,16 @@ func customError(err error) error {
 				return NewValidationError(fmt.Sprintf("%s value must be lower than %s", err.Field(), err.Param()))
 			case "min":
 				return NewValidationError(fmt.Sprintf("%s value must be grather than %s", err.Field(), err.Param()))
+			case "gt":
+				return NewValidationError(fmt.Sprintf("%s value must be greater than %s", err.Field(), err.Param()))
+			case "required_without":
+				return NewValidationError(fmt.Sprintf("%s is required when %s is empty", err.Field(), err.Param()))
+			case "currency":
+				return NewValidationError(fmt.Sprintf("%s is not a supported currency", err.Field()))
+			case "oneof":
+				return NewValidationError(fmt.Sprintf("%s must be one of %s", err.Field(), err.Param()))
+			case "ltfield":
+				return NewValidationError(fmt.Sprintf("%s must be lower than %s", err.Field(), err.Param()))
 			default:
 				return NewValidationError(fmt.Sprintf("%s validation error on %s tag", err.Field(), err.ActualTag()))
 			}
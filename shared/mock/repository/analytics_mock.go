@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/analytics.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/analytics.go -destination=./shared/mock/repository/analytics_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAnalyticsRepositoryImpl is a mock of AnalyticsRepositoryImpl interface.
+type MockAnalyticsRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockAnalyticsRepositoryImplMockRecorder is the mock recorder for MockAnalyticsRepositoryImpl.
+type MockAnalyticsRepositoryImplMockRecorder struct {
+	mock *MockAnalyticsRepositoryImpl
+}
+
+// NewMockAnalyticsRepositoryImpl creates a new mock instance.
+func NewMockAnalyticsRepositoryImpl(ctrl *gomock.Controller) *MockAnalyticsRepositoryImpl {
+	mock := &MockAnalyticsRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsRepositoryImpl) EXPECT() *MockAnalyticsRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// DaysOnHand mocks base method.
+func (m *MockAnalyticsRepositoryImpl) DaysOnHand(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DaysOnHand", ctx, from, to, limit)
+	ret0, _ := ret[0].([]*domain.BookStockSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DaysOnHand indicates an expected call of DaysOnHand.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) DaysOnHand(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DaysOnHand", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).DaysOnHand), ctx, from, to, limit)
+}
+
+// ListAuthorRevenue mocks base method.
+func (m *MockAnalyticsRepositoryImpl) ListAuthorRevenue(ctx context.Context, from, to time.Time, bucket string, authorID int) ([]*domain.AuthorRevenueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthorRevenue", ctx, from, to, bucket, authorID)
+	ret0, _ := ret[0].([]*domain.AuthorRevenueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthorRevenue indicates an expected call of ListAuthorRevenue.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) ListAuthorRevenue(ctx, from, to, bucket, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthorRevenue", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).ListAuthorRevenue), ctx, from, to, bucket, authorID)
+}
+
+// ListSales mocks base method.
+func (m *MockAnalyticsRepositoryImpl) ListSales(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSales", ctx, from, to, bookID)
+	ret0, _ := ret[0].([]*domain.DailyQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSales indicates an expected call of ListSales.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) ListSales(ctx, from, to, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSales", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).ListSales), ctx, from, to, bookID)
+}
+
+// ListStockChanges mocks base method.
+func (m *MockAnalyticsRepositoryImpl) ListStockChanges(ctx context.Context, from, to time.Time, bookID int) ([]*domain.DailyQuantity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStockChanges", ctx, from, to, bookID)
+	ret0, _ := ret[0].([]*domain.DailyQuantity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStockChanges indicates an expected call of ListStockChanges.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) ListStockChanges(ctx, from, to, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockChanges", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).ListStockChanges), ctx, from, to, bookID)
+}
+
+// LockRefresh mocks base method.
+func (m *MockAnalyticsRepositoryImpl) LockRefresh(ctx context.Context, name string) (*domain.AnalyticsRefresh, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockRefresh", ctx, name)
+	ret0, _ := ret[0].(*domain.AnalyticsRefresh)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockRefresh indicates an expected call of LockRefresh.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) LockRefresh(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockRefresh", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).LockRefresh), ctx, name)
+}
+
+// OpeningStock mocks base method.
+func (m *MockAnalyticsRepositoryImpl) OpeningStock(ctx context.Context, day time.Time, bookID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpeningStock", ctx, day, bookID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpeningStock indicates an expected call of OpeningStock.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) OpeningStock(ctx, day, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpeningStock", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).OpeningStock), ctx, day, bookID)
+}
+
+// RefreshSales mocks base method.
+func (m *MockAnalyticsRepositoryImpl) RefreshSales(ctx context.Context, from time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshSales", ctx, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshSales indicates an expected call of RefreshSales.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) RefreshSales(ctx, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSales", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).RefreshSales), ctx, from)
+}
+
+// RefreshStock mocks base method.
+func (m *MockAnalyticsRepositoryImpl) RefreshStock(ctx context.Context, from time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshStock", ctx, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshStock indicates an expected call of RefreshStock.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) RefreshStock(ctx, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshStock", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).RefreshStock), ctx, from)
+}
+
+// SetRefreshed mocks base method.
+func (m *MockAnalyticsRepositoryImpl) SetRefreshed(ctx context.Context, refresh *domain.AnalyticsRefresh) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRefreshed", ctx, refresh)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRefreshed indicates an expected call of SetRefreshed.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) SetRefreshed(ctx, refresh any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRefreshed", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).SetRefreshed), ctx, refresh)
+}
+
+// SlowMovers mocks base method.
+func (m *MockAnalyticsRepositoryImpl) SlowMovers(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookStockSales, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SlowMovers", ctx, from, to, limit)
+	ret0, _ := ret[0].([]*domain.BookStockSales)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SlowMovers indicates an expected call of SlowMovers.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) SlowMovers(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SlowMovers", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).SlowMovers), ctx, from, to, limit)
+}
+
+// TopSelling mocks base method.
+func (m *MockAnalyticsRepositoryImpl) TopSelling(ctx context.Context, from, to time.Time, limit int) ([]*domain.BookRevenueRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopSelling", ctx, from, to, limit)
+	ret0, _ := ret[0].([]*domain.BookRevenueRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopSelling indicates an expected call of TopSelling.
+func (mr *MockAnalyticsRepositoryImplMockRecorder) TopSelling(ctx, from, to, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopSelling", reflect.TypeOf((*MockAnalyticsRepositoryImpl)(nil).TopSelling), ctx, from, to, limit)
+}
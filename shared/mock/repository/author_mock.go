@@ -70,6 +70,21 @@ func (mr *MockAuthorRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAuthorRepositoryImpl)(nil).GetByID), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockAuthorRepositoryImpl) GetByIDs(ctx context.Context, ids []int) ([]*domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAuthorRepositoryImplMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAuthorRepositoryImpl)(nil).GetByIDs), ctx, ids)
+}
+
 // GetByName mocks base method.
 func (m *MockAuthorRepositoryImpl) GetByName(ctx context.Context, name string) (*domain.Author, error) {
 	m.ctrl.T.Helper()
@@ -84,3 +99,22 @@ func (mr *MockAuthorRepositoryImplMockRecorder) GetByName(ctx, name any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockAuthorRepositoryImpl)(nil).GetByName), ctx, name)
 }
+
+// Update mocks base method.
+func (m *MockAuthorRepositoryImpl) Update(ctx context.Context, req *domain.Author, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAuthorRepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorRepositoryImpl)(nil).Update), varargs...)
+}
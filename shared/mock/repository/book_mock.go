@@ -83,6 +83,21 @@ func (mr *MockBookRepositoryImplMockRecorder) DeleteBookByAuthorID(ctx, authorID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBookByAuthorID", reflect.TypeOf((*MockBookRepositoryImpl)(nil).DeleteBookByAuthorID), ctx, authorID, bookID)
 }
 
+// GetByBarcode mocks base method.
+func (m *MockBookRepositoryImpl) GetByBarcode(ctx context.Context, barcode string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByBarcode", ctx, barcode)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByBarcode indicates an expected call of GetByBarcode.
+func (mr *MockBookRepositoryImplMockRecorder) GetByBarcode(ctx, barcode any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByBarcode", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetByBarcode), ctx, barcode)
+}
+
 // GetByID mocks base method.
 func (m *MockBookRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -98,6 +113,66 @@ func (mr *MockBookRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetByID), ctx, id)
 }
 
+// GetByIDs mocks base method.
+func (m *MockBookRepositoryImpl) GetByIDs(ctx context.Context, ids []int) ([]*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockBookRepositoryImplMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetByIDs), ctx, ids)
+}
+
+// GetByISBN mocks base method.
+func (m *MockBookRepositoryImpl) GetByISBN(ctx context.Context, isbn13 string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByISBN", ctx, isbn13)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByISBN indicates an expected call of GetByISBN.
+func (mr *MockBookRepositoryImplMockRecorder) GetByISBN(ctx, isbn13 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByISBN", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetByISBN), ctx, isbn13)
+}
+
+// GetByLocation mocks base method.
+func (m *MockBookRepositoryImpl) GetByLocation(ctx context.Context, location string) ([]*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByLocation", ctx, location)
+	ret0, _ := ret[0].([]*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByLocation indicates an expected call of GetByLocation.
+func (mr *MockBookRepositoryImplMockRecorder) GetByLocation(ctx, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByLocation", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetByLocation), ctx, location)
+}
+
+// GetBySKU mocks base method.
+func (m *MockBookRepositoryImpl) GetBySKU(ctx context.Context, sku string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySKU", ctx, sku)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySKU indicates an expected call of GetBySKU.
+func (mr *MockBookRepositoryImplMockRecorder) GetBySKU(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySKU", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetBySKU), ctx, sku)
+}
+
 // GetLastBook mocks base method.
 func (m *MockBookRepositoryImpl) GetLastBook(ctx context.Context) (*domain.Book, error) {
 	m.ctrl.T.Helper()
@@ -128,16 +203,93 @@ func (mr *MockBookRepositoryImplMockRecorder) GetListBookByAuthorID(ctx, authorI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListBookByAuthorID", reflect.TypeOf((*MockBookRepositoryImpl)(nil).GetListBookByAuthorID), ctx, authorID)
 }
 
+// List mocks base method.
+func (m *MockBookRepositoryImpl) List(ctx context.Context, filter *domain.BookFilter) ([]*domain.Book, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.Book)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockBookRepositoryImplMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBookRepositoryImpl)(nil).List), ctx, filter)
+}
+
+// ReplaceCategories mocks base method.
+func (m *MockBookRepositoryImpl) ReplaceCategories(ctx context.Context, bookID int, categories []*domain.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCategories", ctx, bookID, categories)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCategories indicates an expected call of ReplaceCategories.
+func (mr *MockBookRepositoryImplMockRecorder) ReplaceCategories(ctx, bookID, categories any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCategories", reflect.TypeOf((*MockBookRepositoryImpl)(nil).ReplaceCategories), ctx, bookID, categories)
+}
+
+// ReplaceContributors mocks base method.
+func (m *MockBookRepositoryImpl) ReplaceContributors(ctx context.Context, bookID int, contributors []*domain.BookContributor) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceContributors", ctx, bookID, contributors)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceContributors indicates an expected call of ReplaceContributors.
+func (mr *MockBookRepositoryImplMockRecorder) ReplaceContributors(ctx, bookID, contributors any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceContributors", reflect.TypeOf((*MockBookRepositoryImpl)(nil).ReplaceContributors), ctx, bookID, contributors)
+}
+
+// ReplacePrices mocks base method.
+func (m *MockBookRepositoryImpl) ReplacePrices(ctx context.Context, bookID int, prices []*domain.BookPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplacePrices", ctx, bookID, prices)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplacePrices indicates an expected call of ReplacePrices.
+func (mr *MockBookRepositoryImplMockRecorder) ReplacePrices(ctx, bookID, prices any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplacePrices", reflect.TypeOf((*MockBookRepositoryImpl)(nil).ReplacePrices), ctx, bookID, prices)
+}
+
+// ReplaceTags mocks base method.
+func (m *MockBookRepositoryImpl) ReplaceTags(ctx context.Context, bookID int, tags []*domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceTags", ctx, bookID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceTags indicates an expected call of ReplaceTags.
+func (mr *MockBookRepositoryImplMockRecorder) ReplaceTags(ctx, bookID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceTags", reflect.TypeOf((*MockBookRepositoryImpl)(nil).ReplaceTags), ctx, bookID, tags)
+}
+
 // Update mocks base method.
-func (m *MockBookRepositoryImpl) Update(ctx context.Context, req *domain.Book) error {
+func (m *MockBookRepositoryImpl) Update(ctx context.Context, req *domain.Book, columns ...string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, req)
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockBookRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+func (mr *MockBookRepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepositoryImpl)(nil).Update), ctx, req)
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepositoryImpl)(nil).Update), varargs...)
 }
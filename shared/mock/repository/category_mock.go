@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/category.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/category.go -destination=./shared/mock/repository/category_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCategoryRepositoryImpl is a mock of CategoryRepositoryImpl interface.
+type MockCategoryRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockCategoryRepositoryImplMockRecorder is the mock recorder for MockCategoryRepositoryImpl.
+type MockCategoryRepositoryImplMockRecorder struct {
+	mock *MockCategoryRepositoryImpl
+}
+
+// NewMockCategoryRepositoryImpl creates a new mock instance.
+func NewMockCategoryRepositoryImpl(ctrl *gomock.Controller) *MockCategoryRepositoryImpl {
+	mock := &MockCategoryRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockCategoryRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategoryRepositoryImpl) EXPECT() *MockCategoryRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCategoryRepositoryImpl) Create(ctx context.Context, req *domain.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCategoryRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockCategoryRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// GetBookIDs mocks base method.
+func (m *MockCategoryRepositoryImpl) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookIDs", ctx, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookIDs indicates an expected call of GetBookIDs.
+func (mr *MockCategoryRepositoryImplMockRecorder) GetBookIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookIDs", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).GetBookIDs), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockCategoryRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCategoryRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByIDs mocks base method.
+func (m *MockCategoryRepositoryImpl) GetByIDs(ctx context.Context, ids []int) ([]*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockCategoryRepositoryImplMockRecorder) GetByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).GetByIDs), ctx, ids)
+}
+
+// GetByName mocks base method.
+func (m *MockCategoryRepositoryImpl) GetByName(ctx context.Context, parentID *int, name string) (*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, parentID, name)
+	ret0, _ := ret[0].(*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockCategoryRepositoryImplMockRecorder) GetByName(ctx, parentID, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).GetByName), ctx, parentID, name)
+}
+
+// GetDescendantIDs mocks base method.
+func (m *MockCategoryRepositoryImpl) GetDescendantIDs(ctx context.Context, id int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDescendantIDs", ctx, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDescendantIDs indicates an expected call of GetDescendantIDs.
+func (mr *MockCategoryRepositoryImplMockRecorder) GetDescendantIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDescendantIDs", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).GetDescendantIDs), ctx, id)
+}
+
+// HasChildren mocks base method.
+func (m *MockCategoryRepositoryImpl) HasChildren(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasChildren", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasChildren indicates an expected call of HasChildren.
+func (mr *MockCategoryRepositoryImplMockRecorder) HasChildren(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasChildren", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).HasChildren), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCategoryRepositoryImpl) List(ctx context.Context) ([]*domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCategoryRepositoryImplMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockCategoryRepositoryImpl) Update(ctx context.Context, req *domain.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategoryRepositoryImpl)(nil).Update), ctx, req)
+}
@@ -0,0 +1,163 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cycle_count.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cycle_count.go -destination=./shared/mock/repository/cycle_count_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCycleCountRepositoryImpl is a mock of CycleCountRepositoryImpl interface.
+type MockCycleCountRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockCycleCountRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockCycleCountRepositoryImplMockRecorder is the mock recorder for MockCycleCountRepositoryImpl.
+type MockCycleCountRepositoryImplMockRecorder struct {
+	mock *MockCycleCountRepositoryImpl
+}
+
+// NewMockCycleCountRepositoryImpl creates a new mock instance.
+func NewMockCycleCountRepositoryImpl(ctrl *gomock.Controller) *MockCycleCountRepositoryImpl {
+	mock := &MockCycleCountRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockCycleCountRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCycleCountRepositoryImpl) EXPECT() *MockCycleCountRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// CloseLines mocks base method.
+func (m *MockCycleCountRepositoryImpl) CloseLines(ctx context.Context, cycleCountID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseLines", ctx, cycleCountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseLines indicates an expected call of CloseLines.
+func (mr *MockCycleCountRepositoryImplMockRecorder) CloseLines(ctx, cycleCountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseLines", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).CloseLines), ctx, cycleCountID)
+}
+
+// Create mocks base method.
+func (m *MockCycleCountRepositoryImpl) Create(ctx context.Context, req *domain.CycleCount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCycleCountRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// GetByID mocks base method.
+func (m *MockCycleCountRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCycleCountRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockCycleCountRepositoryImpl) GetByIDForUpdate(ctx context.Context, id int) (*domain.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockCycleCountRepositoryImplMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockCycleCountRepositoryImpl) List(ctx context.Context, filter *domain.CycleCountFilter) ([]*domain.CycleCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.CycleCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCycleCountRepositoryImplMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).List), ctx, filter)
+}
+
+// OpenBookIDs mocks base method.
+func (m *MockCycleCountRepositoryImpl) OpenBookIDs(ctx context.Context, bookIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenBookIDs", ctx, bookIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenBookIDs indicates an expected call of OpenBookIDs.
+func (mr *MockCycleCountRepositoryImplMockRecorder) OpenBookIDs(ctx, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenBookIDs", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).OpenBookIDs), ctx, bookIDs)
+}
+
+// Update mocks base method.
+func (m *MockCycleCountRepositoryImpl) Update(ctx context.Context, req *domain.CycleCount, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCycleCountRepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).Update), varargs...)
+}
+
+// UpdateLineCount mocks base method.
+func (m *MockCycleCountRepositoryImpl) UpdateLineCount(ctx context.Context, line *domain.CycleCountLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLineCount", ctx, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLineCount indicates an expected call of UpdateLineCount.
+func (mr *MockCycleCountRepositoryImplMockRecorder) UpdateLineCount(ctx, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLineCount", reflect.TypeOf((*MockCycleCountRepositoryImpl)(nil).UpdateLineCount), ctx, line)
+}
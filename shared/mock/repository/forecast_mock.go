@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/forecast.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/forecast.go -destination=./shared/mock/repository/forecast_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockForecastRepositoryImpl is a mock of ForecastRepositoryImpl interface.
+type MockForecastRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockForecastRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockForecastRepositoryImplMockRecorder is the mock recorder for MockForecastRepositoryImpl.
+type MockForecastRepositoryImplMockRecorder struct {
+	mock *MockForecastRepositoryImpl
+}
+
+// NewMockForecastRepositoryImpl creates a new mock instance.
+func NewMockForecastRepositoryImpl(ctrl *gomock.Controller) *MockForecastRepositoryImpl {
+	mock := &MockForecastRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockForecastRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockForecastRepositoryImpl) EXPECT() *MockForecastRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// ListDemand mocks base method.
+func (m *MockForecastRepositoryImpl) ListDemand(ctx context.Context, from, to time.Time, bookID int) ([]*domain.BookDemand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDemand", ctx, from, to, bookID)
+	ret0, _ := ret[0].([]*domain.BookDemand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDemand indicates an expected call of ListDemand.
+func (mr *MockForecastRepositoryImplMockRecorder) ListDemand(ctx, from, to, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDemand", reflect.TypeOf((*MockForecastRepositoryImpl)(nil).ListDemand), ctx, from, to, bookID)
+}
+
+// ListOnOrder mocks base method.
+func (m *MockForecastRepositoryImpl) ListOnOrder(ctx context.Context, bookIDs []int) ([]*domain.BookOnOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOnOrder", ctx, bookIDs)
+	ret0, _ := ret[0].([]*domain.BookOnOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOnOrder indicates an expected call of ListOnOrder.
+func (mr *MockForecastRepositoryImplMockRecorder) ListOnOrder(ctx, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOnOrder", reflect.TypeOf((*MockForecastRepositoryImpl)(nil).ListOnOrder), ctx, bookIDs)
+}
+
+// ListSuppliers mocks base method.
+func (m *MockForecastRepositoryImpl) ListSuppliers(ctx context.Context, bookIDs []int) ([]*domain.BookSupplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppliers", ctx, bookIDs)
+	ret0, _ := ret[0].([]*domain.BookSupplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockForecastRepositoryImplMockRecorder) ListSuppliers(ctx, bookIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockForecastRepositoryImpl)(nil).ListSuppliers), ctx, bookIDs)
+}
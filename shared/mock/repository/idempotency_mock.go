@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/idempotency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/idempotency.go -destination=./shared/mock/repository/idempotency_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdempotencyRepositoryImpl is a mock of IdempotencyRepositoryImpl interface.
+type MockIdempotencyRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyRepositoryImplMockRecorder is the mock recorder for MockIdempotencyRepositoryImpl.
+type MockIdempotencyRepositoryImplMockRecorder struct {
+	mock *MockIdempotencyRepositoryImpl
+}
+
+// NewMockIdempotencyRepositoryImpl creates a new mock instance.
+func NewMockIdempotencyRepositoryImpl(ctrl *gomock.Controller) *MockIdempotencyRepositoryImpl {
+	mock := &MockIdempotencyRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyRepositoryImpl) EXPECT() *MockIdempotencyRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIdempotencyRepositoryImpl) Create(ctx context.Context, req *domain.IdempotencyKey) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIdempotencyRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIdempotencyRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockIdempotencyRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIdempotencyRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIdempotencyRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockIdempotencyRepositoryImpl) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockIdempotencyRepositoryImplMockRecorder) DeleteExpired(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockIdempotencyRepositoryImpl)(nil).DeleteExpired), ctx, now)
+}
+
+// GetByKey mocks base method.
+func (m *MockIdempotencyRepositoryImpl) GetByKey(ctx context.Context, userID int, key string) (*domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByKey", ctx, userID, key)
+	ret0, _ := ret[0].(*domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByKey indicates an expected call of GetByKey.
+func (mr *MockIdempotencyRepositoryImplMockRecorder) GetByKey(ctx, userID, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByKey", reflect.TypeOf((*MockIdempotencyRepositoryImpl)(nil).GetByKey), ctx, userID, key)
+}
+
+// UpdateResponse mocks base method.
+func (m *MockIdempotencyRepositoryImpl) UpdateResponse(ctx context.Context, req *domain.IdempotencyKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateResponse", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateResponse indicates an expected call of UpdateResponse.
+func (mr *MockIdempotencyRepositoryImplMockRecorder) UpdateResponse(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResponse", reflect.TypeOf((*MockIdempotencyRepositoryImpl)(nil).UpdateResponse), ctx, req)
+}
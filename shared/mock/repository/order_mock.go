@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/order.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/order.go -destination=./shared/mock/repository/order_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderRepositoryImpl is a mock of OrderRepositoryImpl interface.
+type MockOrderRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryImplMockRecorder is the mock recorder for MockOrderRepositoryImpl.
+type MockOrderRepositoryImplMockRecorder struct {
+	mock *MockOrderRepositoryImpl
+}
+
+// NewMockOrderRepositoryImpl creates a new mock instance.
+func NewMockOrderRepositoryImpl(ctrl *gomock.Controller) *MockOrderRepositoryImpl {
+	mock := &MockOrderRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepositoryImpl) EXPECT() *MockOrderRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderRepositoryImpl) Create(ctx context.Context, req *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockOrderRepositoryImpl) GetByIDForUpdate(ctx context.Context, id int) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockOrderRepositoryImplMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockOrderRepositoryImpl)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockOrderRepositoryImpl) List(ctx context.Context, filter *domain.OrderFilter) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrderRepositoryImplMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderRepositoryImpl)(nil).List), ctx, filter)
+}
+
+// Update mocks base method.
+func (m *MockOrderRepositoryImpl) Update(ctx context.Context, req *domain.Order, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockOrderRepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockOrderRepositoryImpl)(nil).Update), varargs...)
+}
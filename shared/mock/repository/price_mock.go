@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/price.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/price.go -destination=./shared/mock/repository/price_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPriceRepositoryImpl is a mock of PriceRepositoryImpl interface.
+type MockPriceRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockPriceRepositoryImplMockRecorder is the mock recorder for MockPriceRepositoryImpl.
+type MockPriceRepositoryImplMockRecorder struct {
+	mock *MockPriceRepositoryImpl
+}
+
+// NewMockPriceRepositoryImpl creates a new mock instance.
+func NewMockPriceRepositoryImpl(ctrl *gomock.Controller) *MockPriceRepositoryImpl {
+	mock := &MockPriceRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockPriceRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceRepositoryImpl) EXPECT() *MockPriceRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// ClaimDueSchedule mocks base method.
+func (m *MockPriceRepositoryImpl) ClaimDueSchedule(ctx context.Context, now time.Time) (*domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueSchedule", ctx, now)
+	ret0, _ := ret[0].(*domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDueSchedule indicates an expected call of ClaimDueSchedule.
+func (mr *MockPriceRepositoryImplMockRecorder) ClaimDueSchedule(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueSchedule", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).ClaimDueSchedule), ctx, now)
+}
+
+// CreateHistory mocks base method.
+func (m *MockPriceRepositoryImpl) CreateHistory(ctx context.Context, changes []*domain.PriceChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHistory", ctx, changes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateHistory indicates an expected call of CreateHistory.
+func (mr *MockPriceRepositoryImplMockRecorder) CreateHistory(ctx, changes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHistory", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).CreateHistory), ctx, changes)
+}
+
+// CreateSchedule mocks base method.
+func (m *MockPriceRepositoryImpl) CreateSchedule(ctx context.Context, req *domain.ScheduledPrice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSchedule", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSchedule indicates an expected call of CreateSchedule.
+func (mr *MockPriceRepositoryImplMockRecorder) CreateSchedule(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSchedule", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).CreateSchedule), ctx, req)
+}
+
+// FailSchedule mocks base method.
+func (m *MockPriceRepositoryImpl) FailSchedule(ctx context.Context, id int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailSchedule", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailSchedule indicates an expected call of FailSchedule.
+func (mr *MockPriceRepositoryImplMockRecorder) FailSchedule(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailSchedule", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).FailSchedule), ctx, id, reason)
+}
+
+// GetHistory mocks base method.
+func (m *MockPriceRepositoryImpl) GetHistory(ctx context.Context, bookID int) ([]*domain.PriceChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", ctx, bookID)
+	ret0, _ := ret[0].([]*domain.PriceChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockPriceRepositoryImplMockRecorder) GetHistory(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).GetHistory), ctx, bookID)
+}
+
+// GetSchedule mocks base method.
+func (m *MockPriceRepositoryImpl) GetSchedule(ctx context.Context, id int) (*domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedule", ctx, id)
+	ret0, _ := ret[0].(*domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedule indicates an expected call of GetSchedule.
+func (mr *MockPriceRepositoryImplMockRecorder) GetSchedule(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedule", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).GetSchedule), ctx, id)
+}
+
+// GetSchedules mocks base method.
+func (m *MockPriceRepositoryImpl) GetSchedules(ctx context.Context, bookID int) ([]*domain.ScheduledPrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchedules", ctx, bookID)
+	ret0, _ := ret[0].([]*domain.ScheduledPrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSchedules indicates an expected call of GetSchedules.
+func (mr *MockPriceRepositoryImplMockRecorder) GetSchedules(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchedules", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).GetSchedules), ctx, bookID)
+}
+
+// UpdateSchedule mocks base method.
+func (m *MockPriceRepositoryImpl) UpdateSchedule(ctx context.Context, req *domain.ScheduledPrice, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateSchedule", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSchedule indicates an expected call of UpdateSchedule.
+func (mr *MockPriceRepositoryImplMockRecorder) UpdateSchedule(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchedule", reflect.TypeOf((*MockPriceRepositoryImpl)(nil).UpdateSchedule), varargs...)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/promotion.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/promotion.go -destination=./shared/mock/repository/promotion_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPromotionRepositoryImpl is a mock of PromotionRepositoryImpl interface.
+type MockPromotionRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockPromotionRepositoryImplMockRecorder is the mock recorder for MockPromotionRepositoryImpl.
+type MockPromotionRepositoryImplMockRecorder struct {
+	mock *MockPromotionRepositoryImpl
+}
+
+// NewMockPromotionRepositoryImpl creates a new mock instance.
+func NewMockPromotionRepositoryImpl(ctrl *gomock.Controller) *MockPromotionRepositoryImpl {
+	mock := &MockPromotionRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepositoryImpl) EXPECT() *MockPromotionRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPromotionRepositoryImpl) Create(ctx context.Context, req *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPromotionRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockPromotionRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPromotionRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockPromotionRepositoryImpl) List(ctx context.Context) ([]*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPromotionRepositoryImplMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).List), ctx)
+}
+
+// ListValidAt mocks base method.
+func (m *MockPromotionRepositoryImpl) ListValidAt(ctx context.Context, at time.Time) ([]*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListValidAt", ctx, at)
+	ret0, _ := ret[0].([]*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListValidAt indicates an expected call of ListValidAt.
+func (mr *MockPromotionRepositoryImplMockRecorder) ListValidAt(ctx, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListValidAt", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).ListValidAt), ctx, at)
+}
+
+// Update mocks base method.
+func (m *MockPromotionRepositoryImpl) Update(ctx context.Context, req *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotionRepositoryImpl)(nil).Update), ctx, req)
+}
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/publisher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/publisher.go -destination=./shared/mock/repository/publisher_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPublisherRepositoryImpl is a mock of PublisherRepositoryImpl interface.
+type MockPublisherRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherRepositoryImplMockRecorder is the mock recorder for MockPublisherRepositoryImpl.
+type MockPublisherRepositoryImplMockRecorder struct {
+	mock *MockPublisherRepositoryImpl
+}
+
+// NewMockPublisherRepositoryImpl creates a new mock instance.
+func NewMockPublisherRepositoryImpl(ctrl *gomock.Controller) *MockPublisherRepositoryImpl {
+	mock := &MockPublisherRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockPublisherRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisherRepositoryImpl) EXPECT() *MockPublisherRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPublisherRepositoryImpl) Create(ctx context.Context, req *domain.Publisher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPublisherRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockPublisherRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPublisherRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// GetBookIDs mocks base method.
+func (m *MockPublisherRepositoryImpl) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookIDs", ctx, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookIDs indicates an expected call of GetBookIDs.
+func (mr *MockPublisherRepositoryImplMockRecorder) GetBookIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookIDs", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).GetBookIDs), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockPublisherRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPublisherRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByName mocks base method.
+func (m *MockPublisherRepositoryImpl) GetByName(ctx context.Context, name string) (*domain.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*domain.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockPublisherRepositoryImplMockRecorder) GetByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).GetByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockPublisherRepositoryImpl) List(ctx context.Context) ([]*domain.Publisher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Publisher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPublisherRepositoryImplMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockPublisherRepositoryImpl) Update(ctx context.Context, req *domain.Publisher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPublisherRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPublisherRepositoryImpl)(nil).Update), ctx, req)
+}
@@ -0,0 +1,193 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/purchase_order.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/purchase_order.go -destination=./shared/mock/repository/purchase_order_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	money "github.com/imanudd/inventorySvc-clean-architecture/pkg/money"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPurchaseOrderRepositoryImpl is a mock of PurchaseOrderRepositoryImpl interface.
+type MockPurchaseOrderRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurchaseOrderRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockPurchaseOrderRepositoryImplMockRecorder is the mock recorder for MockPurchaseOrderRepositoryImpl.
+type MockPurchaseOrderRepositoryImplMockRecorder struct {
+	mock *MockPurchaseOrderRepositoryImpl
+}
+
+// NewMockPurchaseOrderRepositoryImpl creates a new mock instance.
+func NewMockPurchaseOrderRepositoryImpl(ctrl *gomock.Controller) *MockPurchaseOrderRepositoryImpl {
+	mock := &MockPurchaseOrderRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockPurchaseOrderRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurchaseOrderRepositoryImpl) EXPECT() *MockPurchaseOrderRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) Create(ctx context.Context, req *domain.PurchaseOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// CreateReceipt mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) CreateReceipt(ctx context.Context, req *domain.GoodsReceipt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReceipt", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateReceipt indicates an expected call of CreateReceipt.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) CreateReceipt(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReceipt", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).CreateReceipt), ctx, req)
+}
+
+// ExistsForSupplier mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) ExistsForSupplier(ctx context.Context, supplierID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsForSupplier", ctx, supplierID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsForSupplier indicates an expected call of ExistsForSupplier.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) ExistsForSupplier(ctx, supplierID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsForSupplier", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).ExistsForSupplier), ctx, supplierID)
+}
+
+// GetByID mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) GetByIDForUpdate(ctx context.Context, id int) (*domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// LastUnitCost mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) LastUnitCost(ctx context.Context, supplierID, bookID int) (*money.Money, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastUnitCost", ctx, supplierID, bookID)
+	ret0, _ := ret[0].(*money.Money)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastUnitCost indicates an expected call of LastUnitCost.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) LastUnitCost(ctx, supplierID, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUnitCost", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).LastUnitCost), ctx, supplierID, bookID)
+}
+
+// List mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) List(ctx context.Context, filter *domain.PurchaseOrderFilter) ([]*domain.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).List), ctx, filter)
+}
+
+// ReplaceLines mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) ReplaceLines(ctx context.Context, orderID int, lines []*domain.PurchaseOrderLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceLines", ctx, orderID, lines)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceLines indicates an expected call of ReplaceLines.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) ReplaceLines(ctx, orderID, lines any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceLines", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).ReplaceLines), ctx, orderID, lines)
+}
+
+// Update mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) Update(ctx context.Context, req *domain.PurchaseOrder, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).Update), varargs...)
+}
+
+// UpdateLineReceived mocks base method.
+func (m *MockPurchaseOrderRepositoryImpl) UpdateLineReceived(ctx context.Context, line *domain.PurchaseOrderLine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLineReceived", ctx, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLineReceived indicates an expected call of UpdateLineReceived.
+func (mr *MockPurchaseOrderRepositoryImplMockRecorder) UpdateLineReceived(ctx, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLineReceived", reflect.TypeOf((*MockPurchaseOrderRepositoryImpl)(nil).UpdateLineReceived), ctx, line)
+}
@@ -40,6 +40,20 @@ func (m *MockRepositoryImpl) EXPECT() *MockRepositoryImplMockRecorder {
 	return m.recorder
 }
 
+// GetAnalyticsRepo mocks base method.
+func (m *MockRepositoryImpl) GetAnalyticsRepo() repository.AnalyticsRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnalyticsRepo")
+	ret0, _ := ret[0].(repository.AnalyticsRepositoryImpl)
+	return ret0
+}
+
+// GetAnalyticsRepo indicates an expected call of GetAnalyticsRepo.
+func (mr *MockRepositoryImplMockRecorder) GetAnalyticsRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnalyticsRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetAnalyticsRepo))
+}
+
 // GetAuthorRepo mocks base method.
 func (m *MockRepositoryImpl) GetAuthorRepo() repository.AuthorRepositoryImpl {
 	m.ctrl.T.Helper()
@@ -68,6 +82,202 @@ func (mr *MockRepositoryImplMockRecorder) GetBookRepo() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetBookRepo))
 }
 
+// GetCategoryRepo mocks base method.
+func (m *MockRepositoryImpl) GetCategoryRepo() repository.CategoryRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryRepo")
+	ret0, _ := ret[0].(repository.CategoryRepositoryImpl)
+	return ret0
+}
+
+// GetCategoryRepo indicates an expected call of GetCategoryRepo.
+func (mr *MockRepositoryImplMockRecorder) GetCategoryRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetCategoryRepo))
+}
+
+// GetCycleCountRepo mocks base method.
+func (m *MockRepositoryImpl) GetCycleCountRepo() repository.CycleCountRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCycleCountRepo")
+	ret0, _ := ret[0].(repository.CycleCountRepositoryImpl)
+	return ret0
+}
+
+// GetCycleCountRepo indicates an expected call of GetCycleCountRepo.
+func (mr *MockRepositoryImplMockRecorder) GetCycleCountRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCycleCountRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetCycleCountRepo))
+}
+
+// GetForecastRepo mocks base method.
+func (m *MockRepositoryImpl) GetForecastRepo() repository.ForecastRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetForecastRepo")
+	ret0, _ := ret[0].(repository.ForecastRepositoryImpl)
+	return ret0
+}
+
+// GetForecastRepo indicates an expected call of GetForecastRepo.
+func (mr *MockRepositoryImplMockRecorder) GetForecastRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForecastRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetForecastRepo))
+}
+
+// GetIdempotencyRepo mocks base method.
+func (m *MockRepositoryImpl) GetIdempotencyRepo() repository.IdempotencyRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyRepo")
+	ret0, _ := ret[0].(repository.IdempotencyRepositoryImpl)
+	return ret0
+}
+
+// GetIdempotencyRepo indicates an expected call of GetIdempotencyRepo.
+func (mr *MockRepositoryImplMockRecorder) GetIdempotencyRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetIdempotencyRepo))
+}
+
+// GetOrderRepo mocks base method.
+func (m *MockRepositoryImpl) GetOrderRepo() repository.OrderRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderRepo")
+	ret0, _ := ret[0].(repository.OrderRepositoryImpl)
+	return ret0
+}
+
+// GetOrderRepo indicates an expected call of GetOrderRepo.
+func (mr *MockRepositoryImplMockRecorder) GetOrderRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetOrderRepo))
+}
+
+// GetPriceRepo mocks base method.
+func (m *MockRepositoryImpl) GetPriceRepo() repository.PriceRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceRepo")
+	ret0, _ := ret[0].(repository.PriceRepositoryImpl)
+	return ret0
+}
+
+// GetPriceRepo indicates an expected call of GetPriceRepo.
+func (mr *MockRepositoryImplMockRecorder) GetPriceRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetPriceRepo))
+}
+
+// GetPromotionRepo mocks base method.
+func (m *MockRepositoryImpl) GetPromotionRepo() repository.PromotionRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromotionRepo")
+	ret0, _ := ret[0].(repository.PromotionRepositoryImpl)
+	return ret0
+}
+
+// GetPromotionRepo indicates an expected call of GetPromotionRepo.
+func (mr *MockRepositoryImplMockRecorder) GetPromotionRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromotionRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetPromotionRepo))
+}
+
+// GetPublisherRepo mocks base method.
+func (m *MockRepositoryImpl) GetPublisherRepo() repository.PublisherRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublisherRepo")
+	ret0, _ := ret[0].(repository.PublisherRepositoryImpl)
+	return ret0
+}
+
+// GetPublisherRepo indicates an expected call of GetPublisherRepo.
+func (mr *MockRepositoryImplMockRecorder) GetPublisherRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublisherRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetPublisherRepo))
+}
+
+// GetPurchaseOrderRepo mocks base method.
+func (m *MockRepositoryImpl) GetPurchaseOrderRepo() repository.PurchaseOrderRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderRepo")
+	ret0, _ := ret[0].(repository.PurchaseOrderRepositoryImpl)
+	return ret0
+}
+
+// GetPurchaseOrderRepo indicates an expected call of GetPurchaseOrderRepo.
+func (mr *MockRepositoryImplMockRecorder) GetPurchaseOrderRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetPurchaseOrderRepo))
+}
+
+// GetRMARepo mocks base method.
+func (m *MockRepositoryImpl) GetRMARepo() repository.RMARepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRMARepo")
+	ret0, _ := ret[0].(repository.RMARepositoryImpl)
+	return ret0
+}
+
+// GetRMARepo indicates an expected call of GetRMARepo.
+func (mr *MockRepositoryImplMockRecorder) GetRMARepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRMARepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetRMARepo))
+}
+
+// GetStockAlertRepo mocks base method.
+func (m *MockRepositoryImpl) GetStockAlertRepo() repository.StockAlertRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockAlertRepo")
+	ret0, _ := ret[0].(repository.StockAlertRepositoryImpl)
+	return ret0
+}
+
+// GetStockAlertRepo indicates an expected call of GetStockAlertRepo.
+func (mr *MockRepositoryImplMockRecorder) GetStockAlertRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockAlertRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetStockAlertRepo))
+}
+
+// GetStockRepo mocks base method.
+func (m *MockRepositoryImpl) GetStockRepo() repository.StockRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockRepo")
+	ret0, _ := ret[0].(repository.StockRepositoryImpl)
+	return ret0
+}
+
+// GetStockRepo indicates an expected call of GetStockRepo.
+func (mr *MockRepositoryImplMockRecorder) GetStockRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetStockRepo))
+}
+
+// GetSupplierRepo mocks base method.
+func (m *MockRepositoryImpl) GetSupplierRepo() repository.SupplierRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplierRepo")
+	ret0, _ := ret[0].(repository.SupplierRepositoryImpl)
+	return ret0
+}
+
+// GetSupplierRepo indicates an expected call of GetSupplierRepo.
+func (mr *MockRepositoryImplMockRecorder) GetSupplierRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplierRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetSupplierRepo))
+}
+
+// GetTagRepo mocks base method.
+func (m *MockRepositoryImpl) GetTagRepo() repository.TagRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTagRepo")
+	ret0, _ := ret[0].(repository.TagRepositoryImpl)
+	return ret0
+}
+
+// GetTagRepo indicates an expected call of GetTagRepo.
+func (mr *MockRepositoryImplMockRecorder) GetTagRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTagRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetTagRepo))
+}
+
 // GetTransactionRepo mocks base method.
 func (m *MockRepositoryImpl) GetTransactionRepo() repository.TransactionRepositoryImpl {
 	m.ctrl.T.Helper()
@@ -95,3 +305,17 @@ func (mr *MockRepositoryImplMockRecorder) GetUserRepo() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetUserRepo))
 }
+
+// GetValuationRepo mocks base method.
+func (m *MockRepositoryImpl) GetValuationRepo() repository.ValuationRepositoryImpl {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValuationRepo")
+	ret0, _ := ret[0].(repository.ValuationRepositoryImpl)
+	return ret0
+}
+
+// GetValuationRepo indicates an expected call of GetValuationRepo.
+func (mr *MockRepositoryImplMockRecorder) GetValuationRepo() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuationRepo", reflect.TypeOf((*MockRepositoryImpl)(nil).GetValuationRepo))
+}
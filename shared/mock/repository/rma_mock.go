@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/rma.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/rma.go -destination=./shared/mock/repository/rma_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRMARepositoryImpl is a mock of RMARepositoryImpl interface.
+type MockRMARepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockRMARepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockRMARepositoryImplMockRecorder is the mock recorder for MockRMARepositoryImpl.
+type MockRMARepositoryImplMockRecorder struct {
+	mock *MockRMARepositoryImpl
+}
+
+// NewMockRMARepositoryImpl creates a new mock instance.
+func NewMockRMARepositoryImpl(ctrl *gomock.Controller) *MockRMARepositoryImpl {
+	mock := &MockRMARepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockRMARepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRMARepositoryImpl) EXPECT() *MockRMARepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRMARepositoryImpl) Create(ctx context.Context, req *domain.RMA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRMARepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRMARepositoryImpl)(nil).Create), ctx, req)
+}
+
+// ExistsForOrder mocks base method.
+func (m *MockRMARepositoryImpl) ExistsForOrder(ctx context.Context, orderID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsForOrder", ctx, orderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsForOrder indicates an expected call of ExistsForOrder.
+func (mr *MockRMARepositoryImplMockRecorder) ExistsForOrder(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsForOrder", reflect.TypeOf((*MockRMARepositoryImpl)(nil).ExistsForOrder), ctx, orderID)
+}
+
+// GetByID mocks base method.
+func (m *MockRMARepositoryImpl) GetByID(ctx context.Context, id int) (*domain.RMA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.RMA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRMARepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRMARepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockRMARepositoryImpl) GetByIDForUpdate(ctx context.Context, id int) (*domain.RMA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*domain.RMA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockRMARepositoryImplMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockRMARepositoryImpl)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockRMARepositoryImpl) List(ctx context.Context, filter *domain.RMAFilter) ([]*domain.RMA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*domain.RMA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRMARepositoryImplMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRMARepositoryImpl)(nil).List), ctx, filter)
+}
+
+// ReturnedQuantities mocks base method.
+func (m *MockRMARepositoryImpl) ReturnedQuantities(ctx context.Context, orderID int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReturnedQuantities", ctx, orderID)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReturnedQuantities indicates an expected call of ReturnedQuantities.
+func (mr *MockRMARepositoryImplMockRecorder) ReturnedQuantities(ctx, orderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReturnedQuantities", reflect.TypeOf((*MockRMARepositoryImpl)(nil).ReturnedQuantities), ctx, orderID)
+}
+
+// Update mocks base method.
+func (m *MockRMARepositoryImpl) Update(ctx context.Context, req *domain.RMA, columns ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, req}
+	for _, a := range columns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRMARepositoryImplMockRecorder) Update(ctx, req any, columns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, req}, columns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRMARepositoryImpl)(nil).Update), varargs...)
+}
+
+// UpdateLine mocks base method.
+func (m *MockRMARepositoryImpl) UpdateLine(ctx context.Context, line *domain.RMALine) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLine", ctx, line)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLine indicates an expected call of UpdateLine.
+func (mr *MockRMARepositoryImplMockRecorder) UpdateLine(ctx, line any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLine", reflect.TypeOf((*MockRMARepositoryImpl)(nil).UpdateLine), ctx, line)
+}
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/stock_alert.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/stock_alert.go -destination=./shared/mock/repository/stock_alert_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockAlertRepositoryImpl is a mock of StockAlertRepositoryImpl interface.
+type MockStockAlertRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockAlertRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockStockAlertRepositoryImplMockRecorder is the mock recorder for MockStockAlertRepositoryImpl.
+type MockStockAlertRepositoryImplMockRecorder struct {
+	mock *MockStockAlertRepositoryImpl
+}
+
+// NewMockStockAlertRepositoryImpl creates a new mock instance.
+func NewMockStockAlertRepositoryImpl(ctrl *gomock.Controller) *MockStockAlertRepositoryImpl {
+	mock := &MockStockAlertRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockStockAlertRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockAlertRepositoryImpl) EXPECT() *MockStockAlertRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// CreateAlert mocks base method.
+func (m *MockStockAlertRepositoryImpl) CreateAlert(ctx context.Context, req *domain.StockAlert) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, req)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockStockAlertRepositoryImplMockRecorder) CreateAlert(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).CreateAlert), ctx, req)
+}
+
+// DeleteLocationReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) DeleteLocationReorderPoint(ctx context.Context, location string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocationReorderPoint", ctx, location)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocationReorderPoint indicates an expected call of DeleteLocationReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) DeleteLocationReorderPoint(ctx, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocationReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).DeleteLocationReorderPoint), ctx, location)
+}
+
+// DeleteReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) DeleteReorderPoint(ctx context.Context, bookID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReorderPoint", ctx, bookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReorderPoint indicates an expected call of DeleteReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) DeleteReorderPoint(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).DeleteReorderPoint), ctx, bookID)
+}
+
+// GetLocationReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) GetLocationReorderPoint(ctx context.Context, location string) (*domain.LocationReorderPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocationReorderPoint", ctx, location)
+	ret0, _ := ret[0].(*domain.LocationReorderPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocationReorderPoint indicates an expected call of GetLocationReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) GetLocationReorderPoint(ctx, location any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocationReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).GetLocationReorderPoint), ctx, location)
+}
+
+// GetReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) GetReorderPoint(ctx context.Context, bookID int) (*domain.ReorderPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReorderPoint", ctx, bookID)
+	ret0, _ := ret[0].(*domain.ReorderPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReorderPoint indicates an expected call of GetReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) GetReorderPoint(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).GetReorderPoint), ctx, bookID)
+}
+
+// ListAlerts mocks base method.
+func (m *MockStockAlertRepositoryImpl) ListAlerts(ctx context.Context, filter *domain.StockAlertFilter) ([]*domain.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAlerts", ctx, filter)
+	ret0, _ := ret[0].([]*domain.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAlerts indicates an expected call of ListAlerts.
+func (mr *MockStockAlertRepositoryImplMockRecorder) ListAlerts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAlerts", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).ListAlerts), ctx, filter)
+}
+
+// ListLowStock mocks base method.
+func (m *MockStockAlertRepositoryImpl) ListLowStock(ctx context.Context) ([]*domain.LowStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLowStock", ctx)
+	ret0, _ := ret[0].([]*domain.LowStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStock indicates an expected call of ListLowStock.
+func (mr *MockStockAlertRepositoryImplMockRecorder) ListLowStock(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStock", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).ListLowStock), ctx)
+}
+
+// ResolveRecovered mocks base method.
+func (m *MockStockAlertRepositoryImpl) ResolveRecovered(ctx context.Context, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRecovered", ctx, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveRecovered indicates an expected call of ResolveRecovered.
+func (mr *MockStockAlertRepositoryImplMockRecorder) ResolveRecovered(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRecovered", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).ResolveRecovered), ctx, now)
+}
+
+// SetAlertPurchaseOrder mocks base method.
+func (m *MockStockAlertRepositoryImpl) SetAlertPurchaseOrder(ctx context.Context, alertIDs []int, purchaseOrderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlertPurchaseOrder", ctx, alertIDs, purchaseOrderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlertPurchaseOrder indicates an expected call of SetAlertPurchaseOrder.
+func (mr *MockStockAlertRepositoryImplMockRecorder) SetAlertPurchaseOrder(ctx, alertIDs, purchaseOrderID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlertPurchaseOrder", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).SetAlertPurchaseOrder), ctx, alertIDs, purchaseOrderID)
+}
+
+// SetLocationReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) SetLocationReorderPoint(ctx context.Context, req *domain.LocationReorderPoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocationReorderPoint", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocationReorderPoint indicates an expected call of SetLocationReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) SetLocationReorderPoint(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocationReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).SetLocationReorderPoint), ctx, req)
+}
+
+// SetReorderPoint mocks base method.
+func (m *MockStockAlertRepositoryImpl) SetReorderPoint(ctx context.Context, req *domain.ReorderPoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReorderPoint", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReorderPoint indicates an expected call of SetReorderPoint.
+func (mr *MockStockAlertRepositoryImplMockRecorder) SetReorderPoint(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReorderPoint", reflect.TypeOf((*MockStockAlertRepositoryImpl)(nil).SetReorderPoint), ctx, req)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/stock.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/stock.go -destination=./shared/mock/repository/stock_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStockRepositoryImpl is a mock of StockRepositoryImpl interface.
+type MockStockRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockStockRepositoryImplMockRecorder is the mock recorder for MockStockRepositoryImpl.
+type MockStockRepositoryImplMockRecorder struct {
+	mock *MockStockRepositoryImpl
+}
+
+// NewMockStockRepositoryImpl creates a new mock instance.
+func NewMockStockRepositoryImpl(ctrl *gomock.Controller) *MockStockRepositoryImpl {
+	mock := &MockStockRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepositoryImpl) EXPECT() *MockStockRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// ListMovements mocks base method.
+func (m *MockStockRepositoryImpl) ListMovements(ctx context.Context, bookID int) ([]*domain.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, bookID)
+	ret0, _ := ret[0].([]*domain.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockStockRepositoryImplMockRecorder) ListMovements(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockStockRepositoryImpl)(nil).ListMovements), ctx, bookID)
+}
+
+// Record mocks base method.
+func (m *MockStockRepositoryImpl) Record(ctx context.Context, movements []*domain.StockMovement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, movements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockStockRepositoryImplMockRecorder) Record(ctx, movements any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockStockRepositoryImpl)(nil).Record), ctx, movements)
+}
+
+// Release mocks base method.
+func (m *MockStockRepositoryImpl) Release(ctx context.Context, bookID, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, bookID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockStockRepositoryImplMockRecorder) Release(ctx, bookID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockStockRepositoryImpl)(nil).Release), ctx, bookID, quantity)
+}
+
+// Reserve mocks base method.
+func (m *MockStockRepositoryImpl) Reserve(ctx context.Context, bookID, quantity int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, bookID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockStockRepositoryImplMockRecorder) Reserve(ctx, bookID, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockStockRepositoryImpl)(nil).Reserve), ctx, bookID, quantity)
+}
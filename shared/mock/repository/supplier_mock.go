@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/supplier.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/supplier.go -destination=./shared/mock/repository/supplier_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSupplierRepositoryImpl is a mock of SupplierRepositoryImpl interface.
+type MockSupplierRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockSupplierRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockSupplierRepositoryImplMockRecorder is the mock recorder for MockSupplierRepositoryImpl.
+type MockSupplierRepositoryImplMockRecorder struct {
+	mock *MockSupplierRepositoryImpl
+}
+
+// NewMockSupplierRepositoryImpl creates a new mock instance.
+func NewMockSupplierRepositoryImpl(ctrl *gomock.Controller) *MockSupplierRepositoryImpl {
+	mock := &MockSupplierRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockSupplierRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSupplierRepositoryImpl) EXPECT() *MockSupplierRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSupplierRepositoryImpl) Create(ctx context.Context, req *domain.Supplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSupplierRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockSupplierRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSupplierRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockSupplierRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSupplierRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByName mocks base method.
+func (m *MockSupplierRepositoryImpl) GetByName(ctx context.Context, name string) (*domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockSupplierRepositoryImplMockRecorder) GetByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).GetByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockSupplierRepositoryImpl) List(ctx context.Context) ([]*domain.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockSupplierRepositoryImplMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockSupplierRepositoryImpl) Update(ctx context.Context, req *domain.Supplier) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSupplierRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSupplierRepositoryImpl)(nil).Update), ctx, req)
+}
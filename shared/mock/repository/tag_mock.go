@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/tag.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/tag.go -destination=./shared/mock/repository/tag_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagRepositoryImpl is a mock of TagRepositoryImpl interface.
+type MockTagRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockTagRepositoryImplMockRecorder is the mock recorder for MockTagRepositoryImpl.
+type MockTagRepositoryImplMockRecorder struct {
+	mock *MockTagRepositoryImpl
+}
+
+// NewMockTagRepositoryImpl creates a new mock instance.
+func NewMockTagRepositoryImpl(ctrl *gomock.Controller) *MockTagRepositoryImpl {
+	mock := &MockTagRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepositoryImpl) EXPECT() *MockTagRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTagRepositoryImpl) Create(ctx context.Context, req *domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagRepositoryImplMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTagRepositoryImpl)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockTagRepositoryImpl) Delete(ctx context.Context, id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagRepositoryImplMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagRepositoryImpl)(nil).Delete), ctx, id)
+}
+
+// FindOrCreate mocks base method.
+func (m *MockTagRepositoryImpl) FindOrCreate(ctx context.Context, names []string) ([]*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindOrCreate", ctx, names)
+	ret0, _ := ret[0].([]*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOrCreate indicates an expected call of FindOrCreate.
+func (mr *MockTagRepositoryImplMockRecorder) FindOrCreate(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOrCreate", reflect.TypeOf((*MockTagRepositoryImpl)(nil).FindOrCreate), ctx, names)
+}
+
+// GetBookIDs mocks base method.
+func (m *MockTagRepositoryImpl) GetBookIDs(ctx context.Context, id int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookIDs", ctx, id)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookIDs indicates an expected call of GetBookIDs.
+func (mr *MockTagRepositoryImplMockRecorder) GetBookIDs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookIDs", reflect.TypeOf((*MockTagRepositoryImpl)(nil).GetBookIDs), ctx, id)
+}
+
+// GetByID mocks base method.
+func (m *MockTagRepositoryImpl) GetByID(ctx context.Context, id int) (*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTagRepositoryImplMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTagRepositoryImpl)(nil).GetByID), ctx, id)
+}
+
+// GetByName mocks base method.
+func (m *MockTagRepositoryImpl) GetByName(ctx context.Context, name string) (*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", ctx, name)
+	ret0, _ := ret[0].(*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockTagRepositoryImplMockRecorder) GetByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockTagRepositoryImpl)(nil).GetByName), ctx, name)
+}
+
+// List mocks base method.
+func (m *MockTagRepositoryImpl) List(ctx context.Context) ([]*domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagRepositoryImplMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagRepositoryImpl)(nil).List), ctx)
+}
+
+// Update mocks base method.
+func (m *MockTagRepositoryImpl) Update(ctx context.Context, req *domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagRepositoryImplMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTagRepositoryImpl)(nil).Update), ctx, req)
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	repository "github.com/imanudd/inventorySvc-clean-architecture/internal/repository"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,15 +42,20 @@ func (m *MockTransactionRepositoryImpl) EXPECT() *MockTransactionRepositoryImplM
 }
 
 // WithTransaction mocks base method.
-func (m *MockTransactionRepositoryImpl) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+func (m *MockTransactionRepositoryImpl) WithTransaction(ctx context.Context, fn func(context.Context) error, opts ...repository.TxOption) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "WithTransaction", ctx, fn)
+	varargs := []any{ctx, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WithTransaction", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // WithTransaction indicates an expected call of WithTransaction.
-func (mr *MockTransactionRepositoryImplMockRecorder) WithTransaction(ctx, fn any) *gomock.Call {
+func (mr *MockTransactionRepositoryImplMockRecorder) WithTransaction(ctx, fn any, opts ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockTransactionRepositoryImpl)(nil).WithTransaction), ctx, fn)
+	varargs := append([]any{ctx, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithTransaction", reflect.TypeOf((*MockTransactionRepositoryImpl)(nil).WithTransaction), varargs...)
 }
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/valuation.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/valuation.go -destination=./shared/mock/repository/valuation_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/imanudd/inventorySvc-clean-architecture/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockValuationRepositoryImpl is a mock of ValuationRepositoryImpl interface.
+type MockValuationRepositoryImpl struct {
+	ctrl     *gomock.Controller
+	recorder *MockValuationRepositoryImplMockRecorder
+	isgomock struct{}
+}
+
+// MockValuationRepositoryImplMockRecorder is the mock recorder for MockValuationRepositoryImpl.
+type MockValuationRepositoryImplMockRecorder struct {
+	mock *MockValuationRepositoryImpl
+}
+
+// NewMockValuationRepositoryImpl creates a new mock instance.
+func NewMockValuationRepositoryImpl(ctrl *gomock.Controller) *MockValuationRepositoryImpl {
+	mock := &MockValuationRepositoryImpl{ctrl: ctrl}
+	mock.recorder = &MockValuationRepositoryImplMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockValuationRepositoryImpl) EXPECT() *MockValuationRepositoryImplMockRecorder {
+	return m.recorder
+}
+
+// ListMovements mocks base method.
+func (m *MockValuationRepositoryImpl) ListMovements(ctx context.Context, until time.Time) ([]*domain.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMovements", ctx, until)
+	ret0, _ := ret[0].([]*domain.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMovements indicates an expected call of ListMovements.
+func (mr *MockValuationRepositoryImplMockRecorder) ListMovements(ctx, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMovements", reflect.TypeOf((*MockValuationRepositoryImpl)(nil).ListMovements), ctx, until)
+}
+
+// ListRevenue mocks base method.
+func (m *MockValuationRepositoryImpl) ListRevenue(ctx context.Context, from, to time.Time) ([]*domain.SaleRevenue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevenue", ctx, from, to)
+	ret0, _ := ret[0].([]*domain.SaleRevenue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevenue indicates an expected call of ListRevenue.
+func (mr *MockValuationRepositoryImplMockRecorder) ListRevenue(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevenue", reflect.TypeOf((*MockValuationRepositoryImpl)(nil).ListRevenue), ctx, from, to)
+}